@@ -0,0 +1,154 @@
+// apikey API anahtari olusturmak, listelemek ve iptal etmek icin kucuk bir CLI.
+// Ilk admin anahtarini olusturmak icin kullanilir, sonrasi /api/v1/api-keys uzerinden de yapilabilir.
+//
+//	go run ./cmd/apikey create -name ops -scopes admin
+//	go run ./cmd/apikey create -name checkout -tenant <tenant-id> -scopes messages:write,messages:read
+//	go run ./cmd/apikey list
+//	go run ./cmd/apikey revoke -id <api-key-id>
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/usecases"
+	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+	"message-sending-service/internal/infrastructure/config"
+	"message-sending-service/internal/infrastructure/database"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fail("failed to load configuration: %v", err)
+	}
+
+	db, err := database.NewPostgreSQLConnection(cfg)
+	if err != nil {
+		fail("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	if err := database.CreateTables(db); err != nil {
+		fail("failed to create database tables: %v", err)
+	}
+
+	auditUseCase := usecases.NewAuditUseCase(database.NewAuditLogRepository(db), zap.NewNop())
+	apiKeyUseCase := usecases.NewAPIKeyUseCase(database.NewAPIKeyRepository(db), database.NewTenantRepository(db), auditUseCase, zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// CLI ile yapilan islemler de audit log'a isletim sistemi kullanicisi ile yazilir
+	ctx = entities.ContextWithActor(ctx, entities.Actor{ID: "cli", Name: os.Getenv("USER"), AuthMethod: "cli"})
+
+	switch os.Args[1] {
+	case "create":
+		create(ctx, apiKeyUseCase, os.Args[2:])
+	case "list":
+		list(ctx, apiKeyUseCase, os.Args[2:])
+	case "revoke":
+		revoke(ctx, apiKeyUseCase, os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func create(ctx context.Context, apiKeyUseCase domainUsecases.APIKeyUseCase, args []string) {
+	fs := flag.NewFlagSet("create", flag.ExitOnError)
+	name := fs.String("name", "", "key name (required)")
+	tenant := fs.String("tenant", entities.DefaultTenantID.String(), "tenant ID")
+	scopes := fs.String("scopes", "", "comma separated scopes: "+strings.Join(entities.AllScopes, ", "))
+	fs.Parse(args)
+
+	tenantID, err := uuid.Parse(*tenant)
+	if err != nil {
+		fail("invalid tenant ID: %v", err)
+	}
+
+	key, rawKey, err := apiKeyUseCase.CreateAPIKey(ctx, domainUsecases.CreateAPIKeyInput{
+		TenantID: tenantID,
+		Name:     *name,
+		Scopes:   splitScopes(*scopes),
+	})
+	if err != nil {
+		fail("failed to create api key: %v", err)
+	}
+
+	fmt.Printf("id:     %s\ntenant: %s\nscopes: %s\nkey:    %s\n\nStore the key now, it cannot be shown again.\n",
+		key.ID, key.TenantID, strings.Join(key.Scopes, ","), rawKey)
+}
+
+func list(ctx context.Context, apiKeyUseCase domainUsecases.APIKeyUseCase, args []string) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	page := fs.Int("page", 1, "page number")
+	limit := fs.Int("limit", 50, "items per page")
+	fs.Parse(args)
+
+	keys, err := apiKeyUseCase.GetAPIKeys(ctx, *page, *limit)
+	if err != nil {
+		fail("failed to list api keys: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tPREFIX\tNAME\tTENANT\tSCOPES\tSTATUS")
+	for _, key := range keys {
+		status := "active"
+		if key.IsRevoked() {
+			status = "revoked"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", key.ID, key.Prefix, key.Name, key.TenantID, strings.Join(key.Scopes, ","), status)
+	}
+	w.Flush()
+}
+
+func revoke(ctx context.Context, apiKeyUseCase domainUsecases.APIKeyUseCase, args []string) {
+	fs := flag.NewFlagSet("revoke", flag.ExitOnError)
+	id := fs.String("id", "", "API key ID (required)")
+	fs.Parse(args)
+
+	keyID, err := uuid.Parse(*id)
+	if err != nil {
+		fail("invalid API key ID: %v", err)
+	}
+
+	if err := apiKeyUseCase.RevokeAPIKey(ctx, keyID); err != nil {
+		fail("failed to revoke api key: %v", err)
+	}
+
+	fmt.Printf("revoked %s\n", keyID)
+}
+
+func splitScopes(value string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(value, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: apikey <create|list|revoke> [flags]")
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -0,0 +1,65 @@
+// fakeprovider http adapter'inin konustugu mesaj API'sini taklit eden bagimsiz sunucu. Servis
+// MESSAGE_API_URL=http://localhost:9090/send ile buna yonlendirilir; gelen istekler, senaryolar ve
+// gonderilen teslim raporlari /_fake altindan incelenir (README "Fake Provider").
+//
+//	go run ./cmd/fakeprovider -addr :9090
+//	go run ./cmd/fakeprovider -receipt-url http://localhost:8080/api/v1/delivery-receipts/default -receipt-api-key <key>
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/infrastructure/external/fakeprovider"
+	"message-sending-service/pkg/logger"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "listen address")
+	receiptURL := flag.String("receipt-url", "", "delivery receipt endpoint used when a request has no callback_url")
+	receiptAPIKey := flag.String("receipt-api-key", "", "API key (receipts:write) sent with delivery receipts")
+	receiptDelay := flag.Duration("receipt-delay", 2*time.Second, "wait before posting a delivery receipt")
+	logLevel := flag.String("log-level", "info", "log level")
+	flag.Parse()
+
+	zapLogger, err := logger.NewLogger(*logLevel)
+	if err != nil {
+		panic(err)
+	}
+	defer zapLogger.Sync()
+
+	fake := fakeprovider.New(fakeprovider.Options{
+		ReceiptURL:    *receiptURL,
+		ReceiptAPIKey: *receiptAPIKey,
+		ReceiptDelay:  *receiptDelay,
+		Logger:        zapLogger,
+	})
+	defer fake.Close()
+
+	server := &http.Server{Addr: *addr, Handler: fake}
+
+	go func() {
+		zapLogger.Info("Starting fake provider", zap.String("addr", *addr), zap.String("receipt_url", *receiptURL))
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			zapLogger.Fatal("Failed to start fake provider", zap.Error(err))
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		zapLogger.Error("Fake provider forced to shutdown", zap.Error(err))
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"io"
 	"log"
 	"net/http"
 	"os"
@@ -14,13 +15,20 @@ import (
 	"go.uber.org/zap"
 
 	_ "message-sending-service/docs"
+	"message-sending-service/internal/application/dispatch"
 	"message-sending-service/internal/application/handlers"
+	"message-sending-service/internal/application/middlewares"
+	"message-sending-service/internal/application/policy"
 	"message-sending-service/internal/application/usecases"
+	"message-sending-service/internal/domain/entities"
 	"message-sending-service/internal/domain/repositories"
+	"message-sending-service/internal/domain/services"
 	domainUsecases "message-sending-service/internal/domain/usecases"
+	infraAuth "message-sending-service/internal/infrastructure/auth"
 	"message-sending-service/internal/infrastructure/config"
 	"message-sending-service/internal/infrastructure/database"
 	"message-sending-service/internal/infrastructure/external"
+	"message-sending-service/internal/infrastructure/pricing"
 	infraRedis "message-sending-service/internal/infrastructure/redis"
 	httpPresentation "message-sending-service/internal/presentation/http"
 	"message-sending-service/pkg/logger"
@@ -42,6 +50,10 @@ import (
 // @BasePath /api/v1
 
 // @schemes http https
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
 func main() {
 	cfg, err := config.Load()
 	if err != nil {
@@ -115,36 +127,161 @@ func main() {
 		zapLogger.Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
+	// kalici baglanti tutan provider'lar (smpp) unbind ile kapanir
+	for _, messageProvider := range app.messageProviders {
+		if closer, ok := messageProvider.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				zapLogger.Error("Failed to close message provider", zap.String("provider", messageProvider.Name()), zap.Error(err))
+			}
+		}
+	}
+
 	zapLogger.Info("Server exited")
 }
 
+// twilioAccounts CALLBACK_URL verilmis twilio provider'lari; StatusCallback sadece bunlara gonderilir
+func twilioAccounts(cfg *config.Config) map[string]handlers.TwilioAccount {
+	accounts := make(map[string]handlers.TwilioAccount)
+	for _, providerConfig := range cfg.External.ProviderList() {
+		if providerConfig.Adapter == external.AdapterTwilio && providerConfig.CallbackURL != "" {
+			accounts[providerConfig.Name] = handlers.TwilioAccount{AuthToken: providerConfig.Password, CallbackURL: providerConfig.CallbackURL}
+		}
+	}
+	return accounts
+}
+
+// buildAuthenticators AUTH_MODE'a gore api key ve/veya JWT dogrulayicilarini kurar
+func buildAuthenticators(cfg *config.Config, apiKeyUseCase domainUsecases.APIKeyUseCase, logger *zap.Logger) []middlewares.Authenticator {
+	var authenticators []middlewares.Authenticator
+
+	if cfg.Auth.UsesAPIKeys() {
+		authenticators = append(authenticators, middlewares.APIKeyAuthenticator(apiKeyUseCase))
+	}
+
+	if cfg.Auth.UsesJWT() {
+		jwks := infraAuth.NewJWKS(cfg.Auth.JWT, logger)
+		if err := jwks.Refresh(context.Background()); err != nil {
+			logger.Fatal("Failed to load JWKS", zap.Error(err))
+		}
+		jwks.StartRefresh(context.Background())
+		authenticators = append(authenticators, middlewares.JWTAuthenticator(infraAuth.NewJWTVerifier(jwks, cfg.Auth.JWT)))
+	}
+
+	if len(authenticators) == 0 && cfg.Auth.Enabled {
+		logger.Fatal("Unknown AUTH_MODE", zap.String("mode", cfg.Auth.Mode))
+	}
+
+	return authenticators
+}
+
 type App struct {
 	messageUseCase   domainUsecases.MessageUseCase
 	schedulerUseCase domainUsecases.SchedulerUseCase
+	messageProviders []services.MessageProvider
 	router           *httpPresentation.Router
 }
 
 func initializeApp(cfg *config.Config, db *sql.DB, redisClient *redis.Client, logger *zap.Logger) *App {
 	messageRepo := database.NewMessageRepository(db)
+	linkRepo := database.NewShortLinkRepository(db)
+	contactRepo := database.NewContactRepository(db)
+	contactListRepo := database.NewContactListRepository(db)
+	tenantRepo := database.NewTenantRepository(db)
+	apiKeyRepo := database.NewAPIKeyRepository(db)
+	auditRepo := database.NewAuditLogRepository(db)
+	usageRepo := database.NewUsageRepository(db)
+	quotaRepo := database.NewQuotaRepository(db)
+	routingRuleRepo := database.NewRoutingRuleRepository(db)
+	priceRepo := pricing.NewFilePriceRepository(cfg.Pricing.File)
 
 	var cacheRepo repositories.CacheRepository
+	// Redis yoksa rate limit sadece bu replika icin uygulanir
+	rateLimiter := dispatch.NewLocalRateLimiter(cfg.External.ProviderList())
 	if redisClient != nil {
 		cacheRepo = infraRedis.NewCacheRepository(redisClient)
+		rateLimiter = infraRedis.NewRateLimiter(redisClient, cfg.External.ProviderList())
+	}
+
+	providerRegistry := external.NewProviderRegistry(logger)
+	var messageProviders []services.MessageProvider
+	for _, providerConfig := range cfg.External.ProviderList() {
+		messageProvider, err := providerRegistry.Build(providerConfig)
+		if err != nil {
+			logger.Fatal("Invalid message provider configuration", zap.String("provider", providerConfig.Name), zap.Error(err))
+		}
+		messageProviders = append(messageProviders, messageProvider)
 	}
 
-	apiClient := external.NewMessageAPIClient(cfg)
+	contentPolicy, err := policy.NewChainFromConfig(cfg.Policy)
+	if err != nil {
+		logger.Fatal("Invalid content policy configuration", zap.Error(err))
+	}
 
-	messageUseCase := usecases.NewMessageUseCase(messageRepo, cacheRepo, apiClient, logger)
-	schedulerUseCase := usecases.NewSchedulerUseCase(messageUseCase, cacheRepo, cfg, logger)
+	auditUseCase := usecases.NewAuditUseCase(auditRepo, logger)
+	linkUseCase := usecases.NewLinkUseCase(linkRepo, cfg, logger)
+	usageUseCase := usecases.NewUsageUseCase(usageRepo, quotaRepo, tenantRepo, cfg, logger)
+	pricingUseCase := usecases.NewPricingUseCase(priceRepo, messageRepo, cfg, logger)
+	if err := pricingUseCase.ReloadPrices(context.Background()); err != nil {
+		logger.Fatal("Invalid price table", zap.Error(err))
+	}
+	routingUseCase := usecases.NewRoutingUseCase(routingRuleRepo, tenantRepo, auditUseCase, cfg, logger)
+	if err := routingUseCase.ReloadRules(context.Background()); err != nil {
+		logger.Fatal("Failed to load routing rules", zap.Error(err))
+	}
+	routingUseCase.StartReload(context.Background())
+	failover := dispatch.NewFailover(messageProviders, routingUseCase, rateLimiter, cfg.External, logger)
+	provider := dispatch.NewChannels(failover)
+	if cfg.Email.Enabled {
+		smtpProvider, err := external.NewSMTPProvider(cfg.Email)
+		if err != nil {
+			logger.Fatal("Invalid email configuration", zap.Error(err))
+		}
+		provider.Register(entities.MessageChannelEmail, smtpProvider)
+	}
+	if cfg.Webhook.Enabled {
+		if len(cfg.Webhook.AllowedHosts) == 0 {
+			logger.Warn("Webhook channel is enabled without WEBHOOK_ALLOWED_HOSTS, every webhook message will be rejected")
+		}
+		webhookProvider, err := external.NewWebhookProvider(cfg.Webhook)
+		if err != nil {
+			logger.Fatal("Invalid webhook configuration", zap.Error(err))
+		}
+		provider.Register(entities.MessageChannelWebhook, webhookProvider)
+	}
+	messageUseCase := usecases.NewMessageUseCase(messageRepo, tenantRepo, cacheRepo, provider, contentPolicy, linkUseCase, usageUseCase, pricingUseCase, auditUseCase, cfg, logger)
+	// SMPP gibi kendi baglantisindan teslim raporu iten provider'lar
+	for _, messageProvider := range messageProviders {
+		if source, ok := messageProvider.(services.DeliveryReceiptSource); ok {
+			source.OnDeliveryReceipt(messageUseCase.RecordDeliveryReceipt)
+		}
+	}
+	schedulerUseCase := usecases.NewSchedulerUseCase(messageUseCase, cacheRepo, failover, auditUseCase, cfg, logger)
+	contactUseCase := usecases.NewContactUseCase(contactRepo, contactListRepo, messageUseCase, logger)
+	tenantUseCase := usecases.NewTenantUseCase(tenantRepo, logger)
+	apiKeyUseCase := usecases.NewAPIKeyUseCase(apiKeyRepo, tenantRepo, auditUseCase, logger)
 
 	messageHandler := handlers.NewMessageHandler(messageUseCase, logger)
 	schedulerHandler := handlers.NewSchedulerHandler(schedulerUseCase, logger)
+	linkHandler := handlers.NewLinkHandler(linkUseCase, logger)
+	contactHandler := handlers.NewContactHandler(contactUseCase, logger)
+	tenantHandler := handlers.NewTenantHandler(tenantUseCase, logger)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyUseCase, logger)
+	auditHandler := handlers.NewAuditHandler(auditUseCase, logger)
+	usageHandler := handlers.NewUsageHandler(usageUseCase, logger)
+	pricingHandler := handlers.NewPricingHandler(pricingUseCase, logger)
+	routingHandler := handlers.NewRoutingHandler(routingUseCase, logger)
+	twilioHandler := handlers.NewTwilioCallbackHandler(messageUseCase, twilioAccounts(cfg), logger)
+
+	if !cfg.Auth.Enabled {
+		logger.Warn("Authentication is disabled, all /api/v1 routes are public")
+	}
 
-	router := httpPresentation.NewRouter(messageHandler, schedulerHandler, logger)
+	router := httpPresentation.NewRouter(messageHandler, schedulerHandler, linkHandler, contactHandler, tenantHandler, apiKeyHandler, auditHandler, usageHandler, pricingHandler, routingHandler, twilioHandler, tenantUseCase, buildAuthenticators(cfg, apiKeyUseCase, logger), cfg, logger)
 
 	return &App{
 		messageUseCase:   messageUseCase,
 		schedulerUseCase: schedulerUseCase,
+		messageProviders: messageProviders,
 		router:           router,
 	}
 }
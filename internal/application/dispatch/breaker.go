@@ -0,0 +1,147 @@
+package dispatch
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+// circuitBreaker bir provider'in devresini tutar. Closed'da art arda FailureThreshold gecici hata
+// devreyi acar; open'da provider cagrilmaz; OpenTimeout sonra half-open'da sinirli deneme yapilir.
+type circuitBreaker struct {
+	config config.CircuitBreakerConfig
+	name   string
+	logger *zap.Logger
+
+	mu        sync.Mutex
+	state     entities.CircuitState
+	failures  int
+	openedAt  time.Time
+	trials    int
+	successes int
+}
+
+func newCircuitBreaker(cfg config.CircuitBreakerConfig, name string, logger *zap.Logger) *circuitBreaker {
+	if cfg.HalfOpenRequests <= 0 {
+		cfg.HalfOpenRequests = 1
+	}
+	return &circuitBreaker{
+		config: cfg,
+		name:   name,
+		logger: logger,
+		state:  entities.CircuitClosed,
+	}
+}
+
+func (b *circuitBreaker) enabled() bool {
+	return b.config.FailureThreshold > 0
+}
+
+// allow false donerse provider cagrilmamali; half-open'da true donen her cagri record ya da release ile bitmeli
+func (b *circuitBreaker) allow(now time.Time) bool {
+	if !b.enabled() {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == entities.CircuitOpen {
+		if now.Sub(b.openedAt) < b.config.OpenTimeout {
+			return false
+		}
+		b.transitionLocked(entities.CircuitHalfOpen, now)
+	}
+
+	if b.state == entities.CircuitHalfOpen {
+		if b.trials >= b.config.HalfOpenRequests {
+			return false
+		}
+		b.trials++
+	}
+	return true
+}
+
+func (b *circuitBreaker) record(failed bool, now time.Time) {
+	if !b.enabled() {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case entities.CircuitClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.config.FailureThreshold {
+			b.transitionLocked(entities.CircuitOpen, now)
+		}
+	case entities.CircuitHalfOpen:
+		if failed {
+			b.transitionLocked(entities.CircuitOpen, now)
+			return
+		}
+		b.successes++
+		if b.successes >= b.config.HalfOpenRequests {
+			b.transitionLocked(entities.CircuitClosed, now)
+		}
+	}
+	// open iken biten cagrilar devre acilmadan once baslamistir, sonucu yok sayilir
+}
+
+// release sonucu provider'a yazilmayan (context iptali) half-open denemesinin yerini bosaltir
+func (b *circuitBreaker) release() {
+	if !b.enabled() {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == entities.CircuitHalfOpen && b.trials > 0 {
+		b.trials--
+	}
+}
+
+func (b *circuitBreaker) snapshot(health *entities.ProviderHealth) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health.Circuit = b.state
+	if b.state != entities.CircuitClosed {
+		openedAt := b.openedAt
+		health.CircuitOpenAt = &openedAt
+	}
+}
+
+func (b *circuitBreaker) transitionLocked(state entities.CircuitState, now time.Time) {
+	previous := b.state
+	b.state = state
+	b.trials = 0
+	b.successes = 0
+
+	switch state {
+	case entities.CircuitOpen:
+		b.openedAt = now
+		b.logger.Warn("Provider circuit opened",
+			zap.String("provider", b.name),
+			zap.String("previous_state", string(previous)),
+			zap.Int("consecutive_failures", b.failures),
+			zap.Duration("open_timeout", b.config.OpenTimeout))
+	case entities.CircuitHalfOpen:
+		b.logger.Info("Provider circuit half-open, sending trial requests",
+			zap.String("provider", b.name),
+			zap.Int("trial_requests", b.config.HalfOpenRequests))
+	case entities.CircuitClosed:
+		b.failures = 0
+		b.logger.Info("Provider circuit closed", zap.String("provider", b.name))
+	}
+}
@@ -0,0 +1,53 @@
+package dispatch
+
+import (
+	"context"
+	"fmt"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+)
+
+// Channels mesaji kanalina gore provider'a yollar. SMS mesajlari failover zincirine gider; diger kanallar
+// tek provider ile calisir, kanal icin provider kayitli degilse ErrChannelNotConfigured doner.
+type Channels struct {
+	sms       services.MessageProvider
+	providers map[entities.MessageChannel]services.MessageProvider
+}
+
+func NewChannels(sms services.MessageProvider) *Channels {
+	return &Channels{
+		sms:       sms,
+		providers: make(map[entities.MessageChannel]services.MessageProvider),
+	}
+}
+
+// Register acilista cagrilir, Send ile ayni anda kullanilmaz
+func (c *Channels) Register(channel entities.MessageChannel, provider services.MessageProvider) {
+	c.providers[channel] = provider
+}
+
+// Name SMS provider'inin adi; tahmini maliyet ve basarisiz SMS denemeleri bu isimle yazilir
+func (c *Channels) Name() string {
+	return c.sms.Name()
+}
+
+func (c *Channels) Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+	if request.Channel == "" || request.Channel == entities.MessageChannelSMS {
+		return c.sms.Send(ctx, request)
+	}
+
+	provider, ok := c.providers[request.Channel]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", entities.ErrChannelNotConfigured, request.Channel)
+	}
+
+	result, err := provider.Send(ctx, request)
+	if err != nil {
+		return nil, &entities.ProviderSendError{Provider: provider.Name(), Err: err}
+	}
+	if result.Provider == "" {
+		result.Provider = provider.Name()
+	}
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+func TestChannels_Send(t *testing.T) {
+	sms := &fakeProvider{name: "sms"}
+	email := &fakeProvider{name: "smtp"}
+	channels := NewChannels(sms)
+	channels.Register(entities.MessageChannelEmail, email)
+
+	if channels.Name() != "sms" {
+		t.Errorf("Expected SMS provider name, got %s", channels.Name())
+	}
+
+	// kanal bos ise eski mesajlar gibi SMS sayilir
+	for _, channel := range []entities.MessageChannel{"", entities.MessageChannelSMS} {
+		if _, err := channels.Send(context.Background(), entities.SendRequest{Channel: channel}); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+	}
+	if sms.calls != 2 || email.calls != 0 {
+		t.Errorf("Expected 2 sms and 0 email calls, got %d and %d", sms.calls, email.calls)
+	}
+
+	result, err := channels.Send(context.Background(), entities.SendRequest{Channel: entities.MessageChannelEmail})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.Provider != "smtp" || email.calls != 1 {
+		t.Errorf("Expected email provider to send, got %+v", result)
+	}
+
+	email.err = errors.New("connection refused")
+	_, err = channels.Send(context.Background(), entities.SendRequest{Channel: entities.MessageChannelEmail})
+	var sendErr *entities.ProviderSendError
+	if !errors.As(err, &sendErr) || sendErr.Provider != "smtp" {
+		t.Errorf("Expected ProviderSendError from smtp, got %v", err)
+	}
+
+	if _, err := channels.Send(context.Background(), entities.SendRequest{Channel: "push"}); !errors.Is(err, entities.ErrChannelNotConfigured) {
+		t.Errorf("Expected ErrChannelNotConfigured, got %v", err)
+	}
+}
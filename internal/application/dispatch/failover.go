@@ -0,0 +1,255 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+type member struct {
+	provider services.MessageProvider
+	health   *healthTracker
+	breaker  *circuitBreaker
+}
+
+// Failover provider'lari oncelik sirasiyla dener. Karar hatanin sinifina (entities.ErrorCodeOf) gore verilir:
+// gecici siniflarda (network, timeout, auth, provider_error) siradaki provider'a gecer; kalici siniflarda
+// (invalid_recipient, blocked_content, rejected) diger provider'lar denenmez.
+// Saglik skoru dusuk provider'lar atlanir, hepsi sagliksizsa yine de sirayla denenir.
+// Router verilmisse ve mesaj bir kurala uyuyorsa sadece kuralin provider'lari, kuralin sirasiyla denenir.
+// Devresi acik ya da rate limit'e takilan provider cagrilmadan atlanir; 429/503 donen provider Retry-After
+// boyunca durdurulur. Basari yoksa ve bunlardan biri olduysa hata entities.IsSendDeferred'a uyar,
+// boylece mesaj failed yerine pending kalir.
+type Failover struct {
+	members  []*member
+	router   services.ProviderRouter
+	limiter  services.ProviderRateLimiter
+	throttle config.ThrottleConfig
+	logger   *zap.Logger
+	now      func() time.Time
+	sleep    func(ctx context.Context, d time.Duration) error
+}
+
+func NewFailover(
+	providers []services.MessageProvider,
+	router services.ProviderRouter,
+	limiter services.ProviderRateLimiter,
+	cfg config.ExternalConfig,
+	logger *zap.Logger,
+) *Failover {
+	members := make([]*member, len(providers))
+	for i, provider := range providers {
+		members[i] = &member{
+			provider: provider,
+			health:   newHealthTracker(cfg.Health),
+			breaker:  newCircuitBreaker(cfg.Breaker, provider.Name(), logger),
+		}
+	}
+	return &Failover{
+		members:  members,
+		router:   router,
+		limiter:  limiter,
+		throttle: cfg.Throttle,
+		logger:   logger,
+		now:      time.Now,
+		sleep:    sleepContext,
+	}
+}
+
+// Name tahmini maliyet icin kullanilan birincil provider
+func (f *Failover) Name() string {
+	if len(f.members) == 0 {
+		return ""
+	}
+	return f.members[0].provider.Name()
+}
+
+func (f *Failover) Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+	var lastErr, deferredErr error
+	for _, m := range f.candidates(request) {
+		name := m.provider.Name()
+		if !m.breaker.allow(f.now()) {
+			deferredErr = &entities.ProviderSendError{Provider: name, Err: entities.ErrCircuitOpen}
+			continue
+		}
+		if err := f.acquire(ctx, name); err != nil {
+			m.breaker.release()
+			if ctx.Err() != nil {
+				return nil, &entities.ProviderSendError{Provider: name, Err: err}
+			}
+			deferredErr = &entities.ProviderSendError{Provider: name, Err: err}
+			continue
+		}
+		if lastErr != nil {
+			f.logger.Warn("Failing over to next provider",
+				zap.String("message_id", request.MessageID.String()),
+				zap.String("provider", name),
+				zap.Error(lastErr))
+		}
+
+		start := f.now()
+		result, err := m.provider.Send(ctx, request)
+		latency := f.now().Sub(start)
+
+		// context iptali provider'in sucu degil, sagligi etkilemesin
+		if err != nil && ctx.Err() != nil {
+			m.breaker.release()
+			return nil, &entities.ProviderSendError{Provider: name, Err: err}
+		}
+
+		// throttle provider'in sagligi hakkinda bir sey soylemez; Retry-After boyunca tum replikalar bekler
+		var throttled *entities.ProviderThrottledError
+		if errors.As(err, &throttled) {
+			m.breaker.release()
+			f.pause(ctx, name, throttled.RetryAfter)
+			deferredErr = &entities.ProviderSendError{Provider: name, Err: err}
+			continue
+		}
+
+		// kalici hata provider'in ayakta oldugunu gosterir, saglik icin basarili sayilir
+		permanent := err != nil && entities.ErrorCodeOf(err).IsPermanent()
+		failed := err != nil && !permanent
+		m.health.record(failed, latency, err, f.now())
+		m.breaker.record(failed, f.now())
+
+		if err == nil {
+			if result.Provider == "" {
+				result.Provider = name
+			}
+			return result, nil
+		}
+		if permanent {
+			return nil, &entities.ProviderSendError{Provider: name, Err: err}
+		}
+		lastErr = &entities.ProviderSendError{Provider: name, Err: err}
+	}
+
+	if deferredErr != nil {
+		if lastErr != nil {
+			f.logger.Warn("All reachable providers failed while others are unavailable, leaving message pending",
+				zap.String("message_id", request.MessageID.String()),
+				zap.Error(lastErr))
+		}
+		return nil, deferredErr
+	}
+	if lastErr == nil {
+		return nil, entities.ErrNoProviderAvailable
+	}
+	return nil, lastErr
+}
+
+// acquire MaxWait'e kadar token bekler; daha uzun beklemek gerekirse *entities.ProviderThrottledError doner
+func (f *Failover) acquire(ctx context.Context, name string) error {
+	if f.limiter == nil {
+		return nil
+	}
+
+	var waited time.Duration
+	for {
+		wait, err := f.limiter.Acquire(ctx, name)
+		if err != nil {
+			// limiter'a (Redis) ulasilamiyorsa gonderimi durdurmak yerine limitsiz devam edilir
+			f.logger.Warn("Rate limiter unavailable, sending without limit", zap.String("provider", name), zap.Error(err))
+			return nil
+		}
+		if wait <= 0 {
+			return nil
+		}
+		if waited+wait > f.throttle.MaxWait {
+			return &entities.ProviderThrottledError{RetryAfter: wait}
+		}
+		if err := f.sleep(ctx, wait); err != nil {
+			return err
+		}
+		waited += wait
+	}
+}
+
+func (f *Failover) pause(ctx context.Context, name string, retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		retryAfter = f.throttle.DefaultPause
+	}
+
+	f.logger.Warn("Provider is throttling, pausing sends",
+		zap.String("provider", name),
+		zap.Duration("retry_after", retryAfter))
+
+	if f.limiter == nil {
+		return
+	}
+	if err := f.limiter.Pause(ctx, name, retryAfter); err != nil {
+		f.logger.Warn("Failed to pause throttled provider", zap.String("provider", name), zap.Error(err))
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// candidates once kullanilabilir provider'lar, sonra son care olarak sagliksiz olanlar
+func (f *Failover) candidates(request entities.SendRequest) []*member {
+	now := f.now()
+	routed := f.route(request)
+	available := make([]*member, 0, len(routed))
+	var unhealthy []*member
+	for _, m := range routed {
+		if m.health.available(now) {
+			available = append(available, m)
+		} else {
+			unhealthy = append(unhealthy, m)
+		}
+	}
+	return append(available, unhealthy...)
+}
+
+// route kuralin provider sirasini uygular; kural yoksa ya da kuraldaki provider'larin hicbiri tanimli degilse oncelik sirasi kullanilir
+func (f *Failover) route(request entities.SendRequest) []*member {
+	if f.router == nil {
+		return f.members
+	}
+
+	names := f.router.Route(request)
+	if names == nil {
+		return f.members
+	}
+
+	routed := make([]*member, 0, len(names))
+	for _, name := range names {
+		for _, m := range f.members {
+			if m.provider.Name() == name {
+				routed = append(routed, m)
+				break
+			}
+		}
+	}
+	if len(routed) == 0 {
+		f.logger.Warn("Routing rule has no configured provider, using default order",
+			zap.String("message_id", request.MessageID.String()),
+			zap.Strings("providers", names))
+		return f.members
+	}
+	return routed
+}
+
+func (f *Failover) ProviderHealth() []entities.ProviderHealth {
+	health := make([]entities.ProviderHealth, len(f.members))
+	for i, m := range f.members {
+		health[i] = m.health.snapshot(m.provider.Name(), i+1)
+		m.breaker.snapshot(&health[i])
+	}
+	return health
+}
@@ -0,0 +1,394 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+type fakeProvider struct {
+	name    string
+	err     error
+	latency time.Duration
+	clock   *fakeClock
+	calls   int
+}
+
+func (p *fakeProvider) Name() string {
+	return p.name
+}
+
+func (p *fakeProvider) Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+	p.calls++
+	if p.clock != nil {
+		p.clock.advance(p.latency)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &entities.SendResult{ExternalMessageID: p.name + "_1"}, nil
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestFailover(clock *fakeClock, providers ...*fakeProvider) *Failover {
+	members := make([]services.MessageProvider, len(providers))
+	for i, p := range providers {
+		p.clock = clock
+		members[i] = p
+	}
+	failover := NewFailover(members, nil, nil, config.ExternalConfig{
+		Health: config.ProviderHealthConfig{
+			Window:        4,
+			MinScore:      0.5,
+			SlowLatency:   time.Second,
+			ProbeInterval: time.Minute,
+		},
+		Breaker: config.CircuitBreakerConfig{
+			FailureThreshold: 3,
+			OpenTimeout:      5 * time.Minute,
+			HalfOpenRequests: 1,
+		},
+	}, zap.NewNop())
+	failover.now = clock.Now
+	return failover
+}
+
+func TestFailover_Send(t *testing.T) {
+	transient := fmt.Errorf("API request failed with status %d", 503)
+	rejected := fmt.Errorf("%w: blocked number", entities.ErrProviderRejected)
+	invalidNumber := entities.NewProviderError(entities.ErrorCodeInvalidRecipient, errors.New("unknown subscriber"))
+	auth := entities.NewProviderError(entities.ErrorCodeAuth, errors.New("invalid credentials"))
+
+	tests := []struct {
+		name         string
+		primaryErr   error
+		backupErr    error
+		wantProvider string
+		wantErr      error
+		backupCalls  int
+	}{
+		{name: "primary succeeds", wantProvider: "primary"},
+		{name: "transient error fails over", primaryErr: transient, wantProvider: "backup", backupCalls: 1},
+		{name: "rejection does not fail over", primaryErr: rejected, wantProvider: "primary", wantErr: entities.ErrProviderRejected},
+		{name: "permanent class does not fail over", primaryErr: invalidNumber, wantProvider: "primary", wantErr: invalidNumber},
+		{name: "auth error fails over", primaryErr: auth, wantProvider: "backup", backupCalls: 1},
+		{name: "all providers fail", primaryErr: transient, backupErr: transient, wantProvider: "backup", wantErr: transient, backupCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &fakeProvider{name: "primary", err: tt.primaryErr}
+			backup := &fakeProvider{name: "backup", err: tt.backupErr}
+			failover := newTestFailover(&fakeClock{now: time.Now()}, primary, backup)
+
+			result, err := failover.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1234567890"})
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Expected no error but got: %v", err)
+				}
+				if result.Provider != tt.wantProvider {
+					t.Errorf("Expected provider %s, got %s", tt.wantProvider, result.Provider)
+				}
+			} else {
+				var sendErr *entities.ProviderSendError
+				if !errors.As(err, &sendErr) || !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected ProviderSendError wrapping %v, got %v", tt.wantErr, err)
+				}
+				if sendErr.Provider != tt.wantProvider {
+					t.Errorf("Expected failed provider %s, got %s", tt.wantProvider, sendErr.Provider)
+				}
+			}
+
+			if backup.calls != tt.backupCalls {
+				t.Errorf("Expected %d backup calls, got %d", tt.backupCalls, backup.calls)
+			}
+		})
+	}
+}
+
+func TestFailover_SkipsUnhealthyProvider(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	primary := &fakeProvider{name: "primary", err: errors.New("connection refused")}
+	backup := &fakeProvider{name: "backup"}
+	failover := newTestFailover(clock, primary, backup)
+
+	for i := 0; i < 3; i++ {
+		if _, err := failover.Send(context.Background(), entities.SendRequest{}); err != nil {
+			t.Fatalf("Expected failover to backup, got: %v", err)
+		}
+	}
+
+	// ilk denemeden sonra primary sagliksiz, sonraki mesajlar dogrudan backup'a gider
+	if primary.calls != 1 {
+		t.Errorf("Expected unhealthy primary to be skipped, got %d calls", primary.calls)
+	}
+
+	health := failover.ProviderHealth()
+	if health[0].Healthy || health[0].Failures != 1 || health[0].LastError == nil {
+		t.Errorf("Unexpected primary health %+v", health[0])
+	}
+	if !health[1].Healthy || health[1].Attempts != 3 || health[1].Priority != 2 {
+		t.Errorf("Unexpected backup health %+v", health[1])
+	}
+
+	// probe araligi gecince primary tekrar denenir ve toparlanabilir
+	primary.err = nil
+	clock.advance(2 * time.Minute)
+	result, err := failover.Send(context.Background(), entities.SendRequest{})
+	if err != nil || result.Provider != "primary" {
+		t.Fatalf("Expected primary probe to succeed, got %v %v", result, err)
+	}
+}
+
+func TestFailover_SlowProviderScore(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	slow := &fakeProvider{name: "slow", latency: 4 * time.Second}
+	failover := newTestFailover(clock, slow)
+
+	if _, err := failover.Send(context.Background(), entities.SendRequest{}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	health := failover.ProviderHealth()[0]
+	if health.Score != 0.25 || health.Healthy {
+		t.Errorf("Expected score 0.25 and unhealthy for 4x slow latency, got %+v", health)
+	}
+	if health.AvgLatency != 4*time.Second || health.ErrorRate != 0 {
+		t.Errorf("Unexpected latency/error rate %+v", health)
+	}
+}
+
+type fakeRouter struct {
+	route []string
+}
+
+func (r *fakeRouter) Route(request entities.SendRequest) []string {
+	return r.route
+}
+
+func TestFailover_FollowsRouterOrder(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	primary := &fakeProvider{name: "primary"}
+	backup := &fakeProvider{name: "backup", err: errors.New("connection refused")}
+	cheap := &fakeProvider{name: "cheap"}
+	failover := newTestFailover(clock, primary, backup, cheap)
+	failover.router = &fakeRouter{route: []string{"backup", "cheap"}}
+
+	result, err := failover.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233"})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.Provider != "cheap" {
+		t.Errorf("Expected routed fallback cheap, got %s", result.Provider)
+	}
+	if primary.calls != 0 {
+		t.Errorf("Expected provider outside the route not to be called, got %d calls", primary.calls)
+	}
+}
+
+func TestFailover_CircuitBreaker(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	timeout := errors.New("context deadline exceeded")
+	primary := &fakeProvider{name: "primary", err: timeout}
+	failover := newTestFailover(clock, primary)
+	send := func() error {
+		_, err := failover.Send(context.Background(), entities.SendRequest{})
+		return err
+	}
+
+	// esik 3: ilk uc hata devreyi acar
+	for i := 0; i < 3; i++ {
+		if err := send(); !errors.Is(err, timeout) {
+			t.Fatalf("Expected provider error on attempt %d, got %v", i+1, err)
+		}
+	}
+	if state := failover.ProviderHealth()[0]; state.Circuit != entities.CircuitOpen || state.CircuitOpenAt == nil {
+		t.Fatalf("Expected open circuit, got %+v", state)
+	}
+
+	// acik devre provider'i cagirmadan hizlica reddeder
+	if err := send(); !errors.Is(err, entities.ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if primary.calls != 3 {
+		t.Errorf("Expected open circuit to skip the provider, got %d calls", primary.calls)
+	}
+
+	// half-open denemesi basarisiz olursa devre tekrar acilir
+	clock.advance(6 * time.Minute)
+	if err := send(); !errors.Is(err, timeout) {
+		t.Fatalf("Expected trial request to reach the provider, got %v", err)
+	}
+	if state := failover.ProviderHealth()[0].Circuit; state != entities.CircuitOpen {
+		t.Fatalf("Expected failed trial to reopen the circuit, got %s", state)
+	}
+
+	// basarili deneme devreyi kapatir
+	primary.err = nil
+	clock.advance(6 * time.Minute)
+	if err := send(); err != nil {
+		t.Fatalf("Expected trial request to succeed, got %v", err)
+	}
+	if state := failover.ProviderHealth()[0].Circuit; state != entities.CircuitClosed {
+		t.Errorf("Expected closed circuit after successful trial, got %s", state)
+	}
+}
+
+func TestFailover_CircuitOpenFailsOverToBackup(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	primary := &fakeProvider{name: "primary", err: errors.New("connection refused")}
+	backup := &fakeProvider{name: "backup"}
+	failover := newTestFailover(clock, primary, backup)
+
+	for i := 0; i < 3; i++ {
+		// probe araligi her seferinde primary'yi tekrar denetir
+		clock.advance(2 * time.Minute)
+		if _, err := failover.Send(context.Background(), entities.SendRequest{}); err != nil {
+			t.Fatalf("Expected failover to backup, got: %v", err)
+		}
+	}
+
+	clock.advance(2 * time.Minute)
+	result, err := failover.Send(context.Background(), entities.SendRequest{})
+	if err != nil || result.Provider != "backup" {
+		t.Fatalf("Expected backup to send while primary circuit is open, got %v %v", result, err)
+	}
+	if primary.calls != 3 {
+		t.Errorf("Expected open circuit to skip primary, got %d calls", primary.calls)
+	}
+}
+
+func TestLocalRateLimiter(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	limiter := NewLocalRateLimiter([]config.ProviderConfig{{Name: "primary", RateLimit: 2}}).(*localRateLimiter)
+	limiter.now = clock.Now
+	ctx := context.Background()
+
+	// burst verilmemis: saniyelik limit kadar token
+	for i := 0; i < 2; i++ {
+		if wait, _ := limiter.Acquire(ctx, "primary"); wait != 0 {
+			t.Fatalf("Expected token %d to be available, got wait %s", i+1, wait)
+		}
+	}
+	if wait, _ := limiter.Acquire(ctx, "primary"); wait != 500*time.Millisecond {
+		t.Errorf("Expected 500ms wait at 2 rps, got %s", wait)
+	}
+
+	clock.advance(500 * time.Millisecond)
+	if wait, _ := limiter.Acquire(ctx, "primary"); wait != 0 {
+		t.Errorf("Expected refilled token, got wait %s", wait)
+	}
+
+	limiter.Pause(ctx, "primary", time.Minute)
+	clock.advance(time.Second)
+	if wait, _ := limiter.Acquire(ctx, "primary"); wait != 59*time.Second {
+		t.Errorf("Expected remaining pause 59s, got %s", wait)
+	}
+
+	if wait, _ := limiter.Acquire(ctx, "unlimited"); wait != 0 {
+		t.Errorf("Expected unknown provider to be unlimited, got %s", wait)
+	}
+}
+
+type fakeLimiter struct {
+	waits  map[string]time.Duration
+	paused map[string]time.Duration
+}
+
+func (l *fakeLimiter) Acquire(ctx context.Context, provider string) (time.Duration, error) {
+	if d, ok := l.paused[provider]; ok {
+		return d, nil
+	}
+	return l.waits[provider], nil
+}
+
+func (l *fakeLimiter) Pause(ctx context.Context, provider string, d time.Duration) error {
+	l.paused[provider] = d
+	return nil
+}
+
+func TestFailover_Throttling(t *testing.T) {
+	throttled := fmt.Errorf("API request failed with status 429: %w", &entities.ProviderThrottledError{RetryAfter: 10 * time.Second})
+
+	t.Run("429 pauses provider and fails over", func(t *testing.T) {
+		primary := &fakeProvider{name: "primary", err: throttled}
+		backup := &fakeProvider{name: "backup"}
+		failover := newTestFailover(&fakeClock{now: time.Now()}, primary, backup)
+		limiter := &fakeLimiter{paused: map[string]time.Duration{}}
+		failover.limiter = limiter
+
+		result, err := failover.Send(context.Background(), entities.SendRequest{})
+		if err != nil || result.Provider != "backup" {
+			t.Fatalf("Expected backup to send, got %v %v", result, err)
+		}
+		if limiter.paused["primary"] != 10*time.Second {
+			t.Errorf("Expected primary paused for Retry-After, got %v", limiter.paused)
+		}
+		if health := failover.ProviderHealth()[0]; health.Failures != 0 {
+			t.Errorf("Expected throttling not to count against health, got %+v", health)
+		}
+
+		// durdurulan provider artik cagrilmaz
+		failover.Send(context.Background(), entities.SendRequest{})
+		if primary.calls != 1 {
+			t.Errorf("Expected paused primary to be skipped, got %d calls", primary.calls)
+		}
+	})
+
+	t.Run("only provider throttled leaves message pending", func(t *testing.T) {
+		primary := &fakeProvider{name: "primary", err: throttled}
+		failover := newTestFailover(&fakeClock{now: time.Now()}, primary)
+
+		_, err := failover.Send(context.Background(), entities.SendRequest{})
+		if !entities.IsSendDeferred(err) {
+			t.Fatalf("Expected deferred send error, got %v", err)
+		}
+	})
+
+	t.Run("short wait is slept, long wait skips", func(t *testing.T) {
+		primary := &fakeProvider{name: "primary"}
+		failover := newTestFailover(&fakeClock{now: time.Now()}, primary)
+		limiter := &fakeLimiter{waits: map[string]time.Duration{"primary": 5 * time.Second}, paused: map[string]time.Duration{}}
+		failover.limiter = limiter
+		failover.throttle.MaxWait = time.Second
+
+		var slept time.Duration
+		failover.sleep = func(ctx context.Context, d time.Duration) error {
+			slept += d
+			limiter.waits["primary"] = 0
+			return nil
+		}
+
+		_, err := failover.Send(context.Background(), entities.SendRequest{})
+		if !errors.Is(err, entities.ErrProviderThrottled) || primary.calls != 0 || slept != 0 {
+			t.Fatalf("Expected long wait to skip without sleeping, got %v (calls %d, slept %s)", err, primary.calls, slept)
+		}
+
+		limiter.waits["primary"] = 300 * time.Millisecond
+		if _, err := failover.Send(context.Background(), entities.SendRequest{}); err != nil {
+			t.Fatalf("Expected send after short wait, got %v", err)
+		}
+		if slept != 300*time.Millisecond {
+			t.Errorf("Expected 300ms sleep, got %s", slept)
+		}
+	})
+}
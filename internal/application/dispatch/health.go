@@ -0,0 +1,125 @@
+package dispatch
+
+import (
+	"sync"
+	"time"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+type attempt struct {
+	failed  bool
+	latency time.Duration
+}
+
+// healthTracker bir provider'in son Window denemesini halka tamponda tutar
+type healthTracker struct {
+	config config.ProviderHealthConfig
+
+	mu            sync.Mutex
+	attempts      []attempt
+	next          int
+	lastAttemptAt time.Time
+	lastError     *string
+	lastFailureAt *time.Time
+	lastSuccessAt *time.Time
+}
+
+func newHealthTracker(cfg config.ProviderHealthConfig) *healthTracker {
+	if cfg.Window <= 0 {
+		cfg.Window = 1
+	}
+	return &healthTracker{
+		config:   cfg,
+		attempts: make([]attempt, 0, cfg.Window),
+	}
+}
+
+func (h *healthTracker) record(failed bool, latency time.Duration, err error, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	a := attempt{failed: failed, latency: latency}
+	if len(h.attempts) < h.config.Window {
+		h.attempts = append(h.attempts, a)
+	} else {
+		h.attempts[h.next] = a
+	}
+	h.next = (h.next + 1) % h.config.Window
+	h.lastAttemptAt = now
+
+	if failed {
+		msg := err.Error()
+		h.lastError = &msg
+		h.lastFailureAt = &now
+	} else {
+		h.lastSuccessAt = &now
+	}
+}
+
+// available saglikli provider'lar ya da ProbeInterval boyunca denenmemis sagliksiz provider'lar icin true
+func (h *healthTracker) available(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.healthyLocked() {
+		return true
+	}
+	return now.Sub(h.lastAttemptAt) >= h.config.ProbeInterval
+}
+
+func (h *healthTracker) snapshot(name string, priority int) entities.ProviderHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	errorRate, avgLatency := h.statsLocked()
+	health := entities.ProviderHealth{
+		Name:          name,
+		Priority:      priority,
+		Healthy:       h.healthyLocked(),
+		Score:         h.scoreLocked(),
+		ErrorRate:     errorRate,
+		AvgLatency:    avgLatency,
+		Attempts:      len(h.attempts),
+		LastError:     h.lastError,
+		LastFailureAt: h.lastFailureAt,
+		LastSuccessAt: h.lastSuccessAt,
+	}
+	for _, a := range h.attempts {
+		if a.failed {
+			health.Failures++
+		}
+	}
+	return health
+}
+
+func (h *healthTracker) healthyLocked() bool {
+	return h.scoreLocked() >= h.config.MinScore
+}
+
+// scoreLocked hic deneme yoksa 1; ortalama gecikme SlowLatency'yi asarsa skor orantili duser
+func (h *healthTracker) scoreLocked() float64 {
+	errorRate, avgLatency := h.statsLocked()
+	score := 1 - errorRate
+	if h.config.SlowLatency > 0 && avgLatency > h.config.SlowLatency {
+		score *= float64(h.config.SlowLatency) / float64(avgLatency)
+	}
+	return score
+}
+
+func (h *healthTracker) statsLocked() (float64, time.Duration) {
+	if len(h.attempts) == 0 {
+		return 0, 0
+	}
+
+	var failures int
+	var total time.Duration
+	for _, a := range h.attempts {
+		if a.failed {
+			failures++
+		}
+		total += a.latency
+	}
+	return float64(failures) / float64(len(h.attempts)), total / time.Duration(len(h.attempts))
+}
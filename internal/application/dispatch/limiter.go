@@ -0,0 +1,80 @@
+package dispatch
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"message-sending-service/internal/domain/services"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+type bucket struct {
+	rate        float64
+	burst       float64
+	tokens      float64
+	updatedAt   time.Time
+	pausedUntil time.Time
+}
+
+// localRateLimiter Redis yokken kullanilir; limit sadece bu replika icin gecerlidir
+type localRateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+func NewLocalRateLimiter(providers []config.ProviderConfig) services.ProviderRateLimiter {
+	limiter := &localRateLimiter{
+		buckets: make(map[string]*bucket, len(providers)),
+		now:     time.Now,
+	}
+	for _, provider := range providers {
+		burst := float64(provider.Burst())
+		limiter.buckets[provider.Name] = &bucket{rate: provider.RateLimit, burst: burst, tokens: burst}
+	}
+	return limiter
+}
+
+func (l *localRateLimiter) Acquire(ctx context.Context, provider string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[provider]
+	if !ok {
+		return 0, nil
+	}
+
+	now := l.now()
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now), nil
+	}
+	if b.rate <= 0 {
+		return 0, nil
+	}
+
+	if !b.updatedAt.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.updatedAt).Seconds()*b.rate)
+	}
+	b.updatedAt = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, nil
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second)), nil
+}
+
+func (l *localRateLimiter) Pause(ctx context.Context, provider string, d time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[provider]
+	if !ok {
+		b = &bucket{}
+		l.buckets[provider] = b
+	}
+	b.pausedUntil = l.now().Add(d)
+	return nil
+}
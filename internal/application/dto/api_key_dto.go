@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type CreateAPIKeyRequest struct {
+	Name     string     `json:"name" binding:"required,max=255" example:"checkout-service"`
+	TenantID *uuid.UUID `json:"tenant_id,omitempty" example:"00000000-0000-0000-0000-000000000001"`
+	Scopes   []string   `json:"scopes" binding:"required,min=1" example:"messages:write,messages:read"`
+}
+
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TenantID   uuid.UUID  `json:"tenant_id" example:"00000000-0000-0000-0000-000000000001"`
+	Name       string     `json:"name" example:"checkout-service"`
+	Prefix     string     `json:"prefix" example:"msk_1a2b3c4d"`
+	Scopes     []string   `json:"scopes" example:"messages:write,messages:read"`
+	CreatedAt  time.Time  `json:"created_at" example:"2023-01-01T12:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2023-01-01T12:05:00Z"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" example:"2023-01-02T12:00:00Z"`
+}
+
+// CreateAPIKeyResponse anahtarin duz hali sadece bu cevapta bir kez gorunur
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" example:"msk_1a2b3c4d5e6f..."`
+}
+
+func (r CreateAPIKeyRequest) ToInput() usecases.CreateAPIKeyInput {
+	input := usecases.CreateAPIKeyInput{
+		Name:   r.Name,
+		Scopes: r.Scopes,
+	}
+	if r.TenantID != nil {
+		input.TenantID = *r.TenantID
+	}
+	return input
+}
+
+func ToAPIKeyResponse(key *entities.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.ID,
+		TenantID:   key.TenantID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
+
+func ToAPIKeyResponses(keys []*entities.APIKey) []APIKeyResponse {
+	responses := make([]APIKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = ToAPIKeyResponse(key)
+	}
+	return responses
+}
@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+// AuditLogQuery'deki bos filtreler uygulanmaz, from/to RFC3339 formatinda
+type AuditLogQuery struct {
+	PaginationQuery
+	TenantID   string     `form:"tenant_id" binding:"omitempty,uuid" example:"00000000-0000-0000-0000-000000000001"`
+	Actor      string     `form:"actor" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Action     string     `form:"action" example:"scheduler.stop"`
+	TargetType string     `form:"target_type" binding:"omitempty,oneof=scheduler message api_key routing_rule" example:"message"`
+	TargetID   string     `form:"target_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00" example:"2023-01-01T00:00:00Z"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00" example:"2023-01-02T00:00:00Z"`
+}
+
+type AuditLogResponse struct {
+	ID         uuid.UUID       `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TenantID   *uuid.UUID      `json:"tenant_id,omitempty" example:"00000000-0000-0000-0000-000000000001"`
+	ActorID    string          `json:"actor_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ActorName  string          `json:"actor_name,omitempty" example:"ops"`
+	AuthMethod string          `json:"auth_method,omitempty" example:"api_key"`
+	IPAddress  string          `json:"ip_address,omitempty" example:"10.0.0.12"`
+	Action     string          `json:"action" example:"scheduler.stop"`
+	TargetType string          `json:"target_type" example:"scheduler"`
+	TargetID   string          `json:"target_id,omitempty" example:""`
+	Before     json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	CreatedAt  time.Time       `json:"created_at" example:"2023-01-01T12:00:00Z"`
+}
+
+type GetAuditLogsResponse struct {
+	Entries    []AuditLogResponse `json:"entries"`
+	TotalCount int64              `json:"total_count" example:"100"`
+	Page       int                `json:"page" example:"1"`
+	Limit      int                `json:"limit" example:"10"`
+	TotalPages int                `json:"total_pages" example:"10"`
+}
+
+func (q AuditLogQuery) ToFilter() entities.AuditLogFilter {
+	filter := entities.AuditLogFilter{
+		ActorID:    q.Actor,
+		Action:     q.Action,
+		TargetType: q.TargetType,
+		TargetID:   q.TargetID,
+		From:       q.From,
+		To:         q.To,
+	}
+	if tenantID, err := uuid.Parse(q.TenantID); err == nil {
+		filter.TenantID = &tenantID
+	}
+	return filter
+}
+
+func ToAuditLogResponse(entry *entities.AuditLog) AuditLogResponse {
+	return AuditLogResponse{
+		ID:         entry.ID,
+		TenantID:   entry.TenantID,
+		ActorID:    entry.ActorID,
+		ActorName:  entry.ActorName,
+		AuthMethod: entry.AuthMethod,
+		IPAddress:  entry.IPAddress,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Before:     entry.Before,
+		After:      entry.After,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
@@ -1,9 +1,10 @@
 package dto
 
 type ErrorResponse struct {
-	Error   string `json:"error" example:"Invalid request"`
-	Message string `json:"message,omitempty" example:"Detailed error message"`
-	Code    int    `json:"code,omitempty" example:"400"`
+	Error   string      `json:"error" example:"Invalid request"`
+	Message string      `json:"message,omitempty" example:"Detailed error message"`
+	Code    int         `json:"code,omitempty" example:"400"`
+	Details interface{} `json:"details,omitempty"`
 }
 type SuccessResponse struct {
 	Message string      `json:"message" example:"Operation completed successfully"`
@@ -23,6 +24,12 @@ func NewErrorResponse(err string, message string, code int) ErrorResponse {
 	}
 }
 
+func NewErrorResponseWithDetails(err string, message string, code int, details interface{}) ErrorResponse {
+	response := NewErrorResponse(err, message, code)
+	response.Details = details
+	return response
+}
+
 func NewSuccessResponse(message string, data interface{}) SuccessResponse {
 	return SuccessResponse{
 		Message: message,
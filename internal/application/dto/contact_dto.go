@@ -0,0 +1,161 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type ContactRequest struct {
+	PhoneNumber string            `json:"phone_number" binding:"required,max=20" example:"+1234567890"`
+	Name        string            `json:"name" binding:"max=255" example:"Jane Doe"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	Suppressed  bool              `json:"suppressed" example:"false"`
+}
+
+type ContactResponse struct {
+	ID          uuid.UUID         `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	PhoneNumber string            `json:"phone_number" example:"+1234567890"`
+	Name        string            `json:"name" example:"Jane Doe"`
+	Attributes  map[string]string `json:"attributes"`
+	Suppressed  bool              `json:"suppressed" example:"false"`
+	CreatedAt   time.Time         `json:"created_at" example:"2023-01-01T12:00:00Z"`
+	UpdatedAt   time.Time         `json:"updated_at" example:"2023-01-01T12:00:00Z"`
+}
+
+type GetContactsResponse struct {
+	Contacts   []ContactResponse `json:"contacts"`
+	TotalCount int64             `json:"total_count" example:"100"`
+	Page       int               `json:"page" example:"1"`
+	Limit      int               `json:"limit" example:"10"`
+	TotalPages int               `json:"total_pages" example:"10"`
+}
+
+type ContactListRequest struct {
+	Name        string `json:"name" binding:"required,max=255" example:"VIP customers"`
+	Description string `json:"description" example:"Customers with more than 10 orders"`
+}
+
+type ContactListResponse struct {
+	ID           uuid.UUID `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name         string    `json:"name" example:"VIP customers"`
+	Description  string    `json:"description" example:"Customers with more than 10 orders"`
+	ContactCount int64     `json:"contact_count" example:"250"`
+	CreatedAt    time.Time `json:"created_at" example:"2023-01-01T12:00:00Z"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2023-01-01T12:00:00Z"`
+}
+
+type GetContactListsResponse struct {
+	Lists      []ContactListResponse `json:"lists"`
+	TotalCount int64                 `json:"total_count" example:"5"`
+	Page       int                   `json:"page" example:"1"`
+	Limit      int                   `json:"limit" example:"10"`
+	TotalPages int                   `json:"total_pages" example:"1"`
+}
+
+type AddListContactsRequest struct {
+	ContactIDs []uuid.UUID `json:"contact_ids" binding:"required,min=1"`
+}
+
+type BroadcastRequest struct {
+	Content  string `json:"content" binding:"required" example:"Hi {{.name}}, your order is ready"`
+	Category string `json:"category,omitempty" binding:"omitempty,oneof=transactional marketing" example:"marketing"`
+	Campaign string `json:"campaign,omitempty" binding:"omitempty,max=100" example:"spring-sale"`
+	SenderID string `json:"sender_id,omitempty" binding:"omitempty,max=16" example:"INSIDER"`
+}
+
+type BroadcastFailureResponse struct {
+	ContactID uuid.UUID `json:"contact_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Reason    string    `json:"reason" example:"message content cannot be empty"`
+}
+
+type BroadcastResponse struct {
+	ListID     uuid.UUID                  `json:"list_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Queued     int                        `json:"queued" example:"240"`
+	Suppressed int                        `json:"suppressed" example:"8"`
+	Failed     int                        `json:"failed" example:"2"`
+	MessageIDs []uuid.UUID                `json:"message_ids"`
+	Failures   []BroadcastFailureResponse `json:"failures,omitempty"`
+}
+
+func (r ContactRequest) ToEntity() *entities.Contact {
+	return &entities.Contact{
+		PhoneNumber: r.PhoneNumber,
+		Name:        r.Name,
+		Attributes:  r.Attributes,
+		Suppressed:  r.Suppressed,
+	}
+}
+
+func (r ContactListRequest) ToEntity() *entities.ContactList {
+	return &entities.ContactList{
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+func (r BroadcastRequest) ToInput() usecases.BroadcastInput {
+	input := usecases.BroadcastInput{
+		Content:  r.Content,
+		Category: entities.MessageCategory(r.Category),
+	}
+	if r.Campaign != "" {
+		campaign := r.Campaign
+		input.Campaign = &campaign
+	}
+	if r.SenderID != "" {
+		senderID := r.SenderID
+		input.SenderID = &senderID
+	}
+	return input
+}
+
+func ToContactResponse(contact *entities.Contact) ContactResponse {
+	return ContactResponse{
+		ID:          contact.ID,
+		PhoneNumber: contact.PhoneNumber,
+		Name:        contact.Name,
+		Attributes:  contact.Attributes,
+		Suppressed:  contact.Suppressed,
+		CreatedAt:   contact.CreatedAt,
+		UpdatedAt:   contact.UpdatedAt,
+	}
+}
+
+func ToContactResponses(contacts []*entities.Contact) []ContactResponse {
+	responses := make([]ContactResponse, len(contacts))
+	for i, contact := range contacts {
+		responses[i] = ToContactResponse(contact)
+	}
+	return responses
+}
+
+func ToContactListResponse(list *entities.ContactList) ContactListResponse {
+	return ContactListResponse{
+		ID:           list.ID,
+		Name:         list.Name,
+		Description:  list.Description,
+		ContactCount: list.ContactCount,
+		CreatedAt:    list.CreatedAt,
+		UpdatedAt:    list.UpdatedAt,
+	}
+}
+
+func ToBroadcastResponse(result *usecases.BroadcastResult) BroadcastResponse {
+	response := BroadcastResponse{
+		ListID:     result.ListID,
+		Queued:     result.Queued,
+		Suppressed: result.Suppressed,
+		Failed:     result.Failed,
+		MessageIDs: result.MessageIDs,
+	}
+	for _, failure := range result.Failures {
+		response.Failures = append(response.Failures, BroadcastFailureResponse{
+			ContactID: failure.ContactID,
+			Reason:    failure.Reason,
+		})
+	}
+	return response
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type LinkClickCountResponse struct {
+	Code      string    `json:"code" example:"aB3dE7k"`
+	MessageID uuid.UUID `json:"message_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TargetURL string    `json:"target_url" example:"https://example.com/spring-sale"`
+	Clicks    int64     `json:"clicks" example:"42"`
+}
+
+type ClickStatsResponse struct {
+	TotalClicks int64                    `json:"total_clicks" example:"42"`
+	Links       []LinkClickCountResponse `json:"links"`
+}
+
+func ToClickStatsResponse(stats *usecases.ClickStats) ClickStatsResponse {
+	links := make([]LinkClickCountResponse, len(stats.Links))
+	for i, link := range stats.Links {
+		links[i] = LinkClickCountResponse{
+			Code:      link.Code,
+			MessageID: link.MessageID,
+			TargetURL: link.TargetURL,
+			Clicks:    link.Clicks,
+		}
+	}
+
+	return ClickStatsResponse{
+		TotalClicks: stats.TotalClicks,
+		Links:       links,
+	}
+}
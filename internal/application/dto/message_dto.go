@@ -8,20 +8,84 @@ import (
 	"message-sending-service/internal/domain/usecases"
 )
 
+// CreateMessageRequest'te sms icin phone_number, email icin email ve subject, webhook icin webhook_url gerekir.
+// Icerik siniri kanala gore: sms 160, email ve webhook 100000 karakter.
 type CreateMessageRequest struct {
-	Content     string `json:"content" binding:"required,max=160" example:"Hello, this is a test message"`
-	PhoneNumber string `json:"phone_number" binding:"required" example:"+1234567890"`
+	Channel     string `json:"channel,omitempty" binding:"omitempty,oneof=sms email webhook" example:"sms"`
+	Content     string `json:"content" binding:"required" example:"Hello, this is a test message"`
+	PhoneNumber string `json:"phone_number,omitempty" example:"+1234567890"`
+	Email       string `json:"email,omitempty" binding:"omitempty,max=254" example:"jane@example.com"`
+	Subject     string `json:"subject,omitempty" binding:"omitempty,max=255" example:"Your order has shipped"`
+	WebhookURL  string `json:"webhook_url,omitempty" binding:"omitempty,max=2048" example:"https://hooks.example.com/orders"`
+	Category    string `json:"category,omitempty" binding:"omitempty,oneof=transactional marketing" example:"transactional"`
+	Campaign    string `json:"campaign,omitempty" binding:"omitempty,max=100" example:"spring-sale"`
+	SenderID    string `json:"sender_id,omitempty" binding:"omitempty,max=16" example:"INSIDER"`
 }
+
+// UpdateMessageRequest sadece pending mesajlar icin, gonderilmeyen alanlar degismez
+type UpdateMessageRequest struct {
+	Content     *string `json:"content,omitempty" example:"Hello, this is an edited message"`
+	PhoneNumber *string `json:"phone_number,omitempty" example:"+1234567890"`
+	Email       *string `json:"email,omitempty" binding:"omitempty,max=254" example:"jane@example.com"`
+	Subject     *string `json:"subject,omitempty" binding:"omitempty,max=255" example:"Your order has shipped"`
+	WebhookURL  *string `json:"webhook_url,omitempty" binding:"omitempty,max=2048" example:"https://hooks.example.com/orders"`
+}
+
+// DeliveryReceiptRequest provider'in HTTP ile bildirdigi teslim durumu; message_id gonderimde donen id
+type DeliveryReceiptRequest struct {
+	MessageID  string     `json:"message_id" binding:"required,max=255" example:"ext_123"`
+	Status     string     `json:"status" binding:"required,oneof=delivered undelivered expired rejected unknown" example:"delivered"`
+	ErrorCode  string     `json:"error_code,omitempty" binding:"omitempty,max=64" example:"30003"`
+	ReportedAt *time.Time `json:"reported_at,omitempty" example:"2024-01-01T12:00:00Z"`
+}
+
+func (r DeliveryReceiptRequest) ToReceipt(provider string, now time.Time) entities.DeliveryReceipt {
+	receipt := entities.DeliveryReceipt{
+		Provider:          provider,
+		ExternalMessageID: r.MessageID,
+		Status:            entities.DeliveryStatus(r.Status),
+		ErrorCode:         r.ErrorCode,
+		ReportedAt:        now,
+	}
+	if r.ReportedAt != nil {
+		receipt.ReportedAt = *r.ReportedAt
+	}
+	return receipt
+}
+
+type PolicyViolationResponse struct {
+	Rule    string `json:"rule" example:"banned_content"`
+	Message string `json:"message" example:"content contains banned word \"casino\""`
+}
+
 type MessageResponse struct {
 	ID                uuid.UUID  `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
 	Content           string     `json:"content" example:"Hello, this is a test message"`
 	PhoneNumber       string     `json:"phone_number" example:"+1234567890"`
+	Channel           string     `json:"channel" example:"sms" enums:"sms,email,webhook"`
+	Email             *string    `json:"email,omitempty" example:"jane@example.com"`
+	Subject           *string    `json:"subject,omitempty" example:"Your order has shipped"`
+	WebhookURL        *string    `json:"webhook_url,omitempty" example:"https://hooks.example.com/orders"`
 	Status            string     `json:"status" example:"sent"`
+	Category          string     `json:"category" example:"transactional"`
+	Campaign          *string    `json:"campaign,omitempty" example:"spring-sale"`
+	SenderID          *string    `json:"sender_id,omitempty" example:"INSIDER"`
 	CreatedAt         time.Time  `json:"created_at" example:"2023-01-01T12:00:00Z"`
 	UpdatedAt         time.Time  `json:"updated_at" example:"2023-01-01T12:05:00Z"`
 	SentAt            *time.Time `json:"sent_at,omitempty" example:"2023-01-01T12:05:00Z"`
 	ExternalMessageID *string    `json:"external_message_id,omitempty" example:"ext_msg_123"`
 	ErrorMessage      *string    `json:"error_message,omitempty" example:"Network error"`
+	ErrorCode         *string    `json:"error_code,omitempty" example:"network"`
+	Provider          *string    `json:"provider,omitempty" example:"default"`
+	Attempts          int        `json:"attempts" example:"1"`
+	NextAttemptAt     *time.Time `json:"next_attempt_at,omitempty" example:"2023-01-01T12:06:00Z"`
+	CostPrefix        *string    `json:"cost_prefix,omitempty" example:"90"`
+	EstimatedCost     *float64   `json:"estimated_cost,omitempty" example:"0.0125"`
+	ActualCost        *float64   `json:"actual_cost,omitempty" example:"0.0125"`
+
+	DeliveryStatus     *string    `json:"delivery_status,omitempty" example:"delivered" enums:"delivered,undelivered,expired,rejected,unknown"`
+	DeliveryError      *string    `json:"delivery_error,omitempty" example:"000"`
+	DeliveryReportedAt *time.Time `json:"delivery_reported_at,omitempty" example:"2023-01-01T12:06:00Z"`
 }
 
 type GetSentMessagesResponse struct {
@@ -33,24 +97,105 @@ type GetSentMessagesResponse struct {
 }
 
 type MessageStatsResponse struct {
-	TotalMessages   int64 `json:"total_messages" example:"1000"`
-	PendingMessages int64 `json:"pending_messages" example:"50"`
-	SentMessages    int64 `json:"sent_messages" example:"900"`
-	FailedMessages  int64 `json:"failed_messages" example:"50"`
+	TotalMessages   int64   `json:"total_messages" example:"1000"`
+	PendingMessages int64   `json:"pending_messages" example:"50"`
+	SentMessages    int64   `json:"sent_messages" example:"900"`
+	FailedMessages  int64   `json:"failed_messages" example:"50"`
+	EstimatedCost   float64 `json:"estimated_cost" example:"11.875"`
+	ActualCost      float64 `json:"actual_cost" example:"11.25"`
+	Currency        string  `json:"currency" example:"EUR"`
+	// FailuresByCode failed mesajlarin error_code dagilimi
+	FailuresByCode map[string]int64 `json:"failures_by_code"`
 }
 
 func ToMessageResponse(message *entities.Message) MessageResponse {
-	return MessageResponse{
+	response := MessageResponse{
 		ID:                message.ID,
 		Content:           message.Content,
 		PhoneNumber:       message.PhoneNumber,
+		Channel:           string(message.Channel),
+		Email:             message.Email,
+		Subject:           message.Subject,
+		WebhookURL:        message.WebhookURL,
 		Status:            string(message.Status),
+		Category:          string(message.Category),
+		Campaign:          message.Campaign,
+		SenderID:          message.SenderID,
 		CreatedAt:         message.CreatedAt,
 		UpdatedAt:         message.UpdatedAt,
 		SentAt:            message.SentAt,
 		ExternalMessageID: message.ExternalMessageID,
 		ErrorMessage:      message.ErrorMessage,
+		Provider:          message.Provider,
+		Attempts:          message.Attempts,
+		NextAttemptAt:     message.NextAttemptAt,
+		CostPrefix:        message.CostPrefix,
+		EstimatedCost:     message.EstimatedCost,
+		ActualCost:        message.ActualCost,
+
+		DeliveryError:      message.DeliveryError,
+		DeliveryReportedAt: message.DeliveryReportedAt,
+	}
+	if message.DeliveryStatus != nil {
+		deliveryStatus := string(*message.DeliveryStatus)
+		response.DeliveryStatus = &deliveryStatus
+	}
+	if message.ErrorCode != nil {
+		errorCode := string(*message.ErrorCode)
+		response.ErrorCode = &errorCode
+	}
+	return response
+}
+
+func (r CreateMessageRequest) ToInput() usecases.CreateMessageInput {
+	input := usecases.CreateMessageInput{
+		Channel:     entities.MessageChannel(r.Channel),
+		Content:     r.Content,
+		PhoneNumber: r.PhoneNumber,
+		Category:    entities.MessageCategory(r.Category),
+	}
+	if r.Email != "" {
+		email := r.Email
+		input.Email = &email
+	}
+	if r.Subject != "" {
+		subject := r.Subject
+		input.Subject = &subject
+	}
+	if r.WebhookURL != "" {
+		webhookURL := r.WebhookURL
+		input.WebhookURL = &webhookURL
+	}
+	if r.Campaign != "" {
+		campaign := r.Campaign
+		input.Campaign = &campaign
+	}
+	if r.SenderID != "" {
+		senderID := r.SenderID
+		input.SenderID = &senderID
+	}
+	return input
+}
+
+func (r UpdateMessageRequest) ToInput() usecases.UpdateMessageInput {
+	return usecases.UpdateMessageInput{
+		Content:     r.Content,
+		PhoneNumber: r.PhoneNumber,
+		Email:       r.Email,
+		Subject:     r.Subject,
+		WebhookURL:  r.WebhookURL,
+	}
+}
+
+func ToPolicyViolationResponses(violations []entities.PolicyViolation) []PolicyViolationResponse {
+	responses := make([]PolicyViolationResponse, len(violations))
+	for i, v := range violations {
+		responses[i] = PolicyViolationResponse{
+			Rule:    v.Rule,
+			Message: v.Message,
+		}
 	}
+	return responses
 }
 
 func ToMessageStatsResponse(stats *usecases.MessageStats) MessageStatsResponse {
@@ -59,5 +204,9 @@ func ToMessageStatsResponse(stats *usecases.MessageStats) MessageStatsResponse {
 		PendingMessages: stats.PendingMessages,
 		SentMessages:    stats.SentMessages,
 		FailedMessages:  stats.FailedMessages,
+		EstimatedCost:   stats.EstimatedCost,
+		ActualCost:      stats.ActualCost,
+		Currency:        stats.Currency,
+		FailuresByCode:  stats.FailuresByCode,
 	}
 }
@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"time"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+// CostReportQuery'de from/to RFC3339, group_by verilmezse kampanyaya gore gruplanir
+type CostReportQuery struct {
+	GroupBy  string     `form:"group_by" binding:"omitempty,oneof=campaign country day" example:"campaign"`
+	Campaign string     `form:"campaign" example:"spring-sale"`
+	From     *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00" example:"2023-01-01T00:00:00Z"`
+	To       *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00" example:"2023-02-01T00:00:00Z"`
+}
+
+type PriceEntryResponse struct {
+	Provider        string  `json:"provider" example:"default"`
+	Prefix          string  `json:"prefix" example:"90"`
+	PricePerSegment float64 `json:"price_per_segment" example:"0.0125"`
+}
+
+type PriceListResponse struct {
+	Currency string               `json:"currency" example:"EUR"`
+	Entries  []PriceEntryResponse `json:"entries"`
+}
+
+type CostReportRowResponse struct {
+	Key           string  `json:"key" example:"spring-sale"`
+	Messages      int64   `json:"messages" example:"1000"`
+	SentMessages  int64   `json:"sent_messages" example:"900"`
+	EstimatedCost float64 `json:"estimated_cost" example:"12.5"`
+	ActualCost    float64 `json:"actual_cost" example:"11.25"`
+}
+
+type CostReportResponse struct {
+	Currency string                  `json:"currency" example:"EUR"`
+	GroupBy  string                  `json:"group_by" example:"campaign"`
+	Rows     []CostReportRowResponse `json:"rows"`
+	Total    CostReportRowResponse   `json:"total"`
+}
+
+func (q CostReportQuery) ToFilter() entities.CostReportFilter {
+	filter := entities.CostReportFilter{
+		GroupBy: q.GroupBy,
+		From:    q.From,
+		To:      q.To,
+	}
+	if filter.GroupBy == "" {
+		filter.GroupBy = entities.CostGroupCampaign
+	}
+	if q.Campaign != "" {
+		filter.Campaign = &q.Campaign
+	}
+	return filter
+}
+
+func ToPriceListResponse(prices *usecases.PriceList) PriceListResponse {
+	entries := make([]PriceEntryResponse, len(prices.Entries))
+	for i, entry := range prices.Entries {
+		entries[i] = PriceEntryResponse{
+			Provider:        entry.Provider,
+			Prefix:          entry.Prefix,
+			PricePerSegment: entry.PricePerSegment,
+		}
+	}
+	return PriceListResponse{
+		Currency: prices.Currency,
+		Entries:  entries,
+	}
+}
+
+func ToCostReportRowResponse(row entities.CostReportRow) CostReportRowResponse {
+	return CostReportRowResponse{
+		Key:           row.Key,
+		Messages:      row.Messages,
+		SentMessages:  row.SentMessages,
+		EstimatedCost: row.EstimatedCost,
+		ActualCost:    row.ActualCost,
+	}
+}
+
+func ToCostReportResponse(report *usecases.CostReport) CostReportResponse {
+	rows := make([]CostReportRowResponse, len(report.Rows))
+	for i, row := range report.Rows {
+		rows[i] = ToCostReportRowResponse(*row)
+	}
+	return CostReportResponse{
+		Currency: report.Currency,
+		GroupBy:  report.GroupBy,
+		Rows:     rows,
+		Total:    ToCostReportRowResponse(report.Total),
+	}
+}
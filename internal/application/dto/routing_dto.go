@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type RouteTargetRequest struct {
+	Provider string `json:"provider" binding:"required" example:"backup"`
+	Weight   int    `json:"weight" binding:"min=0" example:"70"`
+}
+
+// RoutingRuleRequest'te bos prefix tum numaralar, tenant_id ve category verilmezse tum tenant ve kategoriler demek
+type RoutingRuleRequest struct {
+	Name     string               `json:"name" binding:"required,max=100" example:"turkey-least-cost"`
+	Prefix   string               `json:"prefix" binding:"omitempty,numeric,max=16" example:"90"`
+	TenantID *uuid.UUID           `json:"tenant_id,omitempty" example:"00000000-0000-0000-0000-000000000001"`
+	Category *string              `json:"category,omitempty" binding:"omitempty,oneof=transactional marketing" example:"marketing"`
+	Targets  []RouteTargetRequest `json:"targets" binding:"required,min=1,dive"`
+	Enabled  *bool                `json:"enabled,omitempty" example:"true"`
+}
+
+type RouteTargetResponse struct {
+	Provider string `json:"provider" example:"backup"`
+	Weight   int    `json:"weight" example:"70"`
+}
+
+type RoutingRuleResponse struct {
+	ID        uuid.UUID             `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name      string                `json:"name" example:"turkey-least-cost"`
+	Prefix    string                `json:"prefix" example:"90"`
+	TenantID  *uuid.UUID            `json:"tenant_id,omitempty" example:"00000000-0000-0000-0000-000000000001"`
+	Category  *string               `json:"category,omitempty" example:"marketing"`
+	Targets   []RouteTargetResponse `json:"targets"`
+	Enabled   bool                  `json:"enabled" example:"true"`
+	CreatedAt time.Time             `json:"created_at" example:"2023-01-01T12:00:00Z"`
+	UpdatedAt time.Time             `json:"updated_at" example:"2023-01-01T12:00:00Z"`
+}
+
+func (r RoutingRuleRequest) ToEntity(id uuid.UUID) *entities.RoutingRule {
+	rule := &entities.RoutingRule{
+		ID:       id,
+		Name:     r.Name,
+		Prefix:   r.Prefix,
+		TenantID: r.TenantID,
+		Targets:  make([]entities.RouteTarget, len(r.Targets)),
+		Enabled:  r.Enabled == nil || *r.Enabled,
+	}
+	if r.Category != nil {
+		category := entities.MessageCategory(*r.Category)
+		rule.Category = &category
+	}
+	for i, target := range r.Targets {
+		rule.Targets[i] = entities.RouteTarget{Provider: target.Provider, Weight: target.Weight}
+	}
+	return rule
+}
+
+func ToRoutingRuleResponse(rule *entities.RoutingRule) RoutingRuleResponse {
+	response := RoutingRuleResponse{
+		ID:        rule.ID,
+		Name:      rule.Name,
+		Prefix:    rule.Prefix,
+		TenantID:  rule.TenantID,
+		Targets:   make([]RouteTargetResponse, len(rule.Targets)),
+		Enabled:   rule.Enabled,
+		CreatedAt: rule.CreatedAt,
+		UpdatedAt: rule.UpdatedAt,
+	}
+	if rule.Category != nil {
+		category := string(*rule.Category)
+		response.Category = &category
+	}
+	for i, target := range rule.Targets {
+		response.Targets[i] = RouteTargetResponse{Provider: target.Provider, Weight: target.Weight}
+	}
+	return response
+}
+
+func ToRoutingRuleResponses(rules []*entities.RoutingRule) []RoutingRuleResponse {
+	responses := make([]RoutingRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = ToRoutingRuleResponse(rule)
+	}
+	return responses
+}
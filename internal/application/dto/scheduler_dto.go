@@ -1,18 +1,37 @@
 package dto
 
 import (
+	"math"
 	"time"
 
 	"message-sending-service/internal/domain/entities"
 )
 
 type SchedulerStatusResponse struct {
-	Status        string     `json:"status" example:"running"`
-	LastRun       *time.Time `json:"last_run,omitempty" example:"2023-01-01T12:00:00Z"`
-	NextRun       *time.Time `json:"next_run,omitempty" example:"2023-01-01T12:02:00Z"`
-	MessagesCount int        `json:"messages_sent_count" example:"150"`
-	Interval      string     `json:"interval" example:"2m0s"`
-	BatchSize     int        `json:"batch_size" example:"2"`
+	Status        string                   `json:"status" example:"running"`
+	LastRun       *time.Time               `json:"last_run,omitempty" example:"2023-01-01T12:00:00Z"`
+	NextRun       *time.Time               `json:"next_run,omitempty" example:"2023-01-01T12:02:00Z"`
+	MessagesCount int                      `json:"messages_sent_count" example:"150"`
+	Interval      string                   `json:"interval" example:"2m0s"`
+	BatchSize     int                      `json:"batch_size" example:"2"`
+	Providers     []ProviderHealthResponse `json:"providers,omitempty"`
+}
+
+// ProviderHealthResponse'ta skor ve hata orani son PROVIDER_HEALTH_WINDOW denemeye gore hesaplanir
+type ProviderHealthResponse struct {
+	Name          string     `json:"name" example:"primary"`
+	Priority      int        `json:"priority" example:"1"`
+	Healthy       bool       `json:"healthy" example:"true"`
+	Score         float64    `json:"score" example:"0.95"`
+	ErrorRate     float64    `json:"error_rate" example:"0.05"`
+	AvgLatencyMs  int64      `json:"avg_latency_ms" example:"240"`
+	Attempts      int        `json:"attempts" example:"20"`
+	Failures      int        `json:"failures" example:"1"`
+	LastError     *string    `json:"last_error,omitempty" example:"API request failed with status 503"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty" example:"2023-01-01T12:00:00Z"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty" example:"2023-01-01T12:01:00Z"`
+	Circuit       string     `json:"circuit" example:"closed" enums:"closed,open,half_open"`
+	CircuitOpenAt *time.Time `json:"circuit_open_at,omitempty" example:"2023-01-01T12:00:00Z"`
 }
 
 type StartSchedulerResponse struct {
@@ -33,5 +52,32 @@ func ToSchedulerStatusResponse(info *entities.SchedulerInfo) SchedulerStatusResp
 		MessagesCount: info.MessagesCount,
 		Interval:      info.Interval.String(),
 		BatchSize:     info.BatchSize,
+		Providers:     ToProviderHealthResponses(info.Providers),
+	}
+}
+
+func ToProviderHealthResponses(health []entities.ProviderHealth) []ProviderHealthResponse {
+	if len(health) == 0 {
+		return nil
+	}
+
+	responses := make([]ProviderHealthResponse, len(health))
+	for i, h := range health {
+		responses[i] = ProviderHealthResponse{
+			Name:          h.Name,
+			Priority:      h.Priority,
+			Healthy:       h.Healthy,
+			Score:         math.Round(h.Score*1000) / 1000,
+			ErrorRate:     math.Round(h.ErrorRate*1000) / 1000,
+			AvgLatencyMs:  h.AvgLatency.Milliseconds(),
+			Attempts:      h.Attempts,
+			Failures:      h.Failures,
+			LastError:     h.LastError,
+			LastFailureAt: h.LastFailureAt,
+			LastSuccessAt: h.LastSuccessAt,
+			Circuit:       string(h.Circuit),
+			CircuitOpenAt: h.CircuitOpenAt,
+		}
 	}
+	return responses
 }
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type TenantRequest struct {
+	Name string `json:"name" binding:"required,max=255" example:"Growth team"`
+}
+
+type TenantResponse struct {
+	ID        uuid.UUID `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name      string    `json:"name" example:"Growth team"`
+	CreatedAt time.Time `json:"created_at" example:"2023-01-01T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T12:00:00Z"`
+}
+
+func ToTenantResponse(tenant *entities.Tenant) TenantResponse {
+	return TenantResponse{
+		ID:        tenant.ID,
+		Name:      tenant.Name,
+		CreatedAt: tenant.CreatedAt,
+		UpdatedAt: tenant.UpdatedAt,
+	}
+}
+
+func ToTenantResponses(tenants []*entities.Tenant) []TenantResponse {
+	responses := make([]TenantResponse, len(tenants))
+	for i, tenant := range tenants {
+		responses[i] = ToTenantResponse(tenant)
+	}
+	return responses
+}
@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+// UsageQuery'de from/to verilmezse gunluk icin son 30 gun, aylik icin son 12 ay doner
+type UsageQuery struct {
+	Period string     `form:"period" binding:"omitempty,oneof=day month" example:"day"`
+	From   *time.Time `form:"from" time_format:"2006-01-02" example:"2023-01-01"`
+	To     *time.Time `form:"to" time_format:"2006-01-02" example:"2023-01-31"`
+}
+
+type QuotaRequest struct {
+	DailyMessages    int64 `json:"daily_messages" binding:"min=0" example:"10000"`
+	DailySegments    int64 `json:"daily_segments" binding:"min=0" example:"0"`
+	MonthlyMessages  int64 `json:"monthly_messages" binding:"min=0" example:"200000"`
+	MonthlySegments  int64 `json:"monthly_segments" binding:"min=0" example:"0"`
+	SoftLimitPercent int   `json:"soft_limit_percent" binding:"min=0,max=100" example:"80"`
+}
+
+type QuotaResponse struct {
+	TenantID         uuid.UUID  `json:"tenant_id" example:"00000000-0000-0000-0000-000000000001"`
+	DailyMessages    int64      `json:"daily_messages" example:"10000"`
+	DailySegments    int64      `json:"daily_segments" example:"0"`
+	MonthlyMessages  int64      `json:"monthly_messages" example:"200000"`
+	MonthlySegments  int64      `json:"monthly_segments" example:"0"`
+	SoftLimitPercent int        `json:"soft_limit_percent" example:"80"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty" example:"2023-01-01T12:00:00Z"`
+}
+
+type UsageRecordResponse struct {
+	PeriodStart time.Time `json:"period_start" example:"2023-01-01T00:00:00Z"`
+	Messages    int64     `json:"messages" example:"120"`
+	Segments    int64     `json:"segments" example:"150"`
+}
+
+type QuotaLimitResponse struct {
+	Period   string    `json:"period" example:"day"`
+	Metric   string    `json:"metric" example:"messages"`
+	Limit    int64     `json:"limit" example:"10000"`
+	Soft     int64     `json:"soft_limit" example:"8000"`
+	Used     int64     `json:"used" example:"120"`
+	ResetsAt time.Time `json:"resets_at" example:"2023-01-02T00:00:00Z"`
+}
+
+type UsageResponse struct {
+	TenantID uuid.UUID             `json:"tenant_id" example:"00000000-0000-0000-0000-000000000001"`
+	Quota    QuotaResponse         `json:"quota"`
+	Today    UsageRecordResponse   `json:"today"`
+	Month    UsageRecordResponse   `json:"month"`
+	Limits   []QuotaLimitResponse  `json:"limits"`
+	Period   string                `json:"period" example:"day"`
+	History  []UsageRecordResponse `json:"history"`
+}
+
+// QuotaExceededResponse 429 cevabinin details alani
+type QuotaExceededResponse struct {
+	Period    string    `json:"period" example:"day"`
+	Metric    string    `json:"metric" example:"messages"`
+	Limit     int64     `json:"limit" example:"10000"`
+	Used      int64     `json:"used" example:"10000"`
+	Requested int64     `json:"requested" example:"1"`
+	ResetsAt  time.Time `json:"resets_at" example:"2023-01-02T00:00:00Z"`
+}
+
+func (r QuotaRequest) ToEntity(tenantID uuid.UUID) *entities.TenantQuota {
+	return &entities.TenantQuota{
+		TenantID:         tenantID,
+		DailyMessages:    r.DailyMessages,
+		DailySegments:    r.DailySegments,
+		MonthlyMessages:  r.MonthlyMessages,
+		MonthlySegments:  r.MonthlySegments,
+		SoftLimitPercent: r.SoftLimitPercent,
+	}
+}
+
+func ToQuotaResponse(quota *entities.TenantQuota) QuotaResponse {
+	response := QuotaResponse{
+		TenantID:         quota.TenantID,
+		DailyMessages:    quota.DailyMessages,
+		DailySegments:    quota.DailySegments,
+		MonthlyMessages:  quota.MonthlyMessages,
+		MonthlySegments:  quota.MonthlySegments,
+		SoftLimitPercent: quota.SoftLimitPercent,
+	}
+	// config varsayilanlari icin updated_at yok
+	if !quota.UpdatedAt.IsZero() {
+		response.UpdatedAt = &quota.UpdatedAt
+	}
+	return response
+}
+
+func ToUsageRecordResponse(record entities.UsageRecord) UsageRecordResponse {
+	return UsageRecordResponse{
+		PeriodStart: record.PeriodStart,
+		Messages:    record.Messages,
+		Segments:    record.Segments,
+	}
+}
+
+func ToUsageResponse(summary *usecases.UsageSummary, period string, history []*entities.UsageRecord) UsageResponse {
+	limits := make([]QuotaLimitResponse, len(summary.Limits))
+	for i, limit := range summary.Limits {
+		limits[i] = QuotaLimitResponse{
+			Period:   limit.Period,
+			Metric:   limit.Metric,
+			Limit:    limit.Hard,
+			Soft:     limit.Soft,
+			Used:     limit.Used,
+			ResetsAt: limit.ResetsAt,
+		}
+	}
+
+	records := make([]UsageRecordResponse, len(history))
+	for i, record := range history {
+		records[i] = ToUsageRecordResponse(*record)
+	}
+
+	return UsageResponse{
+		TenantID: summary.TenantID,
+		Quota:    ToQuotaResponse(summary.Quota),
+		Today:    ToUsageRecordResponse(summary.Today),
+		Month:    ToUsageRecordResponse(summary.Month),
+		Limits:   limits,
+		Period:   period,
+		History:  records,
+	}
+}
+
+func ToQuotaExceededResponse(err *entities.QuotaExceededError) QuotaExceededResponse {
+	return QuotaExceededResponse{
+		Period:    err.Period,
+		Metric:    err.Metric,
+		Limit:     err.Limit,
+		Used:      err.Used,
+		Requested: err.Requested,
+		ResetsAt:  err.ResetsAt,
+	}
+}
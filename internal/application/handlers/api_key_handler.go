@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type APIKeyHandler struct {
+	apiKeyUseCase usecases.APIKeyUseCase
+	logger        *zap.Logger
+}
+
+func NewAPIKeyHandler(apiKeyUseCase usecases.APIKeyUseCase, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUseCase: apiKeyUseCase,
+		logger:        logger,
+	}
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Create an API key for a tenant. The plaintext key is only returned in this response.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param apiKey body dto.CreateAPIKeyRequest true "API key data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.CreateAPIKeyResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	key, rawKey, err := h.apiKeyUseCase.CreateAPIKey(c.Request.Context(), req.ToInput())
+	if err != nil {
+		h.handleError(c, err, "Failed to create API key")
+		return
+	}
+
+	response := dto.CreateAPIKeyResponse{
+		APIKeyResponse: dto.ToAPIKeyResponse(key),
+		Key:            rawKey,
+	}
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse("API key created successfully", response))
+}
+
+// GetAPIKeys godoc
+// @Summary List API keys
+// @Tags api-keys
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.APIKeyResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api-keys [get]
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	var query dto.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_query", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	keys, err := h.apiKeyUseCase.GetAPIKeys(c.Request.Context(), query.Page, query.Limit)
+	if err != nil {
+		h.handleError(c, err, "Failed to get API keys")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("API keys retrieved successfully", dto.ToAPIKeyResponses(keys)))
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Tags api-keys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "API key")
+	if !ok {
+		return
+	}
+
+	if err := h.apiKeyUseCase.RevokeAPIKey(c.Request.Context(), id); err != nil {
+		h.handleError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("API key revoked successfully", nil))
+}
+
+func (h *APIKeyHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, entities.ErrAPIKeyNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "API key not found", http.StatusNotFound))
+	case errors.Is(err, entities.ErrTenantNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("tenant_not_found", "Tenant not found", http.StatusNotFound))
+	case errors.Is(err, entities.ErrInvalidAPIKeyName), errors.Is(err, entities.ErrInvalidAPIKeyScope):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("validation_error", err.Error(), http.StatusBadRequest))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", message, http.StatusInternalServerError))
+	}
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type AuditHandler struct {
+	auditUseCase usecases.AuditUseCase
+	logger       *zap.Logger
+}
+
+func NewAuditHandler(auditUseCase usecases.AuditUseCase, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		auditUseCase: auditUseCase,
+		logger:       logger,
+	}
+}
+
+// GetAuditLogs godoc
+// @Summary List audit log entries
+// @Description List administrative actions (scheduler start/stop, message cancel/edit/requeue, API key changes), newest first
+// @Tags audit
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param tenant_id query string false "Tenant ID"
+// @Param actor query string false "Actor ID (API key ID or JWT subject)"
+// @Param action query string false "Action, e.g. scheduler.stop"
+// @Param target_type query string false "Target type" Enums(scheduler, message, api_key, routing_rule)
+// @Param target_id query string false "Target ID"
+// @Param from query string false "Start time (RFC3339, inclusive)"
+// @Param to query string false "End time (RFC3339, exclusive)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.GetAuditLogsResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /audit [get]
+func (h *AuditHandler) GetAuditLogs(c *gin.Context) {
+	var query dto.AuditLogQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_query", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	entries, totalCount, err := h.auditUseCase.GetAuditLogs(c.Request.Context(), query.ToFilter(), query.Page, query.Limit)
+	if err != nil {
+		h.logger.Error("Failed to get audit logs", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to get audit logs", http.StatusInternalServerError))
+		return
+	}
+
+	responses := make([]dto.AuditLogResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = dto.ToAuditLogResponse(entry)
+	}
+
+	response := dto.GetAuditLogsResponse{
+		Entries:    responses,
+		TotalCount: totalCount,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: int(math.Ceil(float64(totalCount) / float64(query.Limit))),
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Audit logs retrieved successfully", response))
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+)
+
+type mockAuditUseCase struct {
+	domainUsecases.AuditUseCase
+	filter entities.AuditLogFilter
+	page   int
+	limit  int
+}
+
+func (m *mockAuditUseCase) GetAuditLogs(ctx context.Context, filter entities.AuditLogFilter, page, limit int) ([]*entities.AuditLog, int64, error) {
+	m.filter, m.page, m.limit = filter, page, limit
+	return []*entities.AuditLog{{
+		ID:         uuid.New(),
+		ActorID:    "ops",
+		Action:     entities.AuditActionSchedulerStop,
+		TargetType: entities.AuditTargetScheduler,
+		Before:     json.RawMessage(`{"status":"running"}`),
+		After:      json.RawMessage(`{"status":"stopped"}`),
+		CreatedAt:  time.Now(),
+	}}, 21, nil
+}
+
+func TestAuditHandler_GetAuditLogs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tenantID := uuid.New()
+	mockUseCase := &mockAuditUseCase{}
+	handler := NewAuditHandler(mockUseCase, zap.NewNop())
+
+	router := gin.New()
+	router.GET("/audit", handler.GetAuditLogs)
+
+	req := httptest.NewRequest("GET", "/audit?page=2&limit=10&action=scheduler.stop&tenant_id="+tenantID.String()+"&from=2024-01-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	if mockUseCase.page != 2 || mockUseCase.limit != 10 {
+		t.Errorf("Expected page 2 limit 10, got %d %d", mockUseCase.page, mockUseCase.limit)
+	}
+	if mockUseCase.filter.Action != entities.AuditActionSchedulerStop {
+		t.Errorf("Expected action filter, got %q", mockUseCase.filter.Action)
+	}
+	if mockUseCase.filter.TenantID == nil || *mockUseCase.filter.TenantID != tenantID {
+		t.Errorf("Expected tenant filter %v, got %v", tenantID, mockUseCase.filter.TenantID)
+	}
+	if mockUseCase.filter.From == nil || !mockUseCase.filter.From.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected from filter, got %v", mockUseCase.filter.From)
+	}
+
+	var response struct {
+		Data struct {
+			Entries    []map[string]interface{} `json:"entries"`
+			TotalPages int                      `json:"total_pages"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Data.Entries) != 1 || response.Data.TotalPages != 3 {
+		t.Errorf("Unexpected response: %s", w.Body.String())
+	}
+	if before, ok := response.Data.Entries[0]["before"].(map[string]interface{}); !ok || before["status"] != "running" {
+		t.Errorf("Expected before state in entry, got %v", response.Data.Entries[0]["before"])
+	}
+}
+
+func TestAuditHandler_GetAuditLogs_InvalidQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := NewAuditHandler(&mockAuditUseCase{}, zap.NewNop())
+	router := gin.New()
+	router.GET("/audit", handler.GetAuditLogs)
+
+	for _, query := range []string{"from=yesterday", "tenant_id=abc", "target_type=contact"} {
+		req := httptest.NewRequest("GET", "/audit?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
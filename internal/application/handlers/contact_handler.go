@@ -0,0 +1,492 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type ContactHandler struct {
+	contactUseCase usecases.ContactUseCase
+	logger         *zap.Logger
+}
+
+func NewContactHandler(contactUseCase usecases.ContactUseCase, logger *zap.Logger) *ContactHandler {
+	return &ContactHandler{
+		contactUseCase: contactUseCase,
+		logger:         logger,
+	}
+}
+
+// CreateContact godoc
+// @Summary Create a contact
+// @Description Create a new contact with optional template attributes
+// @Tags contacts
+// @Accept json
+// @Produce json
+// @Param contact body dto.ContactRequest true "Contact data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ContactResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /contacts [post]
+func (h *ContactHandler) CreateContact(c *gin.Context) {
+	var req dto.ContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	contact := req.ToEntity()
+	if err := h.contactUseCase.CreateContact(c.Request.Context(), contact); err != nil {
+		h.handleError(c, err, "Failed to create contact")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse("Contact created successfully", dto.ToContactResponse(contact)))
+}
+
+// GetContact godoc
+// @Summary Get a contact by ID
+// @Tags contacts
+// @Produce json
+// @Param id path string true "Contact ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ContactResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /contacts/{id} [get]
+func (h *ContactHandler) GetContact(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "contact")
+	if !ok {
+		return
+	}
+
+	contact, err := h.contactUseCase.GetContact(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get contact")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Contact retrieved successfully", dto.ToContactResponse(contact)))
+}
+
+// UpdateContact godoc
+// @Summary Update a contact
+// @Tags contacts
+// @Accept json
+// @Produce json
+// @Param id path string true "Contact ID"
+// @Param contact body dto.ContactRequest true "Contact data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ContactResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /contacts/{id} [put]
+func (h *ContactHandler) UpdateContact(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "contact")
+	if !ok {
+		return
+	}
+
+	var req dto.ContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	existing, err := h.contactUseCase.GetContact(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get contact")
+		return
+	}
+
+	contact := req.ToEntity()
+	contact.ID = id
+	contact.CreatedAt = existing.CreatedAt
+	if err := h.contactUseCase.UpdateContact(c.Request.Context(), contact); err != nil {
+		h.handleError(c, err, "Failed to update contact")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Contact updated successfully", dto.ToContactResponse(contact)))
+}
+
+// DeleteContact godoc
+// @Summary Delete a contact
+// @Tags contacts
+// @Produce json
+// @Param id path string true "Contact ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /contacts/{id} [delete]
+func (h *ContactHandler) DeleteContact(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "contact")
+	if !ok {
+		return
+	}
+
+	if err := h.contactUseCase.DeleteContact(c.Request.Context(), id); err != nil {
+		h.handleError(c, err, "Failed to delete contact")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Contact deleted successfully", nil))
+}
+
+// GetContacts godoc
+// @Summary List contacts
+// @Tags contacts
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.SuccessResponse{data=dto.GetContactsResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /contacts [get]
+func (h *ContactHandler) GetContacts(c *gin.Context) {
+	var query dto.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_query", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	contacts, totalCount, err := h.contactUseCase.GetContacts(c.Request.Context(), query.Page, query.Limit)
+	if err != nil {
+		h.handleError(c, err, "Failed to get contacts")
+		return
+	}
+
+	response := dto.GetContactsResponse{
+		Contacts:   dto.ToContactResponses(contacts),
+		TotalCount: totalCount,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: int(math.Ceil(float64(totalCount) / float64(query.Limit))),
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Contacts retrieved successfully", response))
+}
+
+// CreateList godoc
+// @Summary Create a contact list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param list body dto.ContactListRequest true "List data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ContactListResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /lists [post]
+func (h *ContactHandler) CreateList(c *gin.Context) {
+	var req dto.ContactListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	list := req.ToEntity()
+	if err := h.contactUseCase.CreateList(c.Request.Context(), list); err != nil {
+		h.handleError(c, err, "Failed to create contact list")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse("Contact list created successfully", dto.ToContactListResponse(list)))
+}
+
+// GetList godoc
+// @Summary Get a contact list by ID
+// @Tags lists
+// @Produce json
+// @Param id path string true "List ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ContactListResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /lists/{id} [get]
+func (h *ContactHandler) GetList(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "list")
+	if !ok {
+		return
+	}
+
+	list, err := h.contactUseCase.GetList(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get contact list")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Contact list retrieved successfully", dto.ToContactListResponse(list)))
+}
+
+// UpdateList godoc
+// @Summary Update a contact list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Param list body dto.ContactListRequest true "List data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ContactListResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /lists/{id} [put]
+func (h *ContactHandler) UpdateList(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "list")
+	if !ok {
+		return
+	}
+
+	var req dto.ContactListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	list, err := h.contactUseCase.GetList(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get contact list")
+		return
+	}
+
+	list.Name = req.Name
+	list.Description = req.Description
+	if err := h.contactUseCase.UpdateList(c.Request.Context(), list); err != nil {
+		h.handleError(c, err, "Failed to update contact list")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Contact list updated successfully", dto.ToContactListResponse(list)))
+}
+
+// DeleteList godoc
+// @Summary Delete a contact list
+// @Description Delete a contact list, contacts themselves are kept
+// @Tags lists
+// @Produce json
+// @Param id path string true "List ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /lists/{id} [delete]
+func (h *ContactHandler) DeleteList(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "list")
+	if !ok {
+		return
+	}
+
+	if err := h.contactUseCase.DeleteList(c.Request.Context(), id); err != nil {
+		h.handleError(c, err, "Failed to delete contact list")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Contact list deleted successfully", nil))
+}
+
+// GetLists godoc
+// @Summary List contact lists
+// @Tags lists
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.SuccessResponse{data=dto.GetContactListsResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /lists [get]
+func (h *ContactHandler) GetLists(c *gin.Context) {
+	var query dto.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_query", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	lists, totalCount, err := h.contactUseCase.GetLists(c.Request.Context(), query.Page, query.Limit)
+	if err != nil {
+		h.handleError(c, err, "Failed to get contact lists")
+		return
+	}
+
+	listResponses := make([]dto.ContactListResponse, len(lists))
+	for i, list := range lists {
+		listResponses[i] = dto.ToContactListResponse(list)
+	}
+
+	response := dto.GetContactListsResponse{
+		Lists:      listResponses,
+		TotalCount: totalCount,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: int(math.Ceil(float64(totalCount) / float64(query.Limit))),
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Contact lists retrieved successfully", response))
+}
+
+// AddListContacts godoc
+// @Summary Add contacts to a list
+// @Description Add existing contacts to a list, unknown or already added contacts are ignored
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Param contacts body dto.AddListContactsRequest true "Contact IDs"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /lists/{id}/contacts [post]
+func (h *ContactHandler) AddListContacts(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "list")
+	if !ok {
+		return
+	}
+
+	var req dto.AddListContactsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	if err := h.contactUseCase.AddContactsToList(c.Request.Context(), id, req.ContactIDs); err != nil {
+		h.handleError(c, err, "Failed to add contacts to list")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Contacts added to list successfully", nil))
+}
+
+// RemoveListContact godoc
+// @Summary Remove a contact from a list
+// @Tags lists
+// @Produce json
+// @Param id path string true "List ID"
+// @Param contactId path string true "Contact ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /lists/{id}/contacts/{contactId} [delete]
+func (h *ContactHandler) RemoveListContact(c *gin.Context) {
+	listID, ok := parseIDParam(c, "id", "list")
+	if !ok {
+		return
+	}
+
+	contactID, ok := parseIDParam(c, "contactId", "contact")
+	if !ok {
+		return
+	}
+
+	if err := h.contactUseCase.RemoveContactFromList(c.Request.Context(), listID, contactID); err != nil {
+		h.handleError(c, err, "Failed to remove contact from list")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Contact removed from list successfully", nil))
+}
+
+// GetListContacts godoc
+// @Summary List the contacts of a list
+// @Tags lists
+// @Produce json
+// @Param id path string true "List ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.ContactResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /lists/{id}/contacts [get]
+func (h *ContactHandler) GetListContacts(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "list")
+	if !ok {
+		return
+	}
+
+	var query dto.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_query", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	contacts, err := h.contactUseCase.GetListContacts(c.Request.Context(), id, query.Page, query.Limit)
+	if err != nil {
+		h.handleError(c, err, "Failed to get list contacts")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("List contacts retrieved successfully", dto.ToContactResponses(contacts)))
+}
+
+// Broadcast godoc
+// @Summary Broadcast a message to a contact list
+// @Description Render the content template for every non-suppressed contact and queue one pending message per contact
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID"
+// @Param broadcast body dto.BroadcastRequest true "Broadcast data"
+// @Success 202 {object} dto.SuccessResponse{data=dto.BroadcastResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /lists/{id}/broadcast [post]
+func (h *ContactHandler) Broadcast(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "list")
+	if !ok {
+		return
+	}
+
+	var req dto.BroadcastRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	result, err := h.contactUseCase.Broadcast(c.Request.Context(), id, req.ToInput())
+	if err != nil {
+		h.handleError(c, err, "Failed to broadcast message")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.NewSuccessResponse("Broadcast queued successfully", dto.ToBroadcastResponse(result)))
+}
+
+func (h *ContactHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, entities.ErrContactNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "Contact not found", http.StatusNotFound))
+	case errors.Is(err, entities.ErrContactListNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "Contact list not found", http.StatusNotFound))
+	case errors.Is(err, entities.ErrContactAlreadyExists):
+		c.JSON(http.StatusConflict, dto.NewErrorResponse("already_exists", err.Error(), http.StatusConflict))
+	case errors.Is(err, entities.ErrInvalidPhoneNumber),
+		errors.Is(err, entities.ErrInvalidContactListName),
+		errors.Is(err, entities.ErrInvalidMessageTemplate):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("validation_error", err.Error(), http.StatusBadRequest))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", message, http.StatusInternalServerError))
+	}
+}
+
+func parseIDParam(c *gin.Context, param, resource string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_id", "Invalid "+resource+" ID format", http.StatusBadRequest))
+		return uuid.Nil, false
+	}
+	return id, true
+}
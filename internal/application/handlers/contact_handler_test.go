@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+)
+
+type mockContactUseCase struct {
+	createContactFunc func(ctx context.Context, contact *entities.Contact) error
+	broadcastFunc     func(ctx context.Context, listID uuid.UUID, input domainUsecases.BroadcastInput) (*domainUsecases.BroadcastResult, error)
+}
+
+func (m *mockContactUseCase) CreateContact(ctx context.Context, contact *entities.Contact) error {
+	if m.createContactFunc != nil {
+		return m.createContactFunc(ctx, contact)
+	}
+	contact.ID = uuid.New()
+	return nil
+}
+
+func (m *mockContactUseCase) GetContact(ctx context.Context, id uuid.UUID) (*entities.Contact, error) {
+	return &entities.Contact{ID: id, PhoneNumber: "+1234567890"}, nil
+}
+
+func (m *mockContactUseCase) UpdateContact(ctx context.Context, contact *entities.Contact) error {
+	return nil
+}
+
+func (m *mockContactUseCase) DeleteContact(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (m *mockContactUseCase) GetContacts(ctx context.Context, page, limit int) ([]*entities.Contact, int64, error) {
+	return []*entities.Contact{}, 0, nil
+}
+
+func (m *mockContactUseCase) CreateList(ctx context.Context, list *entities.ContactList) error {
+	return nil
+}
+
+func (m *mockContactUseCase) GetList(ctx context.Context, id uuid.UUID) (*entities.ContactList, error) {
+	return &entities.ContactList{ID: id, Name: "VIP"}, nil
+}
+
+func (m *mockContactUseCase) UpdateList(ctx context.Context, list *entities.ContactList) error {
+	return nil
+}
+
+func (m *mockContactUseCase) DeleteList(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (m *mockContactUseCase) GetLists(ctx context.Context, page, limit int) ([]*entities.ContactList, int64, error) {
+	return []*entities.ContactList{}, 0, nil
+}
+
+func (m *mockContactUseCase) AddContactsToList(ctx context.Context, listID uuid.UUID, contactIDs []uuid.UUID) error {
+	return nil
+}
+
+func (m *mockContactUseCase) RemoveContactFromList(ctx context.Context, listID, contactID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockContactUseCase) GetListContacts(ctx context.Context, listID uuid.UUID, page, limit int) ([]*entities.Contact, error) {
+	return []*entities.Contact{}, nil
+}
+
+func (m *mockContactUseCase) Broadcast(ctx context.Context, listID uuid.UUID, input domainUsecases.BroadcastInput) (*domainUsecases.BroadcastResult, error) {
+	if m.broadcastFunc != nil {
+		return m.broadcastFunc(ctx, listID, input)
+	}
+	return &domainUsecases.BroadcastResult{ListID: listID, Queued: 1, MessageIDs: []uuid.UUID{uuid.New()}}, nil
+}
+
+func TestContactHandler_CreateContact(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, contact *entities.Contact) error
+		expectedStatus int
+	}{
+		{
+			name:           "successful creation",
+			requestBody:    dto.ContactRequest{PhoneNumber: "+1234567890", Name: "Ada"},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing phone number",
+			requestBody:    dto.ContactRequest{Name: "Ada"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "duplicate phone number",
+			requestBody: dto.ContactRequest{PhoneNumber: "+1234567890"},
+			mockFunc: func(ctx context.Context, contact *entities.Contact) error {
+				return entities.ErrContactAlreadyExists
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewContactHandler(&mockContactUseCase{createContactFunc: tt.mockFunc}, zap.NewNop())
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest("POST", "/contacts", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.CreateContact(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestContactHandler_Broadcast(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		listID         string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, listID uuid.UUID, input domainUsecases.BroadcastInput) (*domainUsecases.BroadcastResult, error)
+		expectedStatus int
+	}{
+		{
+			name:           "successful broadcast",
+			listID:         uuid.New().String(),
+			requestBody:    dto.BroadcastRequest{Content: "Hi {{.name}}"},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "invalid list id",
+			listID:         "invalid-uuid",
+			requestBody:    dto.BroadcastRequest{Content: "Hi"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "list not found",
+			listID:      uuid.New().String(),
+			requestBody: dto.BroadcastRequest{Content: "Hi"},
+			mockFunc: func(ctx context.Context, listID uuid.UUID, input domainUsecases.BroadcastInput) (*domainUsecases.BroadcastResult, error) {
+				return nil, entities.ErrContactListNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:        "invalid template",
+			listID:      uuid.New().String(),
+			requestBody: dto.BroadcastRequest{Content: "Hi {{.name"},
+			mockFunc: func(ctx context.Context, listID uuid.UUID, input domainUsecases.BroadcastInput) (*domainUsecases.BroadcastResult, error) {
+				return nil, entities.ErrInvalidMessageTemplate
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewContactHandler(&mockContactUseCase{broadcastFunc: tt.mockFunc}, zap.NewNop())
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest("POST", "/lists/"+tt.listID+"/broadcast", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{
+				{Key: "id", Value: tt.listID},
+			}
+
+			handler.Broadcast(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
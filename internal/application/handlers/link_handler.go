@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type LinkHandler struct {
+	linkUseCase usecases.LinkUseCase
+	logger      *zap.Logger
+}
+
+func NewLinkHandler(linkUseCase usecases.LinkUseCase, logger *zap.Logger) *LinkHandler {
+	return &LinkHandler{
+		linkUseCase: linkUseCase,
+		logger:      logger,
+	}
+}
+
+// Redirect godoc
+// @Summary Follow a short link
+// @Description Record a click and redirect to the original URL
+// @Tags links
+// @Param code path string true "Short link code"
+// @Success 302
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /r/{code} [get]
+func (h *LinkHandler) Redirect(c *gin.Context) {
+	code := c.Param("code")
+
+	target, err := h.linkUseCase.ResolveClick(c.Request.Context(), code, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if errors.Is(err, entities.ErrShortLinkNotFound) {
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "Short link not found", http.StatusNotFound))
+			return
+		}
+
+		h.logger.Error("Failed to resolve short link", zap.String("code", code), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to resolve short link", http.StatusInternalServerError))
+		return
+	}
+
+	c.Redirect(http.StatusFound, target)
+}
+
+// GetMessageClicks godoc
+// @Summary Get link clicks for a message
+// @Description Get click counts for every short link in a message
+// @Tags links
+// @Produce json
+// @Param id path string true "Message ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ClickStatsResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messages/{id}/clicks [get]
+func (h *LinkHandler) GetMessageClicks(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_id", "Invalid message ID format", http.StatusBadRequest))
+		return
+	}
+
+	stats, err := h.linkUseCase.GetMessageClickStats(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get message clicks", zap.String("id", idStr), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to get message clicks", http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Message clicks retrieved successfully", dto.ToClickStatsResponse(stats)))
+}
+
+// GetCampaignClicks godoc
+// @Summary Get link clicks for a campaign
+// @Description Get click counts for every short link sent in a campaign
+// @Tags links
+// @Produce json
+// @Param campaign path string true "Campaign name"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ClickStatsResponse}
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /campaigns/{campaign}/clicks [get]
+func (h *LinkHandler) GetCampaignClicks(c *gin.Context) {
+	campaign := c.Param("campaign")
+
+	stats, err := h.linkUseCase.GetCampaignClickStats(c.Request.Context(), campaign)
+	if err != nil {
+		h.logger.Error("Failed to get campaign clicks", zap.String("campaign", campaign), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to get campaign clicks", http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Campaign clicks retrieved successfully", dto.ToClickStatsResponse(stats)))
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+)
+
+type mockLinkUseCase struct {
+	resolveClickFunc func(ctx context.Context, code, ipAddress, userAgent string) (string, error)
+}
+
+func (m *mockLinkUseCase) ShortenLinks(ctx context.Context, message *entities.Message) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockLinkUseCase) DiscardLinks(ctx context.Context, codes []string) error {
+	return nil
+}
+
+func (m *mockLinkUseCase) ResolveClick(ctx context.Context, code, ipAddress, userAgent string) (string, error) {
+	if m.resolveClickFunc != nil {
+		return m.resolveClickFunc(ctx, code, ipAddress, userAgent)
+	}
+	return "https://example.com", nil
+}
+
+func (m *mockLinkUseCase) GetMessageClickStats(ctx context.Context, messageID uuid.UUID) (*domainUsecases.ClickStats, error) {
+	return &domainUsecases.ClickStats{TotalClicks: 1}, nil
+}
+
+func (m *mockLinkUseCase) GetCampaignClickStats(ctx context.Context, campaign string) (*domainUsecases.ClickStats, error) {
+	return &domainUsecases.ClickStats{TotalClicks: 1}, nil
+}
+
+func TestLinkHandler_Redirect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		mockFunc         func(ctx context.Context, code, ipAddress, userAgent string) (string, error)
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name:             "known code redirects",
+			mockFunc:         nil,
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "https://example.com",
+		},
+		{
+			name: "unknown code",
+			mockFunc: func(ctx context.Context, code, ipAddress, userAgent string) (string, error) {
+				return "", entities.ErrShortLinkNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewLinkHandler(&mockLinkUseCase{resolveClickFunc: tt.mockFunc}, zap.NewNop())
+
+			router := gin.New()
+			router.GET("/r/:code", handler.Redirect)
+
+			req := httptest.NewRequest("GET", "/r/abc123", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			if tt.expectedLocation != "" && w.Header().Get("Location") != tt.expectedLocation {
+				t.Errorf("Expected location %s, got %s", tt.expectedLocation, w.Header().Get("Location"))
+			}
+		})
+	}
+}
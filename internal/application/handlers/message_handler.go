@@ -4,6 +4,8 @@ import (
 	"errors"
 	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -36,6 +38,9 @@ func NewMessageHandler(messageUseCase usecases.MessageUseCase, logger *zap.Logge
 // @Param message body dto.CreateMessageRequest true "Message data"
 // @Success 201 {object} dto.SuccessResponse{data=dto.MessageResponse}
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse{details=dto.QuotaExceededResponse}
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /messages [post]
 func (h *MessageHandler) CreateMessage(c *gin.Context) {
@@ -46,13 +51,43 @@ func (h *MessageHandler) CreateMessage(c *gin.Context) {
 		return
 	}
 
-	message, err := h.messageUseCase.CreateMessage(c.Request.Context(), req.Content, req.PhoneNumber)
+	message, err := h.messageUseCase.CreateMessage(c.Request.Context(), req.ToInput())
 	if err != nil {
-		if errors.Is(err, entities.ErrInvalidMessageContent) || errors.Is(err, entities.ErrMessageTooLong) || errors.Is(err, entities.ErrInvalidPhoneNumber) {
+		var policyErr *entities.PolicyViolationError
+		if errors.As(err, &policyErr) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponseWithDetails("policy_violation", entities.ErrContentPolicyViolation.Error(), http.StatusBadRequest, dto.ToPolicyViolationResponses(policyErr.Violations)))
+			return
+		}
+
+		if isMessageValidationError(err) {
 			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("validation_error", err.Error(), http.StatusBadRequest))
 			return
 		}
 
+		if errors.Is(err, entities.ErrChannelNotConfigured) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("channel_not_configured", err.Error(), http.StatusBadRequest))
+			return
+		}
+
+		if errors.Is(err, entities.ErrSenderIDNotAllowed) {
+			c.JSON(http.StatusForbidden, dto.NewErrorResponse("sender_id_not_allowed", err.Error(), http.StatusForbidden))
+			return
+		}
+
+		if errors.Is(err, entities.ErrWebhookHostNotAllowed) {
+			c.JSON(http.StatusForbidden, dto.NewErrorResponse("webhook_host_not_allowed", err.Error(), http.StatusForbidden))
+			return
+		}
+
+		if errors.Is(err, entities.ErrDuplicateMessage) {
+			c.JSON(http.StatusConflict, dto.NewErrorResponse("duplicate_message", err.Error(), http.StatusConflict))
+			return
+		}
+
+		if writeQuotaExceeded(c, err) {
+			return
+		}
+
 		h.logger.Error("Failed to create message", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to create message", http.StatusInternalServerError))
 		return
@@ -173,7 +208,9 @@ func (h *MessageHandler) GetMessageStats(c *gin.Context) {
 // @Success 200 {object} dto.SuccessResponse{data=dto.MessageResponse}
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse{details=dto.QuotaExceededResponse}
 // @Failure 500 {object} dto.ErrorResponse
+// @Failure 503 {object} dto.ErrorResponse
 // @Router /messages/{id}/send [post]
 func (h *MessageHandler) SendMessage(c *gin.Context) {
 	idStr := c.Param("id")
@@ -201,6 +238,13 @@ func (h *MessageHandler) SendMessage(c *gin.Context) {
 	}
 
 	if err := h.messageUseCase.SendMessage(c.Request.Context(), message); err != nil {
+		if writeQuotaExceeded(c, err) {
+			return
+		}
+		if writeProviderUnavailable(c, err) {
+			return
+		}
+
 		h.logger.Error("Failed to send message", zap.String("id", idStr), zap.Error(err))
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("send_error", "Failed to send message", http.StatusInternalServerError))
 		return
@@ -208,3 +252,181 @@ func (h *MessageHandler) SendMessage(c *gin.Context) {
 
 	c.JSON(http.StatusOK, dto.NewSuccessResponse("Message sent successfully", nil))
 }
+
+// CancelMessage godoc
+// @Summary Cancel a pending message
+// @Description Cancel a message that has not been sent yet
+// @Tags messages
+// @Produce json
+// @Param id path string true "Message ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.MessageResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messages/{id}/cancel [post]
+func (h *MessageHandler) CancelMessage(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "message")
+	if !ok {
+		return
+	}
+
+	message, err := h.messageUseCase.CancelMessage(c.Request.Context(), id)
+	if err != nil {
+		h.handleMessageChangeError(c, err, "Failed to cancel message")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Message cancelled successfully", dto.ToMessageResponse(message)))
+}
+
+// UpdateMessage godoc
+// @Summary Edit a pending message
+// @Description Change the content, recipient or subject of a message that has not been sent yet
+// @Tags messages
+// @Accept json
+// @Produce json
+// @Param id path string true "Message ID"
+// @Param message body dto.UpdateMessageRequest true "Fields to change"
+// @Success 200 {object} dto.SuccessResponse{data=dto.MessageResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messages/{id} [patch]
+func (h *MessageHandler) UpdateMessage(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "message")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+	if req.Content == nil && req.PhoneNumber == nil && req.Email == nil && req.Subject == nil && req.WebhookURL == nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", "At least one of content, phone_number, email, subject or webhook_url is required", http.StatusBadRequest))
+		return
+	}
+
+	message, err := h.messageUseCase.UpdateMessage(c.Request.Context(), id, req.ToInput())
+	if err != nil {
+		h.handleMessageChangeError(c, err, "Failed to update message")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Message updated successfully", dto.ToMessageResponse(message)))
+}
+
+// RequeueMessage godoc
+// @Summary Requeue a failed or cancelled message
+// @Description Put a failed or cancelled message back into the pending queue
+// @Tags messages
+// @Produce json
+// @Param id path string true "Message ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.MessageResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /messages/{id}/requeue [post]
+func (h *MessageHandler) RequeueMessage(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "message")
+	if !ok {
+		return
+	}
+
+	message, err := h.messageUseCase.RequeueMessage(c.Request.Context(), id)
+	if err != nil {
+		h.handleMessageChangeError(c, err, "Failed to requeue message")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Message requeued successfully", dto.ToMessageResponse(message)))
+}
+
+// RecordDeliveryReceipt godoc
+// @Summary Record a delivery receipt
+// @Description Callback for providers that report delivery over HTTP. The message is found by provider and the id returned when it was sent, among the messages of the caller's tenant.
+// @Tags delivery-receipts
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param receipt body dto.DeliveryReceiptRequest true "Delivery receipt"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /delivery-receipts/{provider} [post]
+func (h *MessageHandler) RecordDeliveryReceipt(c *gin.Context) {
+	var req dto.DeliveryReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("validation_error", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	err := h.messageUseCase.RecordDeliveryReceipt(c.Request.Context(), req.ToReceipt(c.Param("provider"), time.Now()))
+	switch {
+	case errors.Is(err, entities.ErrMessageNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "No message with this provider and message id", http.StatusNotFound))
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to record delivery receipt", http.StatusInternalServerError))
+	default:
+		c.JSON(http.StatusOK, dto.NewSuccessResponse("Delivery receipt recorded", nil))
+	}
+}
+
+func (h *MessageHandler) handleMessageChangeError(c *gin.Context, err error, message string) {
+	var policyErr *entities.PolicyViolationError
+	switch {
+	case errors.Is(err, entities.ErrMessageNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "Message not found", http.StatusNotFound))
+	case errors.Is(err, entities.ErrMessageNotPending), errors.Is(err, entities.ErrMessageNotRequeueable):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_status", err.Error(), http.StatusBadRequest))
+	case errors.As(err, &policyErr):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponseWithDetails("policy_violation", entities.ErrContentPolicyViolation.Error(), http.StatusBadRequest, dto.ToPolicyViolationResponses(policyErr.Violations)))
+	case isMessageValidationError(err):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("validation_error", err.Error(), http.StatusBadRequest))
+	case errors.Is(err, entities.ErrWebhookHostNotAllowed):
+		c.JSON(http.StatusForbidden, dto.NewErrorResponse("webhook_host_not_allowed", err.Error(), http.StatusForbidden))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", message, http.StatusInternalServerError))
+	}
+}
+
+// messageValidationErrors Message.Validate'in donebilecegi hatalar, hepsi 400 validation_error
+var messageValidationErrors = []error{
+	entities.ErrInvalidMessageContent,
+	entities.ErrMessageTooLong,
+	entities.ErrInvalidPhoneNumber,
+	entities.ErrInvalidCategory,
+	entities.ErrInvalidSenderID,
+	entities.ErrInvalidChannel,
+	entities.ErrInvalidEmailAddress,
+	entities.ErrInvalidEmailSubject,
+	entities.ErrInvalidWebhookURL,
+	entities.ErrChannelFieldMismatch,
+}
+
+func isMessageValidationError(err error) bool {
+	for _, target := range messageValidationErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeProviderUnavailable devre acik ya da provider throttle ediyorsa 503 doner; mesaj pending kalir
+func writeProviderUnavailable(c *gin.Context, err error) bool {
+	if !entities.IsSendDeferred(err) {
+		return false
+	}
+
+	var throttled *entities.ProviderThrottledError
+	if errors.As(err, &throttled) && throttled.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(throttled.RetryAfter.Seconds())), 10))
+	}
+	c.JSON(http.StatusServiceUnavailable, dto.NewErrorResponse("provider_unavailable", "Message provider is unavailable, message left pending", http.StatusServiceUnavailable))
+	return true
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -20,21 +21,25 @@ import (
 
 // mock testler
 type mockMessageUseCase struct {
-	createMessageFunc   func(ctx context.Context, content, phoneNumber string) (*entities.Message, error)
+	createMessageFunc   func(ctx context.Context, input domainUsecases.CreateMessageInput) (*entities.Message, error)
 	getMessageByIDFunc  func(ctx context.Context, id uuid.UUID) (*entities.Message, error)
 	getSentMessagesFunc func(ctx context.Context, page, limit int) ([]*entities.Message, int64, error)
 	getMessageStatsFunc func(ctx context.Context) (*domainUsecases.MessageStats, error)
 	sendMessageFunc     func(ctx context.Context, message *entities.Message) error
+	cancelMessageFunc   func(ctx context.Context, id uuid.UUID) (*entities.Message, error)
+	updateMessageFunc   func(ctx context.Context, id uuid.UUID, input domainUsecases.UpdateMessageInput) (*entities.Message, error)
+	requeueMessageFunc  func(ctx context.Context, id uuid.UUID) (*entities.Message, error)
+	recordReceiptFunc   func(ctx context.Context, receipt entities.DeliveryReceipt) error
 }
 
-func (m *mockMessageUseCase) CreateMessage(ctx context.Context, content, phoneNumber string) (*entities.Message, error) {
+func (m *mockMessageUseCase) CreateMessage(ctx context.Context, input domainUsecases.CreateMessageInput) (*entities.Message, error) {
 	if m.createMessageFunc != nil {
-		return m.createMessageFunc(ctx, content, phoneNumber)
+		return m.createMessageFunc(ctx, input)
 	}
 	return &entities.Message{
 		ID:          uuid.New(),
-		Content:     content,
-		PhoneNumber: phoneNumber,
+		Content:     input.Content,
+		PhoneNumber: input.PhoneNumber,
 		Status:      entities.MessageStatusPending,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
@@ -89,13 +94,41 @@ func (m *mockMessageUseCase) GetMessageStats(ctx context.Context) (*domainUsecas
 	}, nil
 }
 
+func (m *mockMessageUseCase) CancelMessage(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
+	if m.cancelMessageFunc != nil {
+		return m.cancelMessageFunc(ctx, id)
+	}
+	return &entities.Message{ID: id, Status: entities.MessageStatusCancelled}, nil
+}
+
+func (m *mockMessageUseCase) UpdateMessage(ctx context.Context, id uuid.UUID, input domainUsecases.UpdateMessageInput) (*entities.Message, error) {
+	if m.updateMessageFunc != nil {
+		return m.updateMessageFunc(ctx, id, input)
+	}
+	return &entities.Message{ID: id, Status: entities.MessageStatusPending}, nil
+}
+
+func (m *mockMessageUseCase) RequeueMessage(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
+	if m.requeueMessageFunc != nil {
+		return m.requeueMessageFunc(ctx, id)
+	}
+	return &entities.Message{ID: id, Status: entities.MessageStatusPending}, nil
+}
+
+func (m *mockMessageUseCase) RecordDeliveryReceipt(ctx context.Context, receipt entities.DeliveryReceipt) error {
+	if m.recordReceiptFunc != nil {
+		return m.recordReceiptFunc(ctx, receipt)
+	}
+	return nil
+}
+
 func TestMessageHandler_CreateMessage(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	tests := []struct {
 		name           string
 		requestBody    interface{}
-		mockFunc       func(ctx context.Context, content, phoneNumber string) (*entities.Message, error)
+		mockFunc       func(ctx context.Context, input domainUsecases.CreateMessageInput) (*entities.Message, error)
 		expectedStatus int
 		expectError    bool
 	}{
@@ -115,7 +148,7 @@ func TestMessageHandler_CreateMessage(t *testing.T) {
 				Content:     "",
 				PhoneNumber: "+1234567890",
 			},
-			mockFunc: func(ctx context.Context, content, phoneNumber string) (*entities.Message, error) {
+			mockFunc: func(ctx context.Context, input domainUsecases.CreateMessageInput) (*entities.Message, error) {
 				return nil, entities.ErrInvalidMessageContent
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -134,12 +167,49 @@ func TestMessageHandler_CreateMessage(t *testing.T) {
 				Content:     string(make([]byte, 161)),
 				PhoneNumber: "+1234567890",
 			},
-			mockFunc: func(ctx context.Context, content, phoneNumber string) (*entities.Message, error) {
+			mockFunc: func(ctx context.Context, input domainUsecases.CreateMessageInput) (*entities.Message, error) {
 				return nil, entities.ErrMessageTooLong
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectError:    true,
 		},
+		{
+			name: "duplicate message",
+			requestBody: dto.CreateMessageRequest{
+				Content:     "Test message",
+				PhoneNumber: "+1234567890",
+			},
+			mockFunc: func(ctx context.Context, input domainUsecases.CreateMessageInput) (*entities.Message, error) {
+				return nil, fmt.Errorf("%w (original message %s)", entities.ErrDuplicateMessage, uuid.New())
+			},
+			expectedStatus: http.StatusConflict,
+			expectError:    true,
+		},
+		{
+			name: "content policy violation",
+			requestBody: dto.CreateMessageRequest{
+				Content:     "Win big at the casino",
+				PhoneNumber: "+1234567890",
+			},
+			mockFunc: func(ctx context.Context, input domainUsecases.CreateMessageInput) (*entities.Message, error) {
+				return nil, &entities.PolicyViolationError{Violations: []entities.PolicyViolation{
+					{Rule: "banned_content", Message: "content contains banned word \"casino\""},
+				}}
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
+		{
+			name: "invalid category",
+			requestBody: dto.CreateMessageRequest{
+				Content:     "Test message",
+				PhoneNumber: "+1234567890",
+				Category:    "promo",
+			},
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+			expectError:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -317,6 +387,53 @@ func TestMessageHandler_GetSentMessages(t *testing.T) {
 	}
 }
 
+func TestMessageHandler_RecordDeliveryReceipt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var recorded entities.DeliveryReceipt
+	mockUseCase := &mockMessageUseCase{
+		recordReceiptFunc: func(ctx context.Context, receipt entities.DeliveryReceipt) error {
+			if receipt.ExternalMessageID == "missing" {
+				return entities.ErrMessageNotFound
+			}
+			recorded = receipt
+			return nil
+		},
+	}
+	router := gin.New()
+	router.POST("/delivery-receipts/:provider", NewMessageHandler(mockUseCase, zap.NewNop()).RecordDeliveryReceipt)
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{name: "undelivered with error code", body: `{"message_id": "ext-1", "status": "undelivered", "error_code": "30003", "reported_at": "2024-01-01T12:00:00Z"}`, wantCode: http.StatusOK},
+		{name: "unknown status", body: `{"message_id": "ext-1", "status": "read"}`, wantCode: http.StatusBadRequest},
+		{name: "missing message id", body: `{"status": "delivered"}`, wantCode: http.StatusBadRequest},
+		{name: "unknown message", body: `{"message_id": "missing", "status": "delivered"}`, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/delivery-receipts/acme", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	reportedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if recorded.Provider != "acme" || recorded.ExternalMessageID != "ext-1" || recorded.Status != entities.DeliveryStatusUndelivered ||
+		recorded.ErrorCode != "30003" || !recorded.ReportedAt.Equal(reportedAt) {
+		t.Errorf("Unexpected receipt %+v", recorded)
+	}
+}
+
 func TestMessageHandler_GetMessageStats(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -359,3 +476,51 @@ func TestMessageHandler_GetMessageStats(t *testing.T) {
 		}
 	}
 }
+
+func TestMessageHandler_ChangeMessage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := &mockMessageUseCase{
+		cancelMessageFunc: func(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
+			return nil, entities.ErrMessageNotPending
+		},
+		requeueMessageFunc: func(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
+			return nil, entities.ErrMessageNotFound
+		},
+	}
+	handler := NewMessageHandler(mockUseCase, zap.NewNop())
+
+	router := gin.New()
+	router.PATCH("/messages/:id", handler.UpdateMessage)
+	router.POST("/messages/:id/cancel", handler.CancelMessage)
+	router.POST("/messages/:id/requeue", handler.RequeueMessage)
+
+	id := uuid.New().String()
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "cancel non-pending", method: "POST", path: "/messages/" + id + "/cancel", expectedStatus: http.StatusBadRequest},
+		{name: "requeue missing", method: "POST", path: "/messages/" + id + "/requeue", expectedStatus: http.StatusNotFound},
+		{name: "invalid id", method: "POST", path: "/messages/nope/cancel", expectedStatus: http.StatusBadRequest},
+		{name: "edit without fields", method: "PATCH", path: "/messages/" + id, body: `{}`, expectedStatus: http.StatusBadRequest},
+		{name: "edit content", method: "PATCH", path: "/messages/" + id, body: `{"content":"Updated"}`, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
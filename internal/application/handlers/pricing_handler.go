@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type PricingHandler struct {
+	pricingUseCase usecases.PricingUseCase
+	logger         *zap.Logger
+}
+
+func NewPricingHandler(pricingUseCase usecases.PricingUseCase, logger *zap.Logger) *PricingHandler {
+	return &PricingHandler{
+		pricingUseCase: pricingUseCase,
+		logger:         logger,
+	}
+}
+
+// GetPrices godoc
+// @Summary List the price table
+// @Description Price per segment for each provider and country prefix, longest prefix first
+// @Tags pricing
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=dto.PriceListResponse}
+// @Router /pricing [get]
+func (h *PricingHandler) GetPrices(c *gin.Context) {
+	prices := h.pricingUseCase.GetPrices(c.Request.Context())
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Price table retrieved successfully", dto.ToPriceListResponse(prices)))
+}
+
+// ReloadPrices godoc
+// @Summary Reload the price table
+// @Description Re-read PRICING_FILE; an invalid file is rejected and the current table stays in use
+// @Tags pricing
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=dto.PriceListResponse}
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pricing/reload [post]
+func (h *PricingHandler) ReloadPrices(c *gin.Context) {
+	if err := h.pricingUseCase.ReloadPrices(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("pricing_error", err.Error(), http.StatusInternalServerError))
+		return
+	}
+
+	prices := h.pricingUseCase.GetPrices(c.Request.Context())
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Price table reloaded successfully", dto.ToPriceListResponse(prices)))
+}
+
+// GetCostReport godoc
+// @Summary Get a cost report
+// @Description Estimated cost of pending and sent messages and actual cost of sent messages, grouped by campaign, country prefix or day (UTC)
+// @Tags pricing
+// @Produce json
+// @Param group_by query string false "Grouping" Enums(campaign, country, day) default(campaign)
+// @Param campaign query string false "Only this campaign"
+// @Param from query string false "Created at or after (RFC3339)"
+// @Param to query string false "Created before (RFC3339)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CostReportResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /reports/cost [get]
+func (h *PricingHandler) GetCostReport(c *gin.Context) {
+	var query dto.CostReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_query", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	report, err := h.pricingUseCase.GetCostReport(c.Request.Context(), query.ToFilter())
+	if err != nil {
+		h.logger.Error("Failed to get cost report", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to get cost report", http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Cost report retrieved successfully", dto.ToCostReportResponse(report)))
+}
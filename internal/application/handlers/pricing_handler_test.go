@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+)
+
+type mockPricingUseCase struct {
+	domainUsecases.PricingUseCase
+	filter entities.CostReportFilter
+}
+
+func (m *mockPricingUseCase) GetCostReport(ctx context.Context, filter entities.CostReportFilter) (*domainUsecases.CostReport, error) {
+	m.filter = filter
+	return &domainUsecases.CostReport{
+		Currency: "EUR",
+		GroupBy:  filter.GroupBy,
+		Rows:     []*entities.CostReportRow{{Key: "90", Messages: 3, SentMessages: 2, EstimatedCost: 0.045, ActualCost: 0.03}},
+		Total:    entities.CostReportRow{Messages: 3, SentMessages: 2, EstimatedCost: 0.045, ActualCost: 0.03},
+	}, nil
+}
+
+func TestPricingHandler_GetCostReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := &mockPricingUseCase{}
+	handler := NewPricingHandler(mockUseCase, zap.NewNop())
+
+	router := gin.New()
+	router.GET("/reports/cost", handler.GetCostReport)
+
+	req := httptest.NewRequest("GET", "/reports/cost?group_by=country&campaign=spring-sale&from=2024-01-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if mockUseCase.filter.GroupBy != entities.CostGroupCountry || mockUseCase.filter.Campaign == nil || mockUseCase.filter.From == nil {
+		t.Errorf("Unexpected filter: %+v", mockUseCase.filter)
+	}
+
+	var response struct {
+		Data struct {
+			Currency string                   `json:"currency"`
+			Rows     []map[string]interface{} `json:"rows"`
+			Total    struct {
+				ActualCost float64 `json:"actual_cost"`
+			} `json:"total"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Data.Currency != "EUR" || len(response.Data.Rows) != 1 || response.Data.Total.ActualCost != 0.03 {
+		t.Errorf("Unexpected response: %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/reports/cost", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || mockUseCase.filter.GroupBy != entities.CostGroupCampaign {
+		t.Errorf("Expected campaign grouping by default, got %d %q", w.Code, mockUseCase.filter.GroupBy)
+	}
+
+	req = httptest.NewRequest("GET", "/reports/cost?group_by=provider", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for unknown grouping, got %d", http.StatusBadRequest, w.Code)
+	}
+}
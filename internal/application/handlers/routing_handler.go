@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type RoutingHandler struct {
+	routingUseCase usecases.RoutingUseCase
+	logger         *zap.Logger
+}
+
+func NewRoutingHandler(routingUseCase usecases.RoutingUseCase, logger *zap.Logger) *RoutingHandler {
+	return &RoutingHandler{
+		routingUseCase: routingUseCase,
+		logger:         logger,
+	}
+}
+
+// CreateRule godoc
+// @Summary Create a routing rule
+// @Description Route messages matching a phone prefix (and optionally a tenant or category) to an ordered, weighted provider list. Takes effect immediately.
+// @Tags routing
+// @Accept json
+// @Produce json
+// @Param rule body dto.RoutingRuleRequest true "Routing rule"
+// @Success 201 {object} dto.SuccessResponse{data=dto.RoutingRuleResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /routing/rules [post]
+func (h *RoutingHandler) CreateRule(c *gin.Context) {
+	var req dto.RoutingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	rule := req.ToEntity(uuid.Nil)
+	if err := h.routingUseCase.CreateRule(c.Request.Context(), rule); err != nil {
+		h.writeError(c, err, "Failed to create routing rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse("Routing rule created successfully", dto.ToRoutingRuleResponse(rule)))
+}
+
+// GetRules godoc
+// @Summary List routing rules
+// @Description All routing rules, including disabled ones, oldest first
+// @Tags routing
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.RoutingRuleResponse}
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /routing/rules [get]
+func (h *RoutingHandler) GetRules(c *gin.Context) {
+	rules, err := h.routingUseCase.GetRules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to get routing rules", http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Routing rules retrieved successfully", dto.ToRoutingRuleResponses(rules)))
+}
+
+// GetRule godoc
+// @Summary Get a routing rule
+// @Tags routing
+// @Produce json
+// @Param id path string true "Routing rule ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.RoutingRuleResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /routing/rules/{id} [get]
+func (h *RoutingHandler) GetRule(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "routing rule")
+	if !ok {
+		return
+	}
+
+	rule, err := h.routingUseCase.GetRule(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "Failed to get routing rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Routing rule retrieved successfully", dto.ToRoutingRuleResponse(rule)))
+}
+
+// UpdateRule godoc
+// @Summary Replace a routing rule
+// @Tags routing
+// @Accept json
+// @Produce json
+// @Param id path string true "Routing rule ID"
+// @Param rule body dto.RoutingRuleRequest true "Routing rule"
+// @Success 200 {object} dto.SuccessResponse{data=dto.RoutingRuleResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /routing/rules/{id} [put]
+func (h *RoutingHandler) UpdateRule(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "routing rule")
+	if !ok {
+		return
+	}
+
+	var req dto.RoutingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	rule := req.ToEntity(id)
+	if err := h.routingUseCase.UpdateRule(c.Request.Context(), rule); err != nil {
+		h.writeError(c, err, "Failed to update routing rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Routing rule updated successfully", dto.ToRoutingRuleResponse(rule)))
+}
+
+// DeleteRule godoc
+// @Summary Delete a routing rule
+// @Tags routing
+// @Produce json
+// @Param id path string true "Routing rule ID"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /routing/rules/{id} [delete]
+func (h *RoutingHandler) DeleteRule(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "routing rule")
+	if !ok {
+		return
+	}
+
+	if err := h.routingUseCase.DeleteRule(c.Request.Context(), id); err != nil {
+		h.writeError(c, err, "Failed to delete routing rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Routing rule deleted successfully", nil))
+}
+
+// ReloadRules godoc
+// @Summary Reload routing rules
+// @Description Re-read routing rules from the database without waiting for ROUTING_RELOAD_INTERVAL
+// @Tags routing
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.RoutingRuleResponse}
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /routing/reload [post]
+func (h *RoutingHandler) ReloadRules(c *gin.Context) {
+	ctx := c.Request.Context()
+	if err := h.routingUseCase.ReloadRules(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("routing_error", err.Error(), http.StatusInternalServerError))
+		return
+	}
+
+	rules, err := h.routingUseCase.GetRules(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to get routing rules", http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Routing rules reloaded successfully", dto.ToRoutingRuleResponses(rules)))
+}
+
+func (h *RoutingHandler) writeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, entities.ErrInvalidRoutingRule), errors.Is(err, entities.ErrInvalidCategory), errors.Is(err, entities.ErrUnknownRouteProvider):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("validation_error", err.Error(), http.StatusBadRequest))
+	case errors.Is(err, entities.ErrRoutingRuleNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "Routing rule not found", http.StatusNotFound))
+	case errors.Is(err, entities.ErrTenantNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "Tenant not found", http.StatusNotFound))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", message, http.StatusInternalServerError))
+	}
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type TenantHandler struct {
+	tenantUseCase usecases.TenantUseCase
+	logger        *zap.Logger
+}
+
+func NewTenantHandler(tenantUseCase usecases.TenantUseCase, logger *zap.Logger) *TenantHandler {
+	return &TenantHandler{
+		tenantUseCase: tenantUseCase,
+		logger:        logger,
+	}
+}
+
+// CreateTenant godoc
+// @Summary Create a tenant
+// @Description Create a new tenant; pass its ID in the X-Tenant-ID header to scope message requests
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param tenant body dto.TenantRequest true "Tenant data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.TenantResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /tenants [post]
+func (h *TenantHandler) CreateTenant(c *gin.Context) {
+	var req dto.TenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	tenant := &entities.Tenant{Name: req.Name}
+	if err := h.tenantUseCase.CreateTenant(c.Request.Context(), tenant); err != nil {
+		if errors.Is(err, entities.ErrInvalidTenantName) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("validation_error", err.Error(), http.StatusBadRequest))
+			return
+		}
+
+		h.logger.Error("Failed to create tenant", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to create tenant", http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse("Tenant created successfully", dto.ToTenantResponse(tenant)))
+}
+
+// GetTenants godoc
+// @Summary List tenants
+// @Tags tenants
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.TenantResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /tenants [get]
+func (h *TenantHandler) GetTenants(c *gin.Context) {
+	var query dto.PaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_query", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	tenants, err := h.tenantUseCase.GetTenants(c.Request.Context(), query.Page, query.Limit)
+	if err != nil {
+		h.logger.Error("Failed to get tenants", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to get tenants", http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Tenants retrieved successfully", dto.ToTenantResponses(tenants)))
+}
+
+// GetTenant godoc
+// @Summary Get a tenant by ID
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TenantResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /tenants/{id} [get]
+func (h *TenantHandler) GetTenant(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "tenant")
+	if !ok {
+		return
+	}
+
+	tenant, err := h.tenantUseCase.GetTenant(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, entities.ErrTenantNotFound) {
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "Tenant not found", http.StatusNotFound))
+			return
+		}
+
+		h.logger.Error("Failed to get tenant", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to get tenant", http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Tenant retrieved successfully", dto.ToTenantResponse(tenant)))
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/entities"
+)
+
+type mockTenantUseCase struct {
+	tenants map[uuid.UUID]*entities.Tenant
+}
+
+func (m *mockTenantUseCase) CreateTenant(ctx context.Context, tenant *entities.Tenant) error {
+	if err := tenant.Validate(); err != nil {
+		return err
+	}
+	tenant.ID = uuid.New()
+	return nil
+}
+
+func (m *mockTenantUseCase) GetTenant(ctx context.Context, id uuid.UUID) (*entities.Tenant, error) {
+	if tenant, ok := m.tenants[id]; ok {
+		return tenant, nil
+	}
+	return nil, entities.ErrTenantNotFound
+}
+
+func (m *mockTenantUseCase) GetTenants(ctx context.Context, page, limit int) ([]*entities.Tenant, error) {
+	return []*entities.Tenant{}, nil
+}
+
+func TestTenantHandler_CreateTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		expectedStatus int
+	}{
+		{
+			name:           "successful creation",
+			requestBody:    dto.TenantRequest{Name: "Growth team"},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing name",
+			requestBody:    map[string]string{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "blank name",
+			requestBody:    dto.TenantRequest{Name: "   "},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewTenantHandler(&mockTenantUseCase{}, zap.NewNop())
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest("POST", "/tenants", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handler.CreateTenant(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestTenantHandler_GetTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	existing := &entities.Tenant{ID: uuid.New(), Name: "Growth team"}
+	useCase := &mockTenantUseCase{tenants: map[uuid.UUID]*entities.Tenant{existing.ID: existing}}
+
+	tests := []struct {
+		name           string
+		tenantID       string
+		expectedStatus int
+	}{
+		{name: "existing tenant", tenantID: existing.ID.String(), expectedStatus: http.StatusOK},
+		{name: "unknown tenant", tenantID: uuid.New().String(), expectedStatus: http.StatusNotFound},
+		{name: "invalid id", tenantID: "invalid-uuid", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewTenantHandler(useCase, zap.NewNop())
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/tenants/"+tt.tenantID, nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.tenantID}}
+
+			handler.GetTenant(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+		})
+	}
+}
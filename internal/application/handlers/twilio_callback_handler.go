@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+// TwilioSignatureHeader Twilio her callback'i auth token ile imzalar
+const TwilioSignatureHeader = "X-Twilio-Signature"
+
+// TwilioAccount imza dogrulamasi icin provider'in auth token'i ve StatusCallback olarak gonderilen adres.
+// Twilio imzayi bu adresin birebir aynisiyla hesaplar, proxy arkasinda istek URL'i farkli olabilir.
+type TwilioAccount struct {
+	AuthToken   string
+	CallbackURL string
+}
+
+// twilioFinalStatuses ara durumlar (queued, sending, sent) kayda yazilmaz
+var twilioFinalStatuses = map[string]entities.DeliveryStatus{
+	"delivered":   entities.DeliveryStatusDelivered,
+	"undelivered": entities.DeliveryStatusUndelivered,
+	"failed":      entities.DeliveryStatusRejected,
+}
+
+type TwilioCallbackHandler struct {
+	messageUseCase usecases.MessageUseCase
+	accounts       map[string]TwilioAccount
+	logger         *zap.Logger
+}
+
+// NewTwilioCallbackHandler accounts provider adina gore; listede olmayan provider'a gelen callback 404 alir
+func NewTwilioCallbackHandler(messageUseCase usecases.MessageUseCase, accounts map[string]TwilioAccount, logger *zap.Logger) *TwilioCallbackHandler {
+	return &TwilioCallbackHandler{
+		messageUseCase: messageUseCase,
+		accounts:       accounts,
+		logger:         logger,
+	}
+}
+
+// StatusCallback godoc
+// @Summary Twilio status callback
+// @Description StatusCallback target for twilio providers. The request must carry a valid X-Twilio-Signature; only final statuses (delivered, undelivered, failed) are recorded.
+// @Tags delivery-receipts
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /callbacks/twilio/{provider} [post]
+func (h *TwilioCallbackHandler) StatusCallback(c *gin.Context) {
+	provider := c.Param("provider")
+	account, ok := h.accounts[provider]
+	if !ok {
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "No twilio provider with this name", http.StatusNotFound))
+		return
+	}
+
+	if err := c.Request.ParseForm(); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("validation_error", "Invalid form body", http.StatusBadRequest))
+		return
+	}
+	params := c.Request.PostForm
+
+	if !validTwilioSignature(account.AuthToken, account.CallbackURL, params, c.GetHeader(TwilioSignatureHeader)) {
+		h.logger.Warn("Rejected twilio callback with an invalid signature", zap.String("provider", provider))
+		c.JSON(http.StatusForbidden, dto.NewErrorResponse("invalid_signature", "Invalid "+TwilioSignatureHeader, http.StatusForbidden))
+		return
+	}
+
+	messageSID := params.Get("MessageSid")
+	if messageSID == "" {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("validation_error", "MessageSid is required", http.StatusBadRequest))
+		return
+	}
+
+	status, final := twilioFinalStatuses[params.Get("MessageStatus")]
+	if !final {
+		c.JSON(http.StatusOK, dto.NewSuccessResponse("Intermediate status ignored", nil))
+		return
+	}
+
+	err := h.messageUseCase.RecordDeliveryReceipt(c.Request.Context(), entities.DeliveryReceipt{
+		Provider:          provider,
+		ExternalMessageID: messageSID,
+		Status:            status,
+		ErrorCode:         params.Get("ErrorCode"),
+		ReportedAt:        time.Now(),
+	})
+	switch {
+	case errors.Is(err, entities.ErrMessageNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "No message with this provider and message id", http.StatusNotFound))
+	case err != nil:
+		h.logger.Error("Failed to record twilio status callback", zap.String("provider", provider), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to record delivery receipt", http.StatusInternalServerError))
+	default:
+		c.JSON(http.StatusOK, dto.NewSuccessResponse("Delivery receipt recorded", nil))
+	}
+}
+
+// validTwilioSignature base64(HMAC-SHA1(auth token, URL + isme gore sirali parametrelerin ad ve degerleri))
+// https://www.twilio.com/docs/usage/security#validating-requests
+func validTwilioSignature(authToken, callbackURL string, params url.Values, signature string) bool {
+	if authToken == "" || callbackURL == "" || signature == "" {
+		return false
+	}
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, twilioSignature(authToken, callbackURL, params))
+}
+
+func twilioSignature(authToken, callbackURL string, params url.Values) []byte {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var payload strings.Builder
+	payload.WriteString(callbackURL)
+	for _, name := range names {
+		values := append([]string(nil), params[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			payload.WriteString(name)
+			payload.WriteString(value)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(payload.String()))
+	return mac.Sum(nil)
+}
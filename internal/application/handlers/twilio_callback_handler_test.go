@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+func TestValidTwilioSignature(t *testing.T) {
+	// Twilio dokumanindaki ornek istek
+	params := url.Values{
+		"CallSid": {"CA1234567890ABCDE"},
+		"Caller":  {"+14158675309"},
+		"Digits":  {"1234"},
+		"From":    {"+14158675309"},
+		"To":      {"+18005551212"},
+	}
+	callbackURL := "https://mycompany.com/myapp.php?foo=1&bar=2"
+
+	if !validTwilioSignature("12345", callbackURL, params, "RSOYDt4T1cUTdK1PDd93/VVr8B8=") {
+		t.Error("Expected the documented signature to be valid")
+	}
+	if validTwilioSignature("54321", callbackURL, params, "RSOYDt4T1cUTdK1PDd93/VVr8B8=") {
+		t.Error("Expected a signature made with another token to be rejected")
+	}
+	params.Set("Digits", "4321")
+	if validTwilioSignature("12345", callbackURL, params, "RSOYDt4T1cUTdK1PDd93/VVr8B8=") {
+		t.Error("Expected a tampered body to be rejected")
+	}
+	if validTwilioSignature("12345", callbackURL, params, "") {
+		t.Error("Expected a missing signature to be rejected")
+	}
+}
+
+func TestTwilioCallbackHandler_StatusCallback(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	callbackURL := "https://sms.example.com/callbacks/twilio/twilio"
+	var recorded []entities.DeliveryReceipt
+	mockUseCase := &mockMessageUseCase{
+		recordReceiptFunc: func(ctx context.Context, receipt entities.DeliveryReceipt) error {
+			if receipt.ExternalMessageID == "SMmissing" {
+				return entities.ErrMessageNotFound
+			}
+			recorded = append(recorded, receipt)
+			return nil
+		},
+	}
+	handler := NewTwilioCallbackHandler(mockUseCase, map[string]TwilioAccount{
+		"twilio": {AuthToken: "auth-token", CallbackURL: callbackURL},
+	}, zap.NewNop())
+	router := gin.New()
+	router.POST("/callbacks/twilio/:provider", handler.StatusCallback)
+
+	sign := func(params url.Values) string {
+		return base64.StdEncoding.EncodeToString(twilioSignature("auth-token", callbackURL, params))
+	}
+
+	tests := []struct {
+		name      string
+		provider  string
+		params    url.Values
+		signature string
+		wantCode  int
+	}{
+		{
+			name:     "undelivered with error code",
+			provider: "twilio",
+			params:   url.Values{"MessageSid": {"SM123"}, "MessageStatus": {"undelivered"}, "ErrorCode": {"30003"}},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "intermediate status is not recorded",
+			provider: "twilio",
+			params:   url.Values{"MessageSid": {"SM123"}, "MessageStatus": {"sent"}},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "invalid signature",
+			provider:  "twilio",
+			params:    url.Values{"MessageSid": {"SM123"}, "MessageStatus": {"delivered"}},
+			signature: "bm90IGEgc2lnbmF0dXJl",
+			wantCode:  http.StatusForbidden,
+		},
+		{
+			name:     "unknown provider",
+			provider: "other",
+			params:   url.Values{"MessageSid": {"SM123"}, "MessageStatus": {"delivered"}},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "unknown message",
+			provider: "twilio",
+			params:   url.Values{"MessageSid": {"SMmissing"}, "MessageStatus": {"delivered"}},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature := tt.signature
+			if signature == "" {
+				signature = sign(tt.params)
+			}
+			req := httptest.NewRequest("POST", "/callbacks/twilio/"+tt.provider, strings.NewReader(tt.params.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set(TwilioSignatureHeader, signature)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	if len(recorded) != 1 {
+		t.Fatalf("Expected only the final status to be recorded, got %+v", recorded)
+	}
+	if receipt := recorded[0]; receipt.Provider != "twilio" || receipt.ExternalMessageID != "SM123" ||
+		receipt.Status != entities.DeliveryStatusUndelivered || receipt.ErrorCode != "30003" {
+		t.Errorf("Unexpected receipt %+v", receipt)
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type UsageHandler struct {
+	usageUseCase usecases.UsageUseCase
+	logger       *zap.Logger
+}
+
+func NewUsageHandler(usageUseCase usecases.UsageUseCase, logger *zap.Logger) *UsageHandler {
+	return &UsageHandler{
+		usageUseCase: usageUseCase,
+		logger:       logger,
+	}
+}
+
+// GetUsage godoc
+// @Summary Get tenant usage
+// @Description Current day/month usage against the tenant quota plus historical usage, newest first
+// @Tags usage
+// @Produce json
+// @Param period query string false "History granularity" Enums(day, month) default(day)
+// @Param from query string false "History start date (YYYY-MM-DD)"
+// @Param to query string false "History end date (YYYY-MM-DD, inclusive)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.UsageResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /usage [get]
+func (h *UsageHandler) GetUsage(c *gin.Context) {
+	var query dto.UsageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_query", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	ctx := c.Request.Context()
+	tenantID, _ := entities.TenantFromContext(ctx)
+
+	period := query.Period
+	if period == "" {
+		period = entities.UsagePeriodDay
+	}
+
+	// to gun olarak verilir, ertesi gunun basina kadar dahil
+	to := time.Now().UTC()
+	if query.To != nil {
+		to = query.To.UTC().AddDate(0, 0, 1)
+	}
+	from := to.AddDate(0, 0, -30)
+	if period == entities.UsagePeriodMonth {
+		from = to.AddDate(0, -12, 0)
+	}
+	if query.From != nil {
+		from = query.From.UTC()
+	}
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_query", "from must be before to", http.StatusBadRequest))
+		return
+	}
+
+	summary, err := h.usageUseCase.GetUsageSummary(ctx, tenantID)
+	if err != nil {
+		h.logger.Error("Failed to get usage summary", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to get usage", http.StatusInternalServerError))
+		return
+	}
+
+	history, err := h.usageUseCase.GetUsageHistory(ctx, tenantID, period, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to get usage", http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Usage retrieved successfully", dto.ToUsageResponse(summary, period, history)))
+}
+
+// GetQuota godoc
+// @Summary Get a tenant quota
+// @Description Returns the tenant's quota override, or the configured defaults when none is set
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuotaResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /tenants/{id}/quota [get]
+func (h *UsageHandler) GetQuota(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "tenant")
+	if !ok {
+		return
+	}
+
+	quota, err := h.usageUseCase.GetQuota(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to get tenant quota", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to get quota", http.StatusInternalServerError))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Quota retrieved successfully", dto.ToQuotaResponse(quota)))
+}
+
+// SetQuota godoc
+// @Summary Set a tenant quota
+// @Description Override the default quota for a tenant, 0 means unlimited
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param quota body dto.QuotaRequest true "Quota limits"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QuotaResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /tenants/{id}/quota [put]
+func (h *UsageHandler) SetQuota(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "tenant")
+	if !ok {
+		return
+	}
+
+	var req dto.QuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_request", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	quota := req.ToEntity(id)
+	if err := h.usageUseCase.SetQuota(c.Request.Context(), quota); err != nil {
+		switch {
+		case errors.Is(err, entities.ErrInvalidQuota):
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("validation_error", err.Error(), http.StatusBadRequest))
+		case errors.Is(err, entities.ErrTenantNotFound):
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("not_found", "Tenant not found", http.StatusNotFound))
+		default:
+			c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to set quota", http.StatusInternalServerError))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewSuccessResponse("Quota updated successfully", dto.ToQuotaResponse(quota)))
+}
+
+// writeQuotaExceeded hard quota asildiginda 429 ve limit yenilenene kadar Retry-After doner
+func writeQuotaExceeded(c *gin.Context, err error) bool {
+	var quotaErr *entities.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		return false
+	}
+
+	retryAfter := int64(math.Ceil(time.Until(quotaErr.ResetsAt).Seconds()))
+	if retryAfter > 0 {
+		c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+	}
+	c.JSON(http.StatusTooManyRequests, dto.NewErrorResponseWithDetails("quota_exceeded", quotaErr.Error(), http.StatusTooManyRequests, dto.ToQuotaExceededResponse(quotaErr)))
+	return true
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+)
+
+type mockUsageUseCase struct {
+	domainUsecases.UsageUseCase
+	period   string
+	from, to time.Time
+	saved    *entities.TenantQuota
+}
+
+func (m *mockUsageUseCase) GetUsageSummary(ctx context.Context, tenantID uuid.UUID) (*domainUsecases.UsageSummary, error) {
+	now := time.Now().UTC()
+	return &domainUsecases.UsageSummary{
+		TenantID: tenantID,
+		Quota:    &entities.TenantQuota{TenantID: tenantID, DailyMessages: 100, SoftLimitPercent: 80},
+		Today:    entities.UsageRecord{Period: entities.UsagePeriodDay, PeriodStart: entities.UsagePeriodStart(entities.UsagePeriodDay, now), Messages: 12},
+		Limits:   []entities.QuotaLimit{{Period: entities.UsagePeriodDay, Metric: entities.QuotaMetricMessages, Hard: 100, Soft: 80, Used: 12}},
+	}, nil
+}
+
+func (m *mockUsageUseCase) GetUsageHistory(ctx context.Context, tenantID uuid.UUID, period string, from, to time.Time) ([]*entities.UsageRecord, error) {
+	m.period, m.from, m.to = period, from, to
+	return []*entities.UsageRecord{{Period: period, PeriodStart: from, Messages: 12, Segments: 14}}, nil
+}
+
+func (m *mockUsageUseCase) SetQuota(ctx context.Context, quota *entities.TenantQuota) error {
+	m.saved = quota
+	return quota.Validate()
+}
+
+func TestUsageHandler_GetUsage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tenantID := uuid.New()
+	mockUseCase := &mockUsageUseCase{}
+	handler := NewUsageHandler(mockUseCase, zap.NewNop())
+
+	router := gin.New()
+	router.GET("/usage", func(c *gin.Context) {
+		c.Request = c.Request.WithContext(entities.ContextWithTenant(c.Request.Context(), tenantID))
+		handler.GetUsage(c)
+	})
+
+	req := httptest.NewRequest("GET", "/usage?period=month&from=2024-01-01&to=2024-03-31", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if mockUseCase.period != entities.UsagePeriodMonth {
+		t.Errorf("Expected month period, got %q", mockUseCase.period)
+	}
+	if !mockUseCase.to.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected inclusive to date, got %v", mockUseCase.to)
+	}
+
+	var response struct {
+		Data struct {
+			TenantID uuid.UUID                `json:"tenant_id"`
+			Limits   []map[string]interface{} `json:"limits"`
+			History  []map[string]interface{} `json:"history"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Data.TenantID != tenantID || len(response.Data.Limits) != 1 || len(response.Data.History) != 1 {
+		t.Errorf("Unexpected response: %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/usage?period=year", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid period, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestUsageHandler_SetQuota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := &mockUsageUseCase{}
+	handler := NewUsageHandler(mockUseCase, zap.NewNop())
+
+	router := gin.New()
+	router.PUT("/tenants/:id/quota", handler.SetQuota)
+
+	tenantID := uuid.New()
+	body := []byte(`{"daily_messages":500,"monthly_segments":10000,"soft_limit_percent":90}`)
+	req := httptest.NewRequest("PUT", "/tenants/"+tenantID.String()+"/quota", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if mockUseCase.saved == nil || mockUseCase.saved.TenantID != tenantID || mockUseCase.saved.DailyMessages != 500 {
+		t.Errorf("Unexpected saved quota: %+v", mockUseCase.saved)
+	}
+
+	req = httptest.NewRequest("PUT", "/tenants/"+tenantID.String()+"/quota", bytes.NewReader([]byte(`{"soft_limit_percent":150}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid soft limit, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestMessageHandler_CreateMessage_QuotaExceeded(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	resetsAt := time.Now().Add(time.Hour)
+	mockUseCase := &mockMessageUseCase{
+		createMessageFunc: func(ctx context.Context, input domainUsecases.CreateMessageInput) (*entities.Message, error) {
+			return nil, &entities.QuotaExceededError{Period: entities.UsagePeriodDay, Metric: entities.QuotaMetricMessages, Limit: 100, Used: 100, Requested: 1, ResetsAt: resetsAt}
+		},
+	}
+	handler := NewMessageHandler(mockUseCase, zap.NewNop())
+
+	router := gin.New()
+	router.POST("/messages", handler.CreateMessage)
+
+	req := httptest.NewRequest("POST", "/messages", bytes.NewReader([]byte(`{"content":"Hello","phone_number":"+1234567890"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusTooManyRequests, w.Code, w.Body.String())
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected Retry-After header")
+	}
+
+	var response struct {
+		Error   string `json:"error"`
+		Details struct {
+			Period string `json:"period"`
+			Limit  int64  `json:"limit"`
+			Used   int64  `json:"used"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Error != "quota_exceeded" || response.Details.Period != entities.UsagePeriodDay || response.Details.Limit != 100 {
+		t.Errorf("Unexpected response: %s", w.Body.String())
+	}
+}
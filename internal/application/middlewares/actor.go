@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+// ActorMiddleware audit kayitlari icin istegi yapan kimligi ve IP'yi request context'ine koyar.
+// AuthMiddleware'den sonra calismali; principal yoksa actor anonymous olur.
+func ActorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := entities.Actor{
+			ID:        entities.AnonymousActorID,
+			IPAddress: c.ClientIP(),
+		}
+		if principal, ok := PrincipalFromContext(c); ok {
+			actor.ID = principal.Subject
+			actor.Name = principal.Name
+			actor.AuthMethod = principal.AuthMethod
+		}
+
+		c.Request = c.Request.WithContext(entities.ContextWithActor(c.Request.Context(), actor))
+		c.Next()
+	}
+}
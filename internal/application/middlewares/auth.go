@@ -0,0 +1,121 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+	"message-sending-service/internal/domain/usecases"
+)
+
+const (
+	APIKeyHeader        = "X-API-Key"
+	principalContextKey = "principal"
+)
+
+// Authenticator istekte kendi tipinde bir kimlik yoksa (nil, nil) doner, boylece api key ve JWT
+// ayni route gruplarina birlikte takilabilir
+type Authenticator func(c *gin.Context) (*entities.Principal, error)
+
+// APIKeyAuthenticator anahtari X-API-Key header'indan ya da JWT olmayan bir bearer token'dan okur
+func APIKeyAuthenticator(apiKeyUseCase usecases.APIKeyUseCase) Authenticator {
+	return func(c *gin.Context) (*entities.Principal, error) {
+		rawKey := strings.TrimSpace(c.GetHeader(APIKeyHeader))
+		if token, ok := bearerToken(c); ok && rawKey == "" && !looksLikeJWT(token) {
+			rawKey = token
+		}
+		if rawKey == "" {
+			return nil, nil
+		}
+
+		key, err := apiKeyUseCase.Authenticate(c.Request.Context(), rawKey)
+		if err != nil {
+			return nil, err
+		}
+		return key.Principal(), nil
+	}
+}
+
+func JWTAuthenticator(verifier services.TokenVerifier) Authenticator {
+	return func(c *gin.Context) (*entities.Principal, error) {
+		token, ok := bearerToken(c)
+		if !ok || !looksLikeJWT(token) {
+			return nil, nil
+		}
+		return verifier.Verify(c.Request.Context(), token)
+	}
+}
+
+// AuthMiddleware ilk taninan kimligi dogrular ve principal'in tenant'ini request context'ine koyar
+func AuthMiddleware(logger *zap.Logger, authenticators ...Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, authenticate := range authenticators {
+			principal, err := authenticate(c)
+			if err != nil {
+				if errors.Is(err, entities.ErrInvalidAPIKey) || errors.Is(err, entities.ErrInvalidToken) {
+					c.AbortWithStatusJSON(http.StatusUnauthorized, dto.NewErrorResponse("unauthorized", err.Error(), http.StatusUnauthorized))
+					return
+				}
+
+				logger.Error("Failed to authenticate request", zap.Error(err))
+				c.AbortWithStatusJSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to authenticate request", http.StatusInternalServerError))
+				return
+			}
+			if principal == nil {
+				continue
+			}
+
+			c.Set(principalContextKey, principal)
+			c.Request = c.Request.WithContext(entities.ContextWithTenant(c.Request.Context(), principal.TenantID))
+			c.Next()
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusUnauthorized, dto.NewErrorResponse("unauthorized", "Missing credentials", http.StatusUnauthorized))
+	}
+}
+
+// RequireScope AuthMiddleware'den sonra kullanilmali, principal yoksa 401 doner
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFromContext(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.NewErrorResponse("unauthorized", "Missing credentials", http.StatusUnauthorized))
+			return
+		}
+
+		for _, scope := range scopes {
+			if !principal.HasScope(scope) {
+				c.AbortWithStatusJSON(http.StatusForbidden, dto.NewErrorResponse("insufficient_scope", "Credentials are missing scope "+scope, http.StatusForbidden))
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func PrincipalFromContext(c *gin.Context) (*entities.Principal, bool) {
+	value, exists := c.Get(principalContextKey)
+	if !exists {
+		return nil, false
+	}
+	principal, ok := value.(*entities.Principal)
+	return principal, ok
+}
+
+func bearerToken(c *gin.Context) (string, bool) {
+	token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	token = strings.TrimSpace(token)
+	return token, found && token != ""
+}
+
+func looksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
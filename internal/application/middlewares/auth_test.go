@@ -0,0 +1,112 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type stubAPIKeyUseCase struct {
+	usecases.APIKeyUseCase
+	keys map[string]*entities.APIKey
+}
+
+func (s *stubAPIKeyUseCase) Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error) {
+	if key, ok := s.keys[rawKey]; ok {
+		return key, nil
+	}
+	return nil, entities.ErrInvalidAPIKey
+}
+
+type stubTenantUseCase struct {
+	usecases.TenantUseCase
+}
+
+func (s *stubTenantUseCase) GetTenant(ctx context.Context, id uuid.UUID) (*entities.Tenant, error) {
+	return &entities.Tenant{ID: id}, nil
+}
+
+type stubTokenVerifier struct {
+	tokens map[string]*entities.Principal
+}
+
+func (s *stubTokenVerifier) Verify(ctx context.Context, token string) (*entities.Principal, error) {
+	if principal, ok := s.tokens[token]; ok {
+		return principal, nil
+	}
+	return nil, entities.ErrInvalidToken
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tenantID, otherTenant := uuid.New(), uuid.New()
+	apiKeys := &stubAPIKeyUseCase{keys: map[string]*entities.APIKey{
+		"reader": {TenantID: tenantID, Scopes: []string{entities.ScopeMessagesRead}},
+		"admin":  {TenantID: tenantID, Scopes: []string{entities.ScopeAdmin}},
+	}}
+	jwtTenant := uuid.New()
+	verifier := &stubTokenVerifier{tokens: map[string]*entities.Principal{
+		"header.reader.sig": {Subject: "sso-user", TenantID: jwtTenant, Scopes: []string{entities.ScopeMessagesRead}, AuthMethod: entities.AuthMethodJWT},
+	}}
+
+	var resolvedTenant uuid.UUID
+	router := gin.New()
+	router.Use(AuthMiddleware(zap.NewNop(), APIKeyAuthenticator(apiKeys), JWTAuthenticator(verifier)), TenantMiddleware(&stubTenantUseCase{}, zap.NewNop()))
+	router.GET("/messages", RequireScope(entities.ScopeMessagesRead), func(c *gin.Context) {
+		resolvedTenant, _ = entities.TenantFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	router.POST("/scheduler/stop", RequireScope(entities.ScopeSchedulerAdmin), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		headers        map[string]string
+		expectedStatus int
+		expectedTenant uuid.UUID
+	}{
+		{name: "missing key", method: "GET", path: "/messages", expectedStatus: http.StatusUnauthorized},
+		{name: "unknown key", method: "GET", path: "/messages", headers: map[string]string{APIKeyHeader: "nope"}, expectedStatus: http.StatusUnauthorized},
+		{name: "x-api-key header", method: "GET", path: "/messages", headers: map[string]string{APIKeyHeader: "reader"}, expectedStatus: http.StatusOK, expectedTenant: tenantID},
+		{name: "bearer token", method: "GET", path: "/messages", headers: map[string]string{"Authorization": "Bearer reader"}, expectedStatus: http.StatusOK, expectedTenant: tenantID},
+		{name: "jwt bearer token", method: "GET", path: "/messages", headers: map[string]string{"Authorization": "Bearer header.reader.sig"}, expectedStatus: http.StatusOK, expectedTenant: jwtTenant},
+		{name: "invalid jwt", method: "GET", path: "/messages", headers: map[string]string{"Authorization": "Bearer header.forged.sig"}, expectedStatus: http.StatusUnauthorized},
+		{name: "jwt missing scope", method: "POST", path: "/scheduler/stop", headers: map[string]string{"Authorization": "Bearer header.reader.sig"}, expectedStatus: http.StatusForbidden},
+		{name: "missing scope", method: "POST", path: "/scheduler/stop", headers: map[string]string{APIKeyHeader: "reader"}, expectedStatus: http.StatusForbidden},
+		{name: "admin covers scope", method: "POST", path: "/scheduler/stop", headers: map[string]string{APIKeyHeader: "admin"}, expectedStatus: http.StatusOK},
+		{name: "tenant switch without admin", method: "GET", path: "/messages", headers: map[string]string{APIKeyHeader: "reader", TenantHeader: otherTenant.String()}, expectedStatus: http.StatusForbidden},
+		{name: "tenant switch with admin", method: "GET", path: "/messages", headers: map[string]string{APIKeyHeader: "admin", TenantHeader: otherTenant.String()}, expectedStatus: http.StatusOK, expectedTenant: otherTenant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolvedTenant = uuid.Nil
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedTenant != uuid.Nil && resolvedTenant != tt.expectedTenant {
+				t.Errorf("Expected tenant %v, got %v", tt.expectedTenant, resolvedTenant)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/application/dto"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+)
+
+const TenantHeader = "X-Tenant-ID"
+
+// TenantMiddleware istegin tenant'ini cozer ve request context'ine koyar. Kimlik dogrulandiysa tenant principal'dan gelir,
+// X-Tenant-ID ile baska tenant'a gecmek sadece admin scope'una acik. Auth kapaliysa header, o da yoksa default tenant kullanilir.
+func TenantMiddleware(tenantUseCase usecases.TenantUseCase, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := entities.DefaultTenantID
+		principal, authenticated := PrincipalFromContext(c)
+		if authenticated {
+			tenantID = principal.TenantID
+		}
+
+		if header := c.GetHeader(TenantHeader); header != "" {
+			id, err := uuid.Parse(header)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, dto.NewErrorResponse("invalid_tenant", "Invalid "+TenantHeader+" header", http.StatusBadRequest))
+				return
+			}
+
+			if authenticated && id != principal.TenantID && !principal.HasScope(entities.ScopeAdmin) {
+				c.AbortWithStatusJSON(http.StatusForbidden, dto.NewErrorResponse("tenant_forbidden", "Credentials cannot act on behalf of another tenant", http.StatusForbidden))
+				return
+			}
+
+			if _, err := tenantUseCase.GetTenant(c.Request.Context(), id); err != nil {
+				if errors.Is(err, entities.ErrTenantNotFound) {
+					c.AbortWithStatusJSON(http.StatusNotFound, dto.NewErrorResponse("tenant_not_found", "Tenant not found", http.StatusNotFound))
+					return
+				}
+
+				logger.Error("Failed to resolve tenant", zap.String("tenant_id", id.String()), zap.Error(err))
+				c.AbortWithStatusJSON(http.StatusInternalServerError, dto.NewErrorResponse("internal_error", "Failed to resolve tenant", http.StatusInternalServerError))
+				return
+			}
+
+			tenantID = id
+		}
+
+		c.Request = c.Request.WithContext(entities.ContextWithTenant(c.Request.Context(), tenantID))
+		c.Next()
+	}
+}
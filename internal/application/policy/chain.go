@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+// Chain kurallari eklendigi sirayla calistirir, temizleyici kurallar once eklenmeli
+type Chain struct {
+	rules []services.ContentRule
+}
+
+func NewChain(rules ...services.ContentRule) *Chain {
+	return &Chain{rules: rules}
+}
+
+// NewChainFromConfig config'de tanimli kurallardan zinciri kurar, bos birakilan kurallar eklenmez
+func NewChainFromConfig(cfg config.ContentPolicyConfig) (*Chain, error) {
+	chain := NewChain()
+
+	if cfg.StripControlChars {
+		chain.Use(NewControlCharacterRule())
+	}
+
+	if len(cfg.BannedWords) > 0 || len(cfg.BannedPatterns) > 0 {
+		patterns := make([]*regexp.Regexp, 0, len(cfg.BannedPatterns))
+		for _, expr := range cfg.BannedPatterns {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid banned pattern %q: %w", expr, err)
+			}
+			patterns = append(patterns, re)
+		}
+		chain.Use(NewBannedContentRule(cfg.BannedWords, patterns))
+	}
+
+	if len(cfg.AllowedURLDomains) > 0 {
+		chain.Use(NewURLAllowlistRule(cfg.AllowedURLDomains))
+	}
+
+	if cfg.OptOutFooter != "" {
+		chain.Use(NewOptOutFooterRule(cfg.OptOutFooter))
+	}
+
+	return chain, nil
+}
+
+func (c *Chain) Use(rule services.ContentRule) {
+	c.rules = append(c.rules, rule)
+}
+
+func (c *Chain) Evaluate(message *entities.Message) error {
+	var violations []entities.PolicyViolation
+	for _, rule := range c.rules {
+		violations = append(violations, rule.Apply(message)...)
+	}
+
+	if len(violations) > 0 {
+		return &entities.PolicyViolationError{Violations: violations}
+	}
+
+	return nil
+}
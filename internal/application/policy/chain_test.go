@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+func TestChain_Evaluate(t *testing.T) {
+	cfg := config.ContentPolicyConfig{
+		StripControlChars: true,
+		BannedWords:       []string{"casino"},
+		BannedPatterns:    []string{`(?i)free\s+money`},
+		AllowedURLDomains: []string{"example.com"},
+		OptOutFooter:      "Reply STOP to opt out",
+	}
+
+	tests := []struct {
+		name           string
+		message        entities.Message
+		wantViolations []string
+		wantContent    string
+	}{
+		{
+			name:        "clean transactional message",
+			message:     entities.Message{Content: "Your code is 1234", Category: entities.MessageCategoryTransactional},
+			wantContent: "Your code is 1234",
+		},
+		{
+			name:        "control characters are stripped",
+			message:     entities.Message{Content: "Hello\x00 wor\x07ld\nBye", Category: entities.MessageCategoryTransactional},
+			wantContent: "Hello world\nBye",
+		},
+		{
+			name:           "banned word",
+			message:        entities.Message{Content: "Visit our CASINO today", Category: entities.MessageCategoryTransactional},
+			wantViolations: []string{"banned_content"},
+		},
+		{
+			name:           "banned pattern",
+			message:        entities.Message{Content: "Get free   money now", Category: entities.MessageCategoryTransactional},
+			wantViolations: []string{"banned_content"},
+		},
+		{
+			name:        "allowed url and subdomain",
+			message:     entities.Message{Content: "See https://example.com/a and www.shop.example.com", Category: entities.MessageCategoryTransactional},
+			wantContent: "See https://example.com/a and www.shop.example.com",
+		},
+		{
+			name:           "url not on allowlist",
+			message:        entities.Message{Content: "See https://evil-example.com/a", Category: entities.MessageCategoryTransactional},
+			wantViolations: []string{"url_allowlist"},
+		},
+		{
+			name:           "marketing without footer",
+			message:        entities.Message{Content: "Big sale today", Category: entities.MessageCategoryMarketing},
+			wantViolations: []string{"opt_out_footer"},
+		},
+		{
+			name:        "marketing with footer",
+			message:     entities.Message{Content: "Big sale today. reply stop to opt out", Category: entities.MessageCategoryMarketing},
+			wantContent: "Big sale today. reply stop to opt out",
+		},
+		{
+			name:           "multiple violations are collected",
+			message:        entities.Message{Content: "casino at http://bad.io", Category: entities.MessageCategoryMarketing},
+			wantViolations: []string{"banned_content", "url_allowlist", "opt_out_footer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, err := NewChainFromConfig(cfg)
+			if err != nil {
+				t.Fatalf("Failed to build chain: %v", err)
+			}
+
+			message := tt.message
+			err = chain.Evaluate(&message)
+
+			if len(tt.wantViolations) == 0 {
+				if err != nil {
+					t.Fatalf("Expected no error but got: %v", err)
+				}
+				if message.Content != tt.wantContent {
+					t.Errorf("Expected content %q, got %q", tt.wantContent, message.Content)
+				}
+				return
+			}
+
+			if !errors.Is(err, entities.ErrContentPolicyViolation) {
+				t.Fatalf("Expected policy violation error, got %v", err)
+			}
+
+			var policyErr *entities.PolicyViolationError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("Expected *entities.PolicyViolationError, got %T", err)
+			}
+
+			if len(policyErr.Violations) != len(tt.wantViolations) {
+				t.Fatalf("Expected %d violations, got %d: %v", len(tt.wantViolations), len(policyErr.Violations), policyErr.Violations)
+			}
+			for i, rule := range tt.wantViolations {
+				if policyErr.Violations[i].Rule != rule {
+					t.Errorf("Expected violation %d to be %s, got %s", i, rule, policyErr.Violations[i].Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestNewChainFromConfig_InvalidPattern(t *testing.T) {
+	_, err := NewChainFromConfig(config.ContentPolicyConfig{BannedPatterns: []string{"("}})
+	if err == nil {
+		t.Error("Expected error for invalid pattern but got none")
+	}
+}
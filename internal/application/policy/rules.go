@@ -0,0 +1,160 @@
+package policy
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+)
+
+var urlPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+`)
+
+type controlCharacterRule struct{}
+
+// NewControlCharacterRule satir sonu haric tum kontrol karakterlerini icerikten siler
+func NewControlCharacterRule() services.ContentRule {
+	return &controlCharacterRule{}
+}
+
+func (r *controlCharacterRule) Name() string {
+	return "control_characters"
+}
+
+func (r *controlCharacterRule) Apply(message *entities.Message) []entities.PolicyViolation {
+	message.Content = strings.Map(func(c rune) rune {
+		if c != '\n' && unicode.IsControl(c) {
+			return -1
+		}
+		return c
+	}, message.Content)
+
+	return nil
+}
+
+type bannedContentRule struct {
+	words    []*regexp.Regexp
+	terms    []string
+	patterns []*regexp.Regexp
+}
+
+func NewBannedContentRule(words []string, patterns []*regexp.Regexp) services.ContentRule {
+	rule := &bannedContentRule{patterns: patterns}
+	for _, word := range words {
+		rule.words = append(rule.words, regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(word)+`\b`))
+		rule.terms = append(rule.terms, word)
+	}
+	return rule
+}
+
+func (r *bannedContentRule) Name() string {
+	return "banned_content"
+}
+
+func (r *bannedContentRule) Apply(message *entities.Message) []entities.PolicyViolation {
+	var violations []entities.PolicyViolation
+
+	for i, re := range r.words {
+		if re.MatchString(message.Content) {
+			violations = append(violations, entities.PolicyViolation{
+				Rule:    r.Name(),
+				Message: fmt.Sprintf("content contains banned word %q", r.terms[i]),
+			})
+		}
+	}
+
+	for _, re := range r.patterns {
+		if re.MatchString(message.Content) {
+			violations = append(violations, entities.PolicyViolation{
+				Rule:    r.Name(),
+				Message: fmt.Sprintf("content matches banned pattern %q", re.String()),
+			})
+		}
+	}
+
+	return violations
+}
+
+type urlAllowlistRule struct {
+	domains []string
+}
+
+// NewURLAllowlistRule sadece listedeki domainlere (ve alt domainlerine) giden linklere izin verir
+func NewURLAllowlistRule(domains []string) services.ContentRule {
+	normalized := make([]string, len(domains))
+	for i, domain := range domains {
+		normalized[i] = strings.ToLower(strings.TrimPrefix(domain, "."))
+	}
+	return &urlAllowlistRule{domains: normalized}
+}
+
+func (r *urlAllowlistRule) Name() string {
+	return "url_allowlist"
+}
+
+func (r *urlAllowlistRule) Apply(message *entities.Message) []entities.PolicyViolation {
+	var violations []entities.PolicyViolation
+
+	for _, raw := range urlPattern.FindAllString(message.Content, -1) {
+		if !r.allowed(raw) {
+			violations = append(violations, entities.PolicyViolation{
+				Rule:    r.Name(),
+				Message: fmt.Sprintf("link %q is not on the allowed domain list", raw),
+			})
+		}
+	}
+
+	return violations
+}
+
+func (r *urlAllowlistRule) allowed(raw string) bool {
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	for _, domain := range r.domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type optOutFooterRule struct {
+	footer string
+}
+
+// NewOptOutFooterRule marketing mesajlarinin opt-out metni ile bitmesini zorunlu kilar
+func NewOptOutFooterRule(footer string) services.ContentRule {
+	return &optOutFooterRule{footer: strings.TrimSpace(footer)}
+}
+
+func (r *optOutFooterRule) Name() string {
+	return "opt_out_footer"
+}
+
+func (r *optOutFooterRule) Apply(message *entities.Message) []entities.PolicyViolation {
+	if !message.IsMarketing() {
+		return nil
+	}
+
+	content := strings.ToLower(strings.TrimSpace(message.Content))
+	if strings.HasSuffix(content, strings.ToLower(r.footer)) {
+		return nil
+	}
+
+	return []entities.PolicyViolation{{
+		Rule:    r.Name(),
+		Message: fmt.Sprintf("marketing messages must end with the opt-out footer %q", r.footer),
+	}}
+}
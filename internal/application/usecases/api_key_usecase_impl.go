@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+	"message-sending-service/internal/domain/usecases"
+)
+
+const (
+	apiKeyPrefix      = "msk_"
+	apiKeySecretBytes = 24
+	apiKeyDisplayLen  = 12
+	// her istekte DB'ye yazmamak icin last_used_at en fazla bu siklikla guncellenir
+	apiKeyTouchInterval = time.Minute
+)
+
+type apiKeyUseCaseImpl struct {
+	apiKeyRepo repositories.APIKeyRepository
+	tenantRepo repositories.TenantRepository
+	audit      usecases.AuditUseCase
+	logger     *zap.Logger
+}
+
+func NewAPIKeyUseCase(
+	apiKeyRepo repositories.APIKeyRepository,
+	tenantRepo repositories.TenantRepository,
+	audit usecases.AuditUseCase,
+	logger *zap.Logger,
+) usecases.APIKeyUseCase {
+	return &apiKeyUseCaseImpl{
+		apiKeyRepo: apiKeyRepo,
+		tenantRepo: tenantRepo,
+		audit:      audit,
+		logger:     logger,
+	}
+}
+
+func (uc *apiKeyUseCaseImpl) CreateAPIKey(ctx context.Context, input usecases.CreateAPIKeyInput) (*entities.APIKey, string, error) {
+	tenantID := input.TenantID
+	if tenantID == uuid.Nil {
+		tenantID = entities.DefaultTenantID
+	}
+
+	key := &entities.APIKey{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Name:      input.Name,
+		Scopes:    input.Scopes,
+		CreatedAt: time.Now(),
+	}
+
+	if err := key.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	if _, err := uc.tenantRepo.GetByID(ctx, tenantID); err != nil {
+		return nil, "", err
+	}
+
+	rawKey, err := generateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	key.Prefix = rawKey[:apiKeyDisplayLen]
+	key.KeyHash = entities.HashAPIKey(rawKey)
+
+	if err := uc.apiKeyRepo.Create(ctx, key); err != nil {
+		uc.logger.Error("Failed to create api key", zap.Error(err))
+		return nil, "", err
+	}
+
+	uc.recordAudit(ctx, entities.AuditActionAPIKeyCreate, nil, key)
+
+	uc.logger.Info("API key created",
+		zap.String("api_key_id", key.ID.String()),
+		zap.String("tenant_id", tenantID.String()),
+		zap.Strings("scopes", key.Scopes))
+
+	return key, rawKey, nil
+}
+
+func (uc *apiKeyUseCaseImpl) Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error) {
+	if rawKey == "" {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	key, err := uc.apiKeyRepo.GetByHash(ctx, entities.HashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, entities.ErrAPIKeyNotFound) {
+			return nil, entities.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	if key.IsRevoked() {
+		return nil, entities.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > apiKeyTouchInterval {
+		if err := uc.apiKeyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			uc.logger.Warn("Failed to update api key last used time", zap.String("api_key_id", key.ID.String()), zap.Error(err))
+		}
+		key.LastUsedAt = &now
+	}
+
+	return key, nil
+}
+
+func (uc *apiKeyUseCaseImpl) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	key, err := uc.apiKeyRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	before := *key
+	now := time.Now()
+	if err := uc.apiKeyRepo.Revoke(ctx, id, now); err != nil {
+		return err
+	}
+	if key.RevokedAt == nil {
+		key.RevokedAt = &now
+	}
+
+	uc.recordAudit(ctx, entities.AuditActionAPIKeyRevoke, &before, key)
+
+	uc.logger.Info("API key revoked", zap.String("api_key_id", id.String()))
+	return nil
+}
+
+func (uc *apiKeyUseCaseImpl) GetAPIKeys(ctx context.Context, page, limit int) ([]*entities.APIKey, error) {
+	offset := (page - 1) * limit
+
+	keys, err := uc.apiKeyRepo.GetAll(ctx, offset, limit)
+	if err != nil {
+		uc.logger.Error("Failed to get api keys", zap.Error(err))
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (uc *apiKeyUseCaseImpl) recordAudit(ctx context.Context, action string, before, after *entities.APIKey) {
+	if uc.audit == nil {
+		return
+	}
+
+	input := usecases.RecordAuditInput{
+		Action:     action,
+		TargetType: entities.AuditTargetAPIKey,
+		TargetID:   after.ID.String(),
+		After:      after,
+	}
+	if before != nil {
+		input.Before = before
+	}
+	uc.audit.Record(ctx, input)
+}
+
+func generateAPIKey() (string, error) {
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return apiKeyPrefix + hex.EncodeToString(secret), nil
+}
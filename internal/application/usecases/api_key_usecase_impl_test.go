@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+)
+
+type mockAPIKeyRepository struct {
+	keys map[string]*entities.APIKey
+}
+
+func newMockAPIKeyRepository() *mockAPIKeyRepository {
+	return &mockAPIKeyRepository{keys: make(map[string]*entities.APIKey)}
+}
+
+func (m *mockAPIKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	m.keys[key.KeyHash] = key
+	return nil
+}
+
+func (m *mockAPIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error) {
+	for _, key := range m.keys {
+		if key.ID == id {
+			return key, nil
+		}
+	}
+	return nil, entities.ErrAPIKeyNotFound
+}
+
+func (m *mockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error) {
+	if key, ok := m.keys[keyHash]; ok {
+		return key, nil
+	}
+	return nil, entities.ErrAPIKeyNotFound
+}
+
+func (m *mockAPIKeyRepository) GetAll(ctx context.Context, offset, limit int) ([]*entities.APIKey, error) {
+	var keys []*entities.APIKey
+	for _, key := range m.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (m *mockAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	for _, key := range m.keys {
+		if key.ID == id {
+			key.RevokedAt = &revokedAt
+			return nil
+		}
+	}
+	return entities.ErrAPIKeyNotFound
+}
+
+func (m *mockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	return nil
+}
+
+func TestAPIKeyUseCase_CreateAndAuthenticate(t *testing.T) {
+	repo := newMockAPIKeyRepository()
+	useCase := NewAPIKeyUseCase(repo, &mockTenantRepository{}, nil, zap.NewNop())
+	ctx := context.Background()
+
+	key, rawKey, err := useCase.CreateAPIKey(ctx, domainUsecases.CreateAPIKeyInput{
+		Name:   "checkout",
+		Scopes: []string{entities.ScopeMessagesWrite},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) || !strings.HasPrefix(rawKey, key.Prefix) {
+		t.Errorf("Expected raw key %q to start with %q and display prefix %q", rawKey, apiKeyPrefix, key.Prefix)
+	}
+	if key.KeyHash == rawKey || key.KeyHash != entities.HashAPIKey(rawKey) {
+		t.Error("Expected only the hash of the key to be stored")
+	}
+	if key.TenantID != entities.DefaultTenantID {
+		t.Errorf("Expected default tenant, got %v", key.TenantID)
+	}
+
+	authenticated, err := useCase.Authenticate(ctx, rawKey)
+	if err != nil {
+		t.Fatalf("Expected key to authenticate but got: %v", err)
+	}
+	if authenticated.ID != key.ID {
+		t.Errorf("Expected key %v, got %v", key.ID, authenticated.ID)
+	}
+
+	if _, err := useCase.Authenticate(ctx, rawKey+"x"); !errors.Is(err, entities.ErrInvalidAPIKey) {
+		t.Errorf("Expected ErrInvalidAPIKey for unknown key, got %v", err)
+	}
+
+	if err := useCase.RevokeAPIKey(ctx, key.ID); err != nil {
+		t.Fatalf("Expected no error revoking key but got: %v", err)
+	}
+	if _, err := useCase.Authenticate(ctx, rawKey); !errors.Is(err, entities.ErrInvalidAPIKey) {
+		t.Errorf("Expected ErrInvalidAPIKey for revoked key, got %v", err)
+	}
+}
+
+func TestAPIKeyUseCase_CreateAPIKey_InvalidScope(t *testing.T) {
+	useCase := NewAPIKeyUseCase(newMockAPIKeyRepository(), &mockTenantRepository{}, nil, zap.NewNop())
+
+	_, _, err := useCase.CreateAPIKey(context.Background(), domainUsecases.CreateAPIKeyInput{
+		Name:   "checkout",
+		Scopes: []string{"everything"},
+	})
+	if !errors.Is(err, entities.ErrInvalidAPIKeyScope) {
+		t.Errorf("Expected ErrInvalidAPIKeyScope, got %v", err)
+	}
+}
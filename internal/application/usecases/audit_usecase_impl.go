@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type auditUseCaseImpl struct {
+	auditRepo repositories.AuditLogRepository
+	logger    *zap.Logger
+}
+
+func NewAuditUseCase(auditRepo repositories.AuditLogRepository, logger *zap.Logger) usecases.AuditUseCase {
+	return &auditUseCaseImpl{
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+func (uc *auditUseCaseImpl) Record(ctx context.Context, input usecases.RecordAuditInput) {
+	actor := entities.ActorFromContext(ctx)
+
+	entry := &entities.AuditLog{
+		ID:         uuid.New(),
+		ActorID:    actor.ID,
+		ActorName:  actor.Name,
+		AuthMethod: actor.AuthMethod,
+		IPAddress:  actor.IPAddress,
+		Action:     input.Action,
+		TargetType: input.TargetType,
+		TargetID:   input.TargetID,
+		Before:     uc.snapshot(input.Before),
+		After:      uc.snapshot(input.After),
+		CreatedAt:  time.Now(),
+	}
+	if tenantID, ok := entities.TenantFromContext(ctx); ok {
+		entry.TenantID = &tenantID
+	}
+
+	// istek iptal edilse bile audit kaydi yazilmali
+	if err := uc.auditRepo.Create(context.WithoutCancel(ctx), entry); err != nil {
+		uc.logger.Error("Failed to write audit log",
+			zap.String("action", entry.Action),
+			zap.String("target_id", entry.TargetID),
+			zap.String("actor_id", entry.ActorID),
+			zap.Error(err))
+	}
+}
+
+func (uc *auditUseCaseImpl) GetAuditLogs(ctx context.Context, filter entities.AuditLogFilter, page, limit int) ([]*entities.AuditLog, int64, error) {
+	offset := (page - 1) * limit
+
+	entries, err := uc.auditRepo.GetAll(ctx, filter, offset, limit)
+	if err != nil {
+		uc.logger.Error("Failed to get audit logs", zap.Error(err))
+		return nil, 0, err
+	}
+
+	totalCount, err := uc.auditRepo.Count(ctx, filter)
+	if err != nil {
+		uc.logger.Error("Failed to count audit logs", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return entries, totalCount, nil
+}
+
+func (uc *auditUseCaseImpl) snapshot(state interface{}) json.RawMessage {
+	if state == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		uc.logger.Warn("Failed to encode audit state", zap.Error(err))
+		return nil
+	}
+	return data
+}
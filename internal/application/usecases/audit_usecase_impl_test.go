@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+)
+
+type mockAuditLogRepository struct {
+	entries []*entities.AuditLog
+}
+
+func (m *mockAuditLogRepository) Create(ctx context.Context, entry *entities.AuditLog) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *mockAuditLogRepository) GetAll(ctx context.Context, filter entities.AuditLogFilter, offset, limit int) ([]*entities.AuditLog, error) {
+	return m.entries, nil
+}
+
+func (m *mockAuditLogRepository) Count(ctx context.Context, filter entities.AuditLogFilter) (int64, error) {
+	return int64(len(m.entries)), nil
+}
+
+// recordingAuditUseCase diger use case testlerinde yazilan audit kayitlarini toplar
+type recordingAuditUseCase struct {
+	domainUsecases.AuditUseCase
+	records []domainUsecases.RecordAuditInput
+}
+
+func (r *recordingAuditUseCase) Record(ctx context.Context, input domainUsecases.RecordAuditInput) {
+	r.records = append(r.records, input)
+}
+
+func TestAuditUseCase_Record(t *testing.T) {
+	repo := &mockAuditLogRepository{}
+	useCase := NewAuditUseCase(repo, zap.NewNop())
+
+	tenantID := uuid.New()
+	ctx := entities.ContextWithTenant(context.Background(), tenantID)
+	ctx = entities.ContextWithActor(ctx, entities.Actor{ID: "key-1", Name: "ops", AuthMethod: entities.AuthMethodAPIKey, IPAddress: "10.0.0.1"})
+
+	useCase.Record(ctx, domainUsecases.RecordAuditInput{
+		Action:     entities.AuditActionSchedulerStop,
+		TargetType: entities.AuditTargetScheduler,
+		Before:     map[string]string{"status": "running"},
+		After:      map[string]string{"status": "stopped"},
+	})
+	useCase.Record(context.Background(), domainUsecases.RecordAuditInput{
+		Action:     entities.AuditActionSchedulerStart,
+		TargetType: entities.AuditTargetScheduler,
+	})
+
+	if len(repo.entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(repo.entries))
+	}
+
+	entry := repo.entries[0]
+	if entry.ActorID != "key-1" || entry.ActorName != "ops" || entry.IPAddress != "10.0.0.1" || entry.AuthMethod != entities.AuthMethodAPIKey {
+		t.Errorf("unexpected actor fields: %+v", entry)
+	}
+	if entry.TenantID == nil || *entry.TenantID != tenantID {
+		t.Errorf("expected tenant %v, got %v", tenantID, entry.TenantID)
+	}
+
+	var before map[string]string
+	if err := json.Unmarshal(entry.Before, &before); err != nil || before["status"] != "running" {
+		t.Errorf("unexpected before state %s", entry.Before)
+	}
+
+	system := repo.entries[1]
+	if system.ActorID != entities.SystemActorID || system.TenantID != nil || system.Before != nil {
+		t.Errorf("expected system actor without tenant or state, got %+v", system)
+	}
+}
@@ -0,0 +1,249 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+	"message-sending-service/internal/domain/usecases"
+)
+
+const broadcastPageSize = 500
+
+type contactUseCaseImpl struct {
+	contactRepo    repositories.ContactRepository
+	listRepo       repositories.ContactListRepository
+	messageUseCase usecases.MessageUseCase
+	logger         *zap.Logger
+}
+
+func NewContactUseCase(
+	contactRepo repositories.ContactRepository,
+	listRepo repositories.ContactListRepository,
+	messageUseCase usecases.MessageUseCase,
+	logger *zap.Logger,
+) usecases.ContactUseCase {
+	return &contactUseCaseImpl{
+		contactRepo:    contactRepo,
+		listRepo:       listRepo,
+		messageUseCase: messageUseCase,
+		logger:         logger,
+	}
+}
+
+func (uc *contactUseCaseImpl) CreateContact(ctx context.Context, contact *entities.Contact) error {
+	if err := contact.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	contact.ID = uuid.New()
+	contact.CreatedAt = now
+	contact.UpdatedAt = now
+	if contact.Attributes == nil {
+		contact.Attributes = map[string]string{}
+	}
+
+	if err := uc.contactRepo.Create(ctx, contact); err != nil {
+		uc.logger.Error("Failed to create contact", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (uc *contactUseCaseImpl) GetContact(ctx context.Context, id uuid.UUID) (*entities.Contact, error) {
+	return uc.contactRepo.GetByID(ctx, id)
+}
+
+func (uc *contactUseCaseImpl) UpdateContact(ctx context.Context, contact *entities.Contact) error {
+	if err := contact.Validate(); err != nil {
+		return err
+	}
+
+	contact.UpdatedAt = time.Now()
+	if contact.Attributes == nil {
+		contact.Attributes = map[string]string{}
+	}
+
+	if err := uc.contactRepo.Update(ctx, contact); err != nil {
+		uc.logger.Error("Failed to update contact", zap.String("contact_id", contact.ID.String()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (uc *contactUseCaseImpl) DeleteContact(ctx context.Context, id uuid.UUID) error {
+	return uc.contactRepo.Delete(ctx, id)
+}
+
+func (uc *contactUseCaseImpl) GetContacts(ctx context.Context, page, limit int) ([]*entities.Contact, int64, error) {
+	offset := (page - 1) * limit
+
+	contacts, err := uc.contactRepo.GetAll(ctx, offset, limit)
+	if err != nil {
+		uc.logger.Error("Failed to get contacts", zap.Error(err))
+		return nil, 0, err
+	}
+
+	totalCount, err := uc.contactRepo.Count(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to count contacts", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return contacts, totalCount, nil
+}
+
+func (uc *contactUseCaseImpl) CreateList(ctx context.Context, list *entities.ContactList) error {
+	if err := list.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	list.ID = uuid.New()
+	list.CreatedAt = now
+	list.UpdatedAt = now
+
+	if err := uc.listRepo.Create(ctx, list); err != nil {
+		uc.logger.Error("Failed to create contact list", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (uc *contactUseCaseImpl) GetList(ctx context.Context, id uuid.UUID) (*entities.ContactList, error) {
+	return uc.listRepo.GetByID(ctx, id)
+}
+
+func (uc *contactUseCaseImpl) UpdateList(ctx context.Context, list *entities.ContactList) error {
+	if err := list.Validate(); err != nil {
+		return err
+	}
+
+	list.UpdatedAt = time.Now()
+
+	if err := uc.listRepo.Update(ctx, list); err != nil {
+		uc.logger.Error("Failed to update contact list", zap.String("list_id", list.ID.String()), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (uc *contactUseCaseImpl) DeleteList(ctx context.Context, id uuid.UUID) error {
+	return uc.listRepo.Delete(ctx, id)
+}
+
+func (uc *contactUseCaseImpl) GetLists(ctx context.Context, page, limit int) ([]*entities.ContactList, int64, error) {
+	offset := (page - 1) * limit
+
+	lists, err := uc.listRepo.GetAll(ctx, offset, limit)
+	if err != nil {
+		uc.logger.Error("Failed to get contact lists", zap.Error(err))
+		return nil, 0, err
+	}
+
+	totalCount, err := uc.listRepo.Count(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to count contact lists", zap.Error(err))
+		return nil, 0, err
+	}
+
+	return lists, totalCount, nil
+}
+
+func (uc *contactUseCaseImpl) AddContactsToList(ctx context.Context, listID uuid.UUID, contactIDs []uuid.UUID) error {
+	if _, err := uc.listRepo.GetByID(ctx, listID); err != nil {
+		return err
+	}
+
+	return uc.listRepo.AddContacts(ctx, listID, contactIDs)
+}
+
+func (uc *contactUseCaseImpl) RemoveContactFromList(ctx context.Context, listID, contactID uuid.UUID) error {
+	return uc.listRepo.RemoveContact(ctx, listID, contactID)
+}
+
+func (uc *contactUseCaseImpl) GetListContacts(ctx context.Context, listID uuid.UUID, page, limit int) ([]*entities.Contact, error) {
+	if _, err := uc.listRepo.GetByID(ctx, listID); err != nil {
+		return nil, err
+	}
+
+	return uc.listRepo.GetContacts(ctx, listID, (page-1)*limit, limit)
+}
+
+// Broadcast listedeki her kisi icin ayri pending mesaj olusturur. Mesajlar normal CreateMessage
+// akisindan gectigi icin policy, dedup ve link kisaltma kisi bazinda uygulanir.
+func (uc *contactUseCaseImpl) Broadcast(ctx context.Context, listID uuid.UUID, input usecases.BroadcastInput) (*usecases.BroadcastResult, error) {
+	if _, err := uc.listRepo.GetByID(ctx, listID); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("broadcast").Option("missingkey=zero").Parse(input.Content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entities.ErrInvalidMessageTemplate, err)
+	}
+
+	result := &usecases.BroadcastResult{
+		ListID:     listID,
+		MessageIDs: []uuid.UUID{},
+	}
+
+	for offset := 0; ; offset += broadcastPageSize {
+		contacts, err := uc.listRepo.GetContacts(ctx, listID, offset, broadcastPageSize)
+		if err != nil {
+			uc.logger.Error("Failed to load list contacts for broadcast", zap.String("list_id", listID.String()), zap.Error(err))
+			return nil, err
+		}
+
+		for _, contact := range contacts {
+			if contact.Suppressed {
+				result.Suppressed++
+				continue
+			}
+
+			var content strings.Builder
+			if err := tmpl.Execute(&content, contact.TemplateData()); err != nil {
+				result.AddFailure(contact.ID, fmt.Errorf("%w: %v", entities.ErrInvalidMessageTemplate, err))
+				continue
+			}
+
+			message, err := uc.messageUseCase.CreateMessage(ctx, usecases.CreateMessageInput{
+				Content:     content.String(),
+				PhoneNumber: contact.PhoneNumber,
+				Category:    input.Category,
+				Campaign:    input.Campaign,
+				SenderID:    input.SenderID,
+			})
+			if err != nil {
+				result.AddFailure(contact.ID, err)
+				continue
+			}
+
+			result.Queued++
+			result.MessageIDs = append(result.MessageIDs, message.ID)
+		}
+
+		if len(contacts) < broadcastPageSize {
+			break
+		}
+	}
+
+	uc.logger.Info("Broadcast completed",
+		zap.String("list_id", listID.String()),
+		zap.Int("queued", result.Queued),
+		zap.Int("suppressed", result.Suppressed),
+		zap.Int("failed", result.Failed))
+
+	return result, nil
+}
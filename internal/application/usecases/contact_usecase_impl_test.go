@@ -0,0 +1,151 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+)
+
+type mockContactListRepository struct {
+	lists   map[uuid.UUID]*entities.ContactList
+	members map[uuid.UUID][]*entities.Contact
+}
+
+func newMockContactListRepository() *mockContactListRepository {
+	return &mockContactListRepository{
+		lists:   make(map[uuid.UUID]*entities.ContactList),
+		members: make(map[uuid.UUID][]*entities.Contact),
+	}
+}
+
+func (m *mockContactListRepository) Create(ctx context.Context, list *entities.ContactList) error {
+	m.lists[list.ID] = list
+	return nil
+}
+
+func (m *mockContactListRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.ContactList, error) {
+	if list, exists := m.lists[id]; exists {
+		return list, nil
+	}
+	return nil, entities.ErrContactListNotFound
+}
+
+func (m *mockContactListRepository) Update(ctx context.Context, list *entities.ContactList) error {
+	m.lists[list.ID] = list
+	return nil
+}
+
+func (m *mockContactListRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(m.lists, id)
+	return nil
+}
+
+func (m *mockContactListRepository) GetAll(ctx context.Context, offset, limit int) ([]*entities.ContactList, error) {
+	var all []*entities.ContactList
+	for _, list := range m.lists {
+		all = append(all, list)
+	}
+	return all, nil
+}
+
+func (m *mockContactListRepository) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.lists)), nil
+}
+
+func (m *mockContactListRepository) AddContacts(ctx context.Context, listID uuid.UUID, contactIDs []uuid.UUID) error {
+	return nil
+}
+
+func (m *mockContactListRepository) RemoveContact(ctx context.Context, listID, contactID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockContactListRepository) GetContacts(ctx context.Context, listID uuid.UUID, offset, limit int) ([]*entities.Contact, error) {
+	contacts := m.members[listID]
+	if offset >= len(contacts) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(contacts) {
+		end = len(contacts)
+	}
+	return contacts[offset:end], nil
+}
+
+type recordingMessageUseCase struct {
+	*mockMessageUseCase
+	inputs []domainUsecases.CreateMessageInput
+}
+
+func (m *recordingMessageUseCase) CreateMessage(ctx context.Context, input domainUsecases.CreateMessageInput) (*entities.Message, error) {
+	if input.Content == "" {
+		return nil, entities.ErrInvalidMessageContent
+	}
+	m.inputs = append(m.inputs, input)
+	return &entities.Message{ID: uuid.New(), Content: input.Content, PhoneNumber: input.PhoneNumber}, nil
+}
+
+func TestContactUseCase_Broadcast(t *testing.T) {
+	listRepo := newMockContactListRepository()
+	list := &entities.ContactList{ID: uuid.New(), Name: "VIP"}
+	listRepo.lists[list.ID] = list
+	listRepo.members[list.ID] = []*entities.Contact{
+		{ID: uuid.New(), PhoneNumber: "+1000000001", Name: "Ada", Attributes: map[string]string{"code": "A1"}},
+		{ID: uuid.New(), PhoneNumber: "+1000000002", Name: "Bob", Suppressed: true},
+		{ID: uuid.New(), PhoneNumber: "+1000000003", Name: "", Attributes: map[string]string{}},
+	}
+
+	messageUseCase := &recordingMessageUseCase{mockMessageUseCase: newMockMessageUseCase()}
+	useCase := NewContactUseCase(nil, listRepo, messageUseCase, zap.NewNop())
+
+	campaign := "spring"
+	result, err := useCase.Broadcast(context.Background(), list.ID, domainUsecases.BroadcastInput{
+		Content:  "{{if .name}}Hi {{.name}}, code {{.code}}{{end}}",
+		Category: entities.MessageCategoryMarketing,
+		Campaign: &campaign,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	if result.Queued != 1 || result.Suppressed != 1 || result.Failed != 1 {
+		t.Errorf("Expected 1 queued, 1 suppressed, 1 failed, got %d/%d/%d", result.Queued, result.Suppressed, result.Failed)
+	}
+
+	if len(messageUseCase.inputs) != 1 {
+		t.Fatalf("Expected 1 created message, got %d", len(messageUseCase.inputs))
+	}
+
+	input := messageUseCase.inputs[0]
+	if input.Content != "Hi Ada, code A1" {
+		t.Errorf("Expected rendered content %q, got %q", "Hi Ada, code A1", input.Content)
+	}
+	if input.PhoneNumber != "+1000000001" {
+		t.Errorf("Expected phone number +1000000001, got %s", input.PhoneNumber)
+	}
+	if input.Campaign == nil || *input.Campaign != campaign {
+		t.Errorf("Expected campaign %s to be forwarded", campaign)
+	}
+}
+
+func TestContactUseCase_Broadcast_Errors(t *testing.T) {
+	listRepo := newMockContactListRepository()
+	list := &entities.ContactList{ID: uuid.New(), Name: "VIP"}
+	listRepo.lists[list.ID] = list
+
+	useCase := NewContactUseCase(nil, listRepo, &recordingMessageUseCase{mockMessageUseCase: newMockMessageUseCase()}, zap.NewNop())
+
+	if _, err := useCase.Broadcast(context.Background(), uuid.New(), domainUsecases.BroadcastInput{Content: "Hi"}); !errors.Is(err, entities.ErrContactListNotFound) {
+		t.Errorf("Expected ErrContactListNotFound, got %v", err)
+	}
+
+	if _, err := useCase.Broadcast(context.Background(), list.ID, domainUsecases.BroadcastInput{Content: "Hi {{.name"}); !errors.Is(err, entities.ErrInvalidMessageTemplate) {
+		t.Errorf("Expected ErrInvalidMessageTemplate, got %v", err)
+	}
+}
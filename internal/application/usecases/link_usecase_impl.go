@@ -0,0 +1,198 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+	"message-sending-service/internal/domain/usecases"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+const shortCodeAlphabet = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+var shortenableURLPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"]+`)
+
+// urlTrailingPunctuation link sonunda gelirse cumlenin parcasi sayilir, kisa linkin arkasina geri eklenir
+const urlTrailingPunctuation = ".,;:!?)]'"
+
+// maxShortCodeAttempts kod cakismasinda en fazla bu kadar yeni kod denenir
+const maxShortCodeAttempts = 5
+
+type linkUseCaseImpl struct {
+	linkRepo repositories.ShortLinkRepository
+	config   *config.Config
+	logger   *zap.Logger
+}
+
+func NewLinkUseCase(
+	linkRepo repositories.ShortLinkRepository,
+	config *config.Config,
+	logger *zap.Logger,
+) usecases.LinkUseCase {
+	return &linkUseCaseImpl{
+		linkRepo: linkRepo,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+func (uc *linkUseCaseImpl) ShortenLinks(ctx context.Context, message *entities.Message) ([]string, error) {
+	if !uc.config.ShortLink.Enabled {
+		return nil, nil
+	}
+
+	if uc.config.ShortLink.MarketingOnly && !message.IsMarketing() {
+		return nil, nil
+	}
+
+	// webhook icerigi baska bir servisin okudugu veri, linkleri degistirilmez
+	if message.IsWebhook() {
+		return nil, nil
+	}
+
+	prefix := strings.TrimRight(uc.config.ShortLink.BaseURL, "/") + "/r/"
+
+	var codes []string
+	var shortenErr error
+	content := shortenableURLPattern.ReplaceAllStringFunc(message.Content, func(match string) string {
+		if shortenErr != nil || strings.HasPrefix(match, prefix) {
+			return match
+		}
+
+		// cumle sonundaki noktalama linke dahil degil
+		target := strings.TrimRight(match, urlTrailingPunctuation)
+		suffix := match[len(target):]
+
+		code, err := uc.createLink(ctx, message, target)
+		if err != nil {
+			shortenErr = err
+			return match
+		}
+		codes = append(codes, code)
+
+		return prefix + code + suffix
+	})
+
+	if shortenErr != nil {
+		uc.logger.Error("Failed to shorten message links",
+			zap.String("message_id", message.ID.String()),
+			zap.Error(shortenErr))
+		if err := uc.DiscardLinks(ctx, codes); err != nil {
+			uc.logger.Warn("Failed to discard short links", zap.Strings("codes", codes), zap.Error(err))
+		}
+		return nil, fmt.Errorf("failed to shorten links: %w", shortenErr)
+	}
+
+	message.Content = content
+	return codes, nil
+}
+
+// createLink kod cakisirsa yeni kodla tekrar dener
+func (uc *linkUseCaseImpl) createLink(ctx context.Context, message *entities.Message, target string) (string, error) {
+	for attempt := 1; ; attempt++ {
+		code, err := generateShortCode(uc.config.ShortLink.CodeLength)
+		if err != nil {
+			return "", err
+		}
+
+		err = uc.linkRepo.Create(ctx, &entities.ShortLink{
+			Code:      code,
+			MessageID: message.ID,
+			Campaign:  message.Campaign,
+			TargetURL: target,
+			CreatedAt: time.Now(),
+		})
+		if err == nil {
+			return code, nil
+		}
+		if !errors.Is(err, entities.ErrShortCodeTaken) || attempt == maxShortCodeAttempts {
+			return "", err
+		}
+		uc.logger.Debug("Short link code collision, retrying", zap.String("code", code))
+	}
+}
+
+// DiscardLinks mesaj kaydedilemediginde ShortenLinks'in olusturdugu linkleri siler
+func (uc *linkUseCaseImpl) DiscardLinks(ctx context.Context, codes []string) error {
+	if len(codes) == 0 {
+		return nil
+	}
+	return uc.linkRepo.DeleteByCodes(ctx, codes)
+}
+
+func (uc *linkUseCaseImpl) ResolveClick(ctx context.Context, code, ipAddress, userAgent string) (string, error) {
+	link, err := uc.linkRepo.GetByCode(ctx, code)
+	if err != nil {
+		return "", err
+	}
+
+	// tiklama kaydedilemese bile kullaniciyi yonlendiriyoruz
+	if err := uc.linkRepo.RecordClick(ctx, link.NewClick(ipAddress, userAgent)); err != nil {
+		uc.logger.Warn("Failed to record link click",
+			zap.String("code", code),
+			zap.String("message_id", link.MessageID.String()),
+			zap.Error(err))
+	}
+
+	return link.TargetURL, nil
+}
+
+func (uc *linkUseCaseImpl) GetMessageClickStats(ctx context.Context, messageID uuid.UUID) (*usecases.ClickStats, error) {
+	counts, err := uc.linkRepo.GetClickCountsByMessage(ctx, messageID)
+	if err != nil {
+		uc.logger.Error("Failed to get message click stats", zap.String("message_id", messageID.String()), zap.Error(err))
+		return nil, err
+	}
+
+	return newClickStats(counts), nil
+}
+
+func (uc *linkUseCaseImpl) GetCampaignClickStats(ctx context.Context, campaign string) (*usecases.ClickStats, error) {
+	counts, err := uc.linkRepo.GetClickCountsByCampaign(ctx, campaign)
+	if err != nil {
+		uc.logger.Error("Failed to get campaign click stats", zap.String("campaign", campaign), zap.Error(err))
+		return nil, err
+	}
+
+	return newClickStats(counts), nil
+}
+
+func newClickStats(counts []*entities.LinkClickCount) *usecases.ClickStats {
+	stats := &usecases.ClickStats{Links: counts}
+	if stats.Links == nil {
+		stats.Links = []*entities.LinkClickCount{}
+	}
+	for _, count := range counts {
+		stats.TotalClicks += count.Clicks
+	}
+	return stats
+}
+
+func generateShortCode(length int) (string, error) {
+	if length <= 0 {
+		length = 7
+	}
+
+	max := big.NewInt(int64(len(shortCodeAlphabet)))
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate short code: %w", err)
+		}
+		code[i] = shortCodeAlphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
@@ -0,0 +1,332 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+type mockShortLinkRepository struct {
+	links      map[string]*entities.ShortLink
+	clicks     []*entities.LinkClick
+	shouldFail bool
+	createFunc func(link *entities.ShortLink) error
+}
+
+func newMockShortLinkRepository() *mockShortLinkRepository {
+	return &mockShortLinkRepository{
+		links: make(map[string]*entities.ShortLink),
+	}
+}
+
+func (m *mockShortLinkRepository) Create(ctx context.Context, link *entities.ShortLink) error {
+	if m.shouldFail {
+		return errors.New("database error")
+	}
+	if m.createFunc != nil {
+		if err := m.createFunc(link); err != nil {
+			return err
+		}
+	}
+	m.links[link.Code] = link
+	return nil
+}
+
+func (m *mockShortLinkRepository) DeleteByCodes(ctx context.Context, codes []string) error {
+	for _, code := range codes {
+		delete(m.links, code)
+	}
+	return nil
+}
+
+func (m *mockShortLinkRepository) GetByCode(ctx context.Context, code string) (*entities.ShortLink, error) {
+	if link, exists := m.links[code]; exists {
+		return link, nil
+	}
+	return nil, entities.ErrShortLinkNotFound
+}
+
+func (m *mockShortLinkRepository) RecordClick(ctx context.Context, click *entities.LinkClick) error {
+	m.clicks = append(m.clicks, click)
+	return nil
+}
+
+func (m *mockShortLinkRepository) countClicks(match func(link *entities.ShortLink) bool) []*entities.LinkClickCount {
+	var counts []*entities.LinkClickCount
+	for _, link := range m.links {
+		if !match(link) {
+			continue
+		}
+		count := &entities.LinkClickCount{Code: link.Code, MessageID: link.MessageID, TargetURL: link.TargetURL}
+		for _, click := range m.clicks {
+			if click.Code == link.Code {
+				count.Clicks++
+			}
+		}
+		counts = append(counts, count)
+	}
+	return counts
+}
+
+func (m *mockShortLinkRepository) GetClickCountsByMessage(ctx context.Context, messageID uuid.UUID) ([]*entities.LinkClickCount, error) {
+	return m.countClicks(func(link *entities.ShortLink) bool { return link.MessageID == messageID }), nil
+}
+
+func (m *mockShortLinkRepository) GetClickCountsByCampaign(ctx context.Context, campaign string) ([]*entities.LinkClickCount, error) {
+	return m.countClicks(func(link *entities.ShortLink) bool { return link.Campaign != nil && *link.Campaign == campaign }), nil
+}
+
+func newLinkTestConfig() *config.Config {
+	return &config.Config{
+		ShortLink: config.ShortLinkConfig{
+			Enabled:       true,
+			BaseURL:       "https://s.example.com/",
+			CodeLength:    6,
+			MarketingOnly: true,
+		},
+	}
+}
+
+func TestLinkUseCase_ShortenLinks(t *testing.T) {
+	tests := []struct {
+		name      string
+		category  entities.MessageCategory
+		channel   entities.MessageChannel
+		content   string
+		wantLinks int
+	}{
+		{
+			name:      "marketing links are shortened",
+			category:  entities.MessageCategoryMarketing,
+			content:   "Sale at https://example.com/very/long/path?utm_source=sms and http://example.org",
+			wantLinks: 2,
+		},
+		{
+			name:      "transactional links are kept",
+			category:  entities.MessageCategoryTransactional,
+			content:   "Reset at https://example.com/reset?token=abc",
+			wantLinks: 0,
+		},
+		{
+			name:      "webhook links are kept",
+			category:  entities.MessageCategoryMarketing,
+			channel:   entities.MessageChannelWebhook,
+			content:   `{"url": "https://example.com/very/long/path"}`,
+			wantLinks: 0,
+		},
+		{
+			name:      "content without links",
+			category:  entities.MessageCategoryMarketing,
+			content:   "No links here",
+			wantLinks: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockShortLinkRepository()
+			useCase := NewLinkUseCase(repo, newLinkTestConfig(), zap.NewNop())
+
+			campaign := "spring"
+			message := &entities.Message{ID: uuid.New(), Content: tt.content, Category: tt.category, Channel: tt.channel, Campaign: &campaign}
+
+			if _, err := useCase.ShortenLinks(context.Background(), message); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			if len(repo.links) != tt.wantLinks {
+				t.Errorf("Expected %d short links, got %d", tt.wantLinks, len(repo.links))
+			}
+
+			if tt.wantLinks == 0 && message.Content != tt.content {
+				t.Errorf("Expected content to be unchanged, got %q", message.Content)
+			}
+
+			for code, link := range repo.links {
+				if !strings.Contains(message.Content, "https://s.example.com/r/"+code) {
+					t.Errorf("Expected content to contain short link for %s, got %q", code, message.Content)
+				}
+				if strings.Contains(message.Content, link.TargetURL) {
+					t.Errorf("Expected original URL %s to be replaced", link.TargetURL)
+				}
+				if link.MessageID != message.ID {
+					t.Errorf("Expected link to reference message %v, got %v", message.ID, link.MessageID)
+				}
+			}
+		})
+	}
+}
+
+func TestLinkUseCase_ShortenLinks_TrailingPunctuation(t *testing.T) {
+	repo := newMockShortLinkRepository()
+	useCase := NewLinkUseCase(repo, newLinkTestConfig(), zap.NewNop())
+
+	message := &entities.Message{
+		ID:       uuid.New(),
+		Content:  "Sale (https://example.com/sale). See https://example.org/a?b=1, or https://example.net!",
+		Category: entities.MessageCategoryMarketing,
+	}
+	codes, err := useCase.ShortenLinks(context.Background(), message)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(codes) != 3 {
+		t.Fatalf("Expected 3 short links, got %d", len(codes))
+	}
+
+	want := "Sale (https://s.example.com/r/" + codes[0] + "). See https://s.example.com/r/" + codes[1] +
+		", or https://s.example.com/r/" + codes[2] + "!"
+	if message.Content != want {
+		t.Errorf("Expected %q, got %q", want, message.Content)
+	}
+	for i, target := range []string{"https://example.com/sale", "https://example.org/a?b=1", "https://example.net"} {
+		if repo.links[codes[i]].TargetURL != target {
+			t.Errorf("Expected target %s, got %s", target, repo.links[codes[i]].TargetURL)
+		}
+	}
+}
+
+func TestLinkUseCase_ShortenLinks_CodeCollision(t *testing.T) {
+	repo := newMockShortLinkRepository()
+	collisions := 2
+	repo.createFunc = func(link *entities.ShortLink) error {
+		if collisions > 0 {
+			collisions--
+			return entities.ErrShortCodeTaken
+		}
+		return nil
+	}
+	useCase := NewLinkUseCase(repo, newLinkTestConfig(), zap.NewNop())
+
+	message := &entities.Message{ID: uuid.New(), Content: "Sale at https://example.com/sale", Category: entities.MessageCategoryMarketing}
+	codes, err := useCase.ShortenLinks(context.Background(), message)
+	if err != nil {
+		t.Fatalf("Expected collisions to be retried, got %v", err)
+	}
+	if len(codes) != 1 || len(repo.links) != 1 {
+		t.Errorf("Expected one short link, got %v", codes)
+	}
+
+	repo.createFunc = func(link *entities.ShortLink) error { return entities.ErrShortCodeTaken }
+	message.Content = "Sale at https://example.com/other"
+	if _, err := useCase.ShortenLinks(context.Background(), message); !errors.Is(err, entities.ErrShortCodeTaken) {
+		t.Errorf("Expected ErrShortCodeTaken after %d attempts, got %v", maxShortCodeAttempts, err)
+	}
+}
+
+func TestLinkUseCase_ShortenLinks_PartialFailure(t *testing.T) {
+	repo := newMockShortLinkRepository()
+	created := 0
+	repo.createFunc = func(link *entities.ShortLink) error {
+		if created == 1 {
+			return errors.New("database error")
+		}
+		created++
+		return nil
+	}
+	useCase := NewLinkUseCase(repo, newLinkTestConfig(), zap.NewNop())
+
+	content := "Sale at https://example.com/sale and https://example.org"
+	message := &entities.Message{ID: uuid.New(), Content: content, Category: entities.MessageCategoryMarketing}
+	if _, err := useCase.ShortenLinks(context.Background(), message); err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if len(repo.links) != 0 {
+		t.Errorf("Expected links created before the failure to be discarded, got %d", len(repo.links))
+	}
+	if message.Content != content {
+		t.Errorf("Expected content to be unchanged on error, got %q", message.Content)
+	}
+}
+
+func TestLinkUseCase_ShortenLinks_RepositoryError(t *testing.T) {
+	repo := newMockShortLinkRepository()
+	repo.shouldFail = true
+	useCase := NewLinkUseCase(repo, newLinkTestConfig(), zap.NewNop())
+
+	content := "Sale at https://example.com/sale"
+	message := &entities.Message{ID: uuid.New(), Content: content, Category: entities.MessageCategoryMarketing}
+
+	if _, err := useCase.ShortenLinks(context.Background(), message); err == nil {
+		t.Error("Expected error but got none")
+	}
+
+	if message.Content != content {
+		t.Errorf("Expected content to be unchanged on error, got %q", message.Content)
+	}
+}
+
+func TestLinkUseCase_ResolveClick(t *testing.T) {
+	repo := newMockShortLinkRepository()
+	useCase := NewLinkUseCase(repo, newLinkTestConfig(), zap.NewNop())
+	ctx := context.Background()
+
+	campaign := "spring"
+	message := &entities.Message{ID: uuid.New(), Content: "Go https://example.com/sale", Category: entities.MessageCategoryMarketing, Campaign: &campaign}
+	if _, err := useCase.ShortenLinks(ctx, message); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	var code string
+	for c := range repo.links {
+		code = c
+	}
+
+	for i := 0; i < 3; i++ {
+		target, err := useCase.ResolveClick(ctx, code, "127.0.0.1", "test-agent")
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if target != "https://example.com/sale" {
+			t.Errorf("Expected target https://example.com/sale, got %s", target)
+		}
+	}
+
+	if _, err := useCase.ResolveClick(ctx, "missing", "127.0.0.1", "test-agent"); !errors.Is(err, entities.ErrShortLinkNotFound) {
+		t.Errorf("Expected ErrShortLinkNotFound, got %v", err)
+	}
+
+	messageStats, err := useCase.GetMessageClickStats(ctx, message.ID)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if messageStats.TotalClicks != 3 {
+		t.Errorf("Expected 3 message clicks, got %d", messageStats.TotalClicks)
+	}
+
+	campaignStats, err := useCase.GetCampaignClickStats(ctx, campaign)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if campaignStats.TotalClicks != 3 {
+		t.Errorf("Expected 3 campaign clicks, got %d", campaignStats.TotalClicks)
+	}
+}
+
+func TestMessageUseCase_CreateMessage_DiscardsLinksOnFailure(t *testing.T) {
+	linkRepo := newMockShortLinkRepository()
+	linkUseCase := NewLinkUseCase(linkRepo, newLinkTestConfig(), zap.NewNop())
+	messageRepo := newMockMessageRepository()
+	messageRepo.shouldFail = true
+	useCase := NewMessageUseCase(messageRepo, nil, nil, nil, nil, linkUseCase, nil, nil, nil, nil, zap.NewNop())
+
+	_, err := useCase.CreateMessage(context.Background(), domainUsecases.CreateMessageInput{
+		Content:     "Sale at https://example.com/sale",
+		PhoneNumber: "+1234567890",
+		Category:    entities.MessageCategoryMarketing,
+	})
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if len(linkRepo.links) != 0 {
+		t.Errorf("Expected the short links of the unsaved message to be discarded, got %d", len(linkRepo.links))
+	}
+}
@@ -2,7 +2,11 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,38 +14,97 @@ import (
 
 	"message-sending-service/internal/domain/entities"
 	"message-sending-service/internal/domain/repositories"
+	"message-sending-service/internal/domain/services"
 	"message-sending-service/internal/domain/usecases"
-	"message-sending-service/internal/infrastructure/external"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+const dedupKeyPrefix = "message_dedup:"
+
+// dedupReservation mesaj kaydedilene kadar parmak izi anahtarinda tutulan deger
+var dedupReservation = uuid.Nil
+
+const (
+	defaultSendMaxAttempts  = 5
+	defaultSendRetryBackoff = time.Minute
+	maxSendRetryBackoff     = time.Hour
 )
 
 type messageUseCaseImpl struct {
-	messageRepo repositories.MessageRepository
-	cacheRepo   repositories.CacheRepository
-	apiClient   *external.MessageAPIClient
-	logger      *zap.Logger
+	messageRepo   repositories.MessageRepository
+	tenantRepo    repositories.TenantRepository
+	cacheRepo     repositories.CacheRepository
+	provider      services.MessageProvider
+	contentPolicy services.ContentPolicy
+	linkShortener services.LinkShortener
+	quota         services.QuotaEnforcer
+	pricing       services.CostCalculator
+	audit         usecases.AuditUseCase
+	config        *config.Config
+	logger        *zap.Logger
+
+	// tenantCursor her batch'te ilk sirayi alan tenant'i kaydirir
+	tenantCursor atomic.Uint64
 }
 
 func NewMessageUseCase(
 	messageRepo repositories.MessageRepository,
+	tenantRepo repositories.TenantRepository,
 	cacheRepo repositories.CacheRepository,
-	apiClient *external.MessageAPIClient,
+	provider services.MessageProvider,
+	contentPolicy services.ContentPolicy,
+	linkShortener services.LinkShortener,
+	quota services.QuotaEnforcer,
+	pricing services.CostCalculator,
+	audit usecases.AuditUseCase,
+	config *config.Config,
 	logger *zap.Logger,
 ) usecases.MessageUseCase {
 	return &messageUseCaseImpl{
-		messageRepo: messageRepo,
-		cacheRepo:   cacheRepo,
-		apiClient:   apiClient,
-		logger:      logger,
+		messageRepo:   messageRepo,
+		tenantRepo:    tenantRepo,
+		cacheRepo:     cacheRepo,
+		provider:      provider,
+		contentPolicy: contentPolicy,
+		linkShortener: linkShortener,
+		quota:         quota,
+		pricing:       pricing,
+		audit:         audit,
+		config:        config,
+		logger:        logger,
 	}
 }
 
-func (uc *messageUseCaseImpl) CreateMessage(ctx context.Context, content, phoneNumber string) (*entities.Message, error) {
+func (uc *messageUseCaseImpl) CreateMessage(ctx context.Context, input usecases.CreateMessageInput) (*entities.Message, error) {
+	category := input.Category
+	if category == "" {
+		category = entities.MessageCategoryTransactional
+	}
+
+	channel := input.Channel
+	if channel == "" {
+		channel = entities.MessageChannelSMS
+	}
+	if !uc.channelEnabled(channel) {
+		return nil, entities.ErrChannelNotConfigured
+	}
+
+	tenantID, _ := entities.TenantFromContext(ctx)
+
 	now := time.Now()
 	message := &entities.Message{
 		ID:          uuid.New(),
-		Content:     content,
-		PhoneNumber: phoneNumber,
+		TenantID:    tenantID,
+		Content:     input.Content,
+		PhoneNumber: input.PhoneNumber,
+		Channel:     channel,
+		Email:       input.Email,
+		Subject:     input.Subject,
+		WebhookURL:  input.WebhookURL,
 		Status:      entities.MessageStatusPending,
+		Category:    category,
+		Campaign:    input.Campaign,
+		SenderID:    input.SenderID,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -50,15 +113,204 @@ func (uc *messageUseCaseImpl) CreateMessage(ctx context.Context, content, phoneN
 		return nil, err
 	}
 
+	if message.SenderID != nil && uc.config != nil && !uc.config.Sender.IsAllowed(*message.SenderID) {
+		return nil, entities.ErrSenderIDNotAllowed
+	}
+
+	if err := uc.checkWebhookHost(message); err != nil {
+		return nil, err
+	}
+
+	if uc.contentPolicy != nil {
+		if err := uc.contentPolicy.Evaluate(message); err != nil {
+			uc.logger.Info("Message rejected by content policy", zap.Error(err))
+			return nil, err
+		}
+
+		// temizleyici kurallar icerigi bosaltmis olabilir
+		if err := message.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	fingerprint := message.Fingerprint()
+	reserved, original, err := uc.reserveFingerprint(ctx, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	if original != nil {
+		return original, nil
+	}
+	// mesaj kaydedilemezse rezervasyon silinir, ayni istek tekrar denenebilsin
+	created := false
+	if reserved {
+		defer func() {
+			if !created {
+				uc.releaseFingerprint(ctx, fingerprint)
+			}
+		}()
+	}
+
+	var linkCodes []string
+	if uc.linkShortener != nil {
+		if linkCodes, err = uc.linkShortener.ShortenLinks(ctx, message); err != nil {
+			return nil, err
+		}
+	}
+
+	// kisa linkler icerigin uzunlugunu degistirdigi icin tahmin ve kota en son hesaplanir
+	uc.estimateCost(message)
+
+	reservation, err := uc.reserveQuota(ctx, message)
+	if err != nil {
+		uc.logger.Info("Message rejected by tenant quota", zap.String("tenant_id", message.TenantID.String()), zap.Error(err))
+		uc.discardLinks(ctx, message, linkCodes)
+		return nil, err
+	}
+
 	if err := uc.messageRepo.Create(ctx, message); err != nil {
 		uc.logger.Error("Failed to create message", zap.Error(err))
+		uc.discardLinks(ctx, message, linkCodes)
+		uc.releaseQuota(ctx, message, reservation)
 		return nil, fmt.Errorf("failed to create message: %w", err)
 	}
 
+	created = true
+	if reserved {
+		uc.rememberFingerprint(ctx, fingerprint, message.ID)
+	}
+
 	uc.logger.Info("Message created successfully", zap.String("message_id", message.ID.String()))
 	return message, nil
 }
 
+func (uc *messageUseCaseImpl) dedupEnabled() bool {
+	return uc.cacheRepo != nil && uc.config != nil && uc.config.Dedup.Enabled && uc.config.Dedup.Window > 0
+}
+
+// channelEnabled sms her zaman acik; email ve webhook config'te acilmadikca mesaj kabul edilmez
+func (uc *messageUseCaseImpl) channelEnabled(channel entities.MessageChannel) bool {
+	if uc.config == nil {
+		return true
+	}
+	switch channel {
+	case entities.MessageChannelEmail:
+		return uc.config.Email.Enabled
+	case entities.MessageChannelWebhook:
+		return uc.config.Webhook.Enabled
+	}
+	return true
+}
+
+// checkWebhookHost URL'in host'unu WEBHOOK_ALLOWED_HOSTS ile karsilastirir; Validate'den sonra cagrilir
+func (uc *messageUseCaseImpl) checkWebhookHost(message *entities.Message) error {
+	if !message.IsWebhook() || message.WebhookURL == nil || uc.config == nil {
+		return nil
+	}
+	parsed, err := url.Parse(*message.WebhookURL)
+	if err != nil {
+		return entities.ErrInvalidWebhookURL
+	}
+	if !uc.config.Webhook.IsHostAllowed(parsed.Hostname()) {
+		return entities.ErrWebhookHostNotAllowed
+	}
+	return nil
+}
+
+// reserveFingerprint parmak izini SETNX ile pencere suresince rezerve eder; ayni anda gelen iki istekten
+// sadece biri mesaj olusturur. Rezervasyon alinamazsa findDuplicate'in sonucu doner. Cache hatalari mesaj
+// kabulunu engellemez, o durumda rezervasyon yapilmamis sayilir.
+func (uc *messageUseCaseImpl) reserveFingerprint(ctx context.Context, fingerprint string) (bool, *entities.Message, error) {
+	if !uc.dedupEnabled() {
+		return false, nil, nil
+	}
+
+	key := dedupKeyPrefix + fingerprint
+	reserved, err := uc.cacheRepo.SetNX(ctx, key, dedupReservation, uc.config.Dedup.Window)
+	if err != nil {
+		uc.logger.Warn("Failed to reserve message fingerprint", zap.Error(err))
+		return false, nil, nil
+	}
+	if reserved {
+		return true, nil, nil
+	}
+
+	original, err := uc.findDuplicate(ctx, key)
+	if err != nil || original != nil {
+		return false, original, err
+	}
+
+	// orijinal mesaj silinmis; anahtar bu istek icin bir kez daha denenir
+	if err := uc.cacheRepo.Delete(ctx, key); err != nil {
+		uc.logger.Warn("Failed to drop stale message fingerprint", zap.Error(err))
+		return false, nil, nil
+	}
+	reserved, err = uc.cacheRepo.SetNX(ctx, key, dedupReservation, uc.config.Dedup.Window)
+	if err != nil {
+		uc.logger.Warn("Failed to reserve message fingerprint", zap.Error(err))
+		return false, nil, nil
+	}
+	if !reserved {
+		return false, nil, entities.ErrDuplicateMessage
+	}
+	return true, nil, nil
+}
+
+// findDuplicate pencere icinde ayni mesaj varsa reject modunda ErrDuplicateMessage,
+// link modunda orijinal mesaji doner. Orijinal henuz kaydedilmemisse (rezervasyon) iki modda da
+// ErrDuplicateMessage doner; orijinal silinmisse nil, nil.
+func (uc *messageUseCaseImpl) findDuplicate(ctx context.Context, key string) (*entities.Message, error) {
+	val, err := uc.cacheRepo.Get(ctx, key)
+	if err != nil {
+		uc.logger.Warn("Failed to read duplicate message fingerprint", zap.Error(err))
+		return nil, nil
+	}
+
+	var originalID uuid.UUID
+	if err := json.Unmarshal([]byte(val), &originalID); err != nil {
+		uc.logger.Warn("Invalid duplicate message fingerprint value", zap.String("key", key), zap.Error(err))
+		return nil, nil
+	}
+
+	uc.logger.Info("Duplicate message detected",
+		zap.String("original_message_id", originalID.String()),
+		zap.String("mode", uc.config.Dedup.Mode))
+
+	if originalID == dedupReservation {
+		return nil, fmt.Errorf("%w (original message is still being created)", entities.ErrDuplicateMessage)
+	}
+
+	if uc.config.Dedup.Mode != config.DedupModeLink {
+		return nil, fmt.Errorf("%w (original message %s)", entities.ErrDuplicateMessage, originalID)
+	}
+
+	original, err := uc.messageRepo.GetByID(ctx, originalID)
+	if err != nil {
+		if errors.Is(err, entities.ErrMessageNotFound) {
+			return nil, nil
+		}
+		uc.logger.Error("Failed to load original message for duplicate", zap.String("message_id", originalID.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed to load original message: %w", err)
+	}
+
+	return original, nil
+}
+
+// rememberFingerprint rezervasyonu olusturulan mesaja baglar; pencere bu andan itibaren yeniden baslar
+func (uc *messageUseCaseImpl) rememberFingerprint(ctx context.Context, fingerprint string, messageID uuid.UUID) {
+	if err := uc.cacheRepo.Set(ctx, dedupKeyPrefix+fingerprint, messageID, uc.config.Dedup.Window); err != nil {
+		uc.logger.Warn("Failed to store message fingerprint",
+			zap.String("message_id", messageID.String()),
+			zap.Error(err))
+	}
+}
+
+func (uc *messageUseCaseImpl) releaseFingerprint(ctx context.Context, fingerprint string) {
+	if err := uc.cacheRepo.Delete(ctx, dedupKeyPrefix+fingerprint); err != nil {
+		uc.logger.Warn("Failed to release message fingerprint", zap.Error(err))
+	}
+}
+
 func (uc *messageUseCaseImpl) GetMessageByID(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
 	message, err := uc.messageRepo.GetByID(ctx, id)
 	if err != nil {
@@ -100,40 +352,107 @@ func (uc *messageUseCaseImpl) GetSentMessages(ctx context.Context, page, limit i
 func (uc *messageUseCaseImpl) SendMessage(ctx context.Context, message *entities.Message) error {
 	uc.logger.Info("Sending message",
 		zap.String("message_id", message.ID.String()),
-		zap.String("phone_number", message.PhoneNumber))
+		zap.String("channel", string(message.Channel)),
+		zap.String("recipient", message.Recipient()))
 
-	response, err := uc.apiClient.SendMessage(ctx, message.PhoneNumber, message.Content)
+	// kabulde payi ayrilmayan mesaj (quota sonradan acildi ya da requeue edildi) gonderimden once ayirir;
+	// kota doluysa mesaj pending kalir ve kota yenilenene kadar scheduler almaz
+	reservation, err := uc.reserveQuota(ctx, message)
 	if err != nil {
-		message.MarkAsFailed(err.Error())
-		if updateErr := uc.messageRepo.Update(ctx, message); updateErr != nil {
-			uc.logger.Error("Failed to update message status after API error",
-				zap.String("message_id", message.ID.String()),
-				zap.Error(updateErr))
+		var quotaErr *entities.QuotaExceededError
+		if errors.As(err, &quotaErr) && !quotaErr.ResetsAt.IsZero() {
+			message.HoldUntil(quotaErr.ResetsAt)
+			if updateErr := uc.messageRepo.Update(ctx, message); updateErr != nil {
+				uc.logger.Error("Failed to hold message back until quota resets",
+					zap.String("message_id", message.ID.String()),
+					zap.Error(updateErr))
+			}
 		}
-
-		uc.logger.Error("Failed to send message via API",
+		uc.logger.Info("Message held back by tenant quota",
 			zap.String("message_id", message.ID.String()),
 			zap.Error(err))
 		return err
 	}
 
-	if response.Status != "sent" {
-		errorMsg := response.Error
-		if errorMsg == "" {
-			errorMsg = "Unknown error from API"
+	result, err := uc.provider.Send(ctx, entities.NewSendRequest(message))
+
+	// devre acik ya da provider throttle ediyor; mesaj pending kalir ve sonraki batch'te tekrar denenir.
+	// Sadece SMS failover zinciri Retry-After boyunca provider'i durdurur; email ve webhook'ta throttle
+	// deneme sayilir ve asagida backoff ile tekrar denenir, yoksa mesaj her tick'te ayni adrese gider.
+	if message.IsSMS() && entities.IsSendDeferred(err) {
+		uc.logger.Warn("Message held back, provider unavailable",
+			zap.String("message_id", message.ID.String()),
+			zap.Error(err))
+		if reservation != nil {
+			message.SetQuotaReservation(nil)
+			uc.releaseQuota(ctx, message, reservation)
 		}
+		return err
+	}
+
+	providerName := uc.provider.Name()
+	var sendErr *entities.ProviderSendError
+	switch {
+	case err == nil && result.Provider != "":
+		providerName = result.Provider
+	case errors.As(err, &sendErr):
+		providerName = sendErr.Provider
+	}
+	message.Provider = &providerName
 
-		message.MarkAsFailed(errorMsg)
+	if err != nil {
+		// gecici hatada mesaj pending kalir ve backoff sonrasi tekrar denenir; kalici hata ya da deneme limiti failed yapar
+		maxAttempts, backoff := uc.retryPolicy()
+		retry := !entities.ErrorCodeOf(err).IsPermanent() && message.Attempts+1 < maxAttempts
+		// kalici olarak gonderilemeyen mesajin payi kotaya geri verilir
+		var released *entities.QuotaReservation
+		if retry {
+			delay := retryDelay(backoff, message.Attempts+1)
+			var throttled *entities.ProviderThrottledError
+			if errors.As(err, &throttled) && throttled.RetryAfter > 0 {
+				delay = throttled.RetryAfter
+			}
+			message.ScheduleRetry(err, delay)
+		} else {
+			message.MarkSendFailed(err)
+			released = message.QuotaReservation()
+			message.SetQuotaReservation(nil)
+		}
 		if updateErr := uc.messageRepo.Update(ctx, message); updateErr != nil {
-			uc.logger.Error("Failed to update message status after API failure",
+			uc.logger.Error("Failed to update message status after provider error",
 				zap.String("message_id", message.ID.String()),
 				zap.Error(updateErr))
+			// kayittaki mesaj hala eski rezervasyonunu tutuyor; sadece bu denemede ayrilan pay kayda gecmedi
+			released = reservation
+		}
+		uc.releaseQuota(ctx, message, released)
+
+		if retry {
+			uc.logger.Warn("Failed to send message via provider, retry scheduled",
+				zap.String("message_id", message.ID.String()),
+				zap.String("provider", providerName),
+				zap.String("error_code", string(*message.ErrorCode)),
+				zap.Int("attempts", message.Attempts),
+				zap.Time("next_attempt_at", *message.NextAttemptAt),
+				zap.Error(err))
+			return err
 		}
 
-		return fmt.Errorf("API returned error: %s", errorMsg)
+		uc.logger.Error("Failed to send message via provider",
+			zap.String("message_id", message.ID.String()),
+			zap.String("provider", providerName),
+			zap.String("error_code", string(*message.ErrorCode)),
+			zap.Int("attempts", message.Attempts),
+			zap.Error(err))
+		return err
 	}
 
-	message.MarkAsSent(response.MessageID)
+	message.MarkAsSent(result.ExternalMessageID)
+	if uc.pricing != nil {
+		if cost, ok := uc.pricing.Cost(providerName, message); ok {
+			message.SetActualCost(cost)
+		}
+	}
 	if err := uc.messageRepo.Update(ctx, message); err != nil {
 		uc.logger.Error("Failed to update message status after successful send",
 			zap.String("message_id", message.ID.String()),
@@ -142,7 +461,7 @@ func (uc *messageUseCaseImpl) SendMessage(ctx context.Context, message *entities
 	}
 
 	if uc.cacheRepo != nil {
-		if err := uc.cacheRepo.SetMessageSent(ctx, message.ID.String(), response.MessageID, *message.SentAt); err != nil {
+		if err := uc.cacheRepo.SetMessageSent(ctx, message.ID.String(), result.ExternalMessageID, *message.SentAt); err != nil {
 			uc.logger.Warn("Failed to cache sent message info",
 				zap.String("message_id", message.ID.String()),
 				zap.Error(err))
@@ -151,7 +470,8 @@ func (uc *messageUseCaseImpl) SendMessage(ctx context.Context, message *entities
 
 	uc.logger.Info("Message sent successfully",
 		zap.String("message_id", message.ID.String()),
-		zap.String("external_message_id", response.MessageID))
+		zap.String("provider", providerName),
+		zap.String("external_message_id", result.ExternalMessageID))
 
 	return nil
 }
@@ -159,7 +479,7 @@ func (uc *messageUseCaseImpl) SendMessage(ctx context.Context, message *entities
 func (uc *messageUseCaseImpl) ProcessPendingMessages(ctx context.Context, batchSize int) (int, error) {
 	uc.logger.Info("Processing pending messages", zap.Int("batch_size", batchSize))
 
-	messages, err := uc.GetPendingMessages(ctx, batchSize)
+	messages, err := uc.collectPendingMessages(ctx, batchSize)
 	if err != nil {
 		return 0, err
 	}
@@ -171,7 +491,12 @@ func (uc *messageUseCaseImpl) ProcessPendingMessages(ctx context.Context, batchS
 
 	successCount := 0
 	for _, message := range messages {
-		if err := uc.SendMessage(ctx, message); err != nil {
+		messageCtx := ctx
+		if message.TenantID != uuid.Nil {
+			messageCtx = entities.ContextWithTenant(ctx, message.TenantID)
+		}
+
+		if err := uc.SendMessage(messageCtx, message); err != nil {
 			uc.logger.Error("Failed to send message",
 				zap.String("message_id", message.ID.String()),
 				zap.Error(err))
@@ -188,6 +513,57 @@ func (uc *messageUseCaseImpl) ProcessPendingMessages(ctx context.Context, batchS
 	return successCount, nil
 }
 
+// collectPendingMessages context'te tenant varsa sadece onu, yoksa (scheduler) tum tenant'lari isler.
+// Batch tenant'lar arasinda round-robin dagitilir, boylece kalabalik bir tenant digerlerini bekletmez.
+func (uc *messageUseCaseImpl) collectPendingMessages(ctx context.Context, batchSize int) ([]*entities.Message, error) {
+	if _, ok := entities.TenantFromContext(ctx); ok || uc.tenantRepo == nil {
+		return uc.GetPendingMessages(ctx, batchSize)
+	}
+
+	tenantIDs, err := uc.tenantRepo.GetIDsWithPendingMessages(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to get tenants with pending messages", zap.Error(err))
+		return nil, err
+	}
+	if len(tenantIDs) == 0 {
+		return nil, nil
+	}
+
+	start := int(uc.tenantCursor.Add(1)-1) % len(tenantIDs)
+	queues := make([][]*entities.Message, 0, len(tenantIDs))
+	for i := range tenantIDs {
+		tenantID := tenantIDs[(start+i)%len(tenantIDs)]
+
+		pending, err := uc.GetPendingMessages(entities.ContextWithTenant(ctx, tenantID), batchSize)
+		if err != nil {
+			uc.logger.Warn("Skipping tenant in this batch", zap.String("tenant_id", tenantID.String()), zap.Error(err))
+			continue
+		}
+		queues = append(queues, pending)
+	}
+
+	messages := make([]*entities.Message, 0, batchSize)
+	for len(messages) < batchSize {
+		picked := false
+		for i := range queues {
+			if len(messages) == batchSize {
+				break
+			}
+			if len(queues[i]) == 0 {
+				continue
+			}
+			messages = append(messages, queues[i][0])
+			queues[i] = queues[i][1:]
+			picked = true
+		}
+		if !picked {
+			break
+		}
+	}
+
+	return messages, nil
+}
+
 func (uc *messageUseCaseImpl) GetMessageStats(ctx context.Context) (*usecases.MessageStats, error) {
 	pendingCount, err := uc.messageRepo.CountByStatus(ctx, entities.MessageStatusPending)
 	if err != nil {
@@ -206,10 +582,292 @@ func (uc *messageUseCaseImpl) GetMessageStats(ctx context.Context) (*usecases.Me
 
 	totalCount := pendingCount + sentCount + failedCount
 
-	return &usecases.MessageStats{
+	stats := &usecases.MessageStats{
 		TotalMessages:   totalCount,
 		PendingMessages: pendingCount,
 		SentMessages:    sentCount,
 		FailedMessages:  failedCount,
-	}, nil
+		FailuresByCode:  make(map[string]int64),
+	}
+
+	failures, err := uc.messageRepo.CountFailuresByCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for code, count := range failures {
+		stats.FailuresByCode[string(code)] = count
+	}
+
+	costs, err := uc.messageRepo.GetCostReport(ctx, entities.CostReportFilter{})
+	if err != nil {
+		return nil, err
+	}
+	if len(costs) > 0 {
+		stats.EstimatedCost = costs[0].EstimatedCost
+		stats.ActualCost = costs[0].ActualCost
+	}
+	if uc.config != nil {
+		stats.Currency = uc.config.Pricing.Currency
+	}
+
+	return stats, nil
+}
+
+// estimateCost mesajin guncel icerik ve numarasina gore tahmini maliyetini yazar; fiyat bulunamazsa
+// onceki tahmin silinir ki duzenlenen mesajda eski numaranin fiyati kalmasin
+func (uc *messageUseCaseImpl) estimateCost(message *entities.Message) {
+	if uc.pricing == nil {
+		return
+	}
+
+	cost, ok := uc.pricing.Cost(uc.providerName(), message)
+	if !ok {
+		message.CostPrefix = nil
+		message.EstimatedCost = nil
+		return
+	}
+	message.SetEstimatedCost(cost)
+}
+
+// retryPolicy config yoksa varsayilan limitlerle calisir
+func (uc *messageUseCaseImpl) retryPolicy() (int, time.Duration) {
+	maxAttempts, backoff := defaultSendMaxAttempts, defaultSendRetryBackoff
+	if uc.config != nil {
+		if uc.config.Scheduler.MaxAttempts > 0 {
+			maxAttempts = uc.config.Scheduler.MaxAttempts
+		}
+		if uc.config.Scheduler.RetryBackoff > 0 {
+			backoff = uc.config.Scheduler.RetryBackoff
+		}
+	}
+	return maxAttempts, backoff
+}
+
+// retryDelay her denemede iki katina cikar, maxSendRetryBackoff ile sinirlidir
+func retryDelay(backoff time.Duration, attempt int) time.Duration {
+	delay := backoff
+	for i := 1; i < attempt && delay < maxSendRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxSendRetryBackoff {
+		delay = maxSendRetryBackoff
+	}
+	return delay
+}
+
+// discardLinks kaydedilemeyen mesaj icin olusturulan kisa linkleri siler, yetim link kalmasin
+func (uc *messageUseCaseImpl) discardLinks(ctx context.Context, message *entities.Message, codes []string) {
+	if uc.linkShortener == nil || len(codes) == 0 {
+		return
+	}
+	if err := uc.linkShortener.DiscardLinks(ctx, codes); err != nil {
+		uc.logger.Warn("Failed to discard short links of unsaved message",
+			zap.String("message_id", message.ID.String()),
+			zap.Strings("codes", codes),
+			zap.Error(err))
+	}
+}
+
+// reserveQuota rezervasyonu olmayan mesaj icin kotadan pay ayirir ve mesaja yazar; yeni ayrilan payi doner
+func (uc *messageUseCaseImpl) reserveQuota(ctx context.Context, message *entities.Message) (*entities.QuotaReservation, error) {
+	if uc.quota == nil || message.QuotaReservation() != nil {
+		return nil, nil
+	}
+
+	reservation, err := uc.quota.ReserveQuota(ctx, message.TenantID, 1, int64(message.Segments()))
+	if err != nil {
+		return nil, err
+	}
+	message.SetQuotaReservation(reservation)
+	return reservation, nil
+}
+
+// releaseQuota kaydedilemeyen, iptal edilen ya da gonderilemeyen mesajin payini geri verir
+func (uc *messageUseCaseImpl) releaseQuota(ctx context.Context, message *entities.Message, reservation *entities.QuotaReservation) {
+	if uc.quota == nil || reservation == nil {
+		return
+	}
+	if err := uc.quota.ReleaseQuota(ctx, message.TenantID, *reservation); err != nil {
+		uc.logger.Warn("Failed to release quota reservation",
+			zap.String("message_id", message.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// providerName tahmini maliyet icin mesaji gonderecek provider'in adi
+func (uc *messageUseCaseImpl) providerName() string {
+	if uc.provider != nil {
+		return uc.provider.Name()
+	}
+	if uc.config == nil {
+		return ""
+	}
+	return uc.config.External.Provider
+}
+
+func (uc *messageUseCaseImpl) CancelMessage(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
+	message, err := uc.messageRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *message
+	if err := message.Cancel(); err != nil {
+		return nil, err
+	}
+	reservation := message.QuotaReservation()
+	message.SetQuotaReservation(nil)
+
+	if err := uc.messageRepo.Update(ctx, message); err != nil {
+		uc.logger.Error("Failed to cancel message", zap.String("message_id", id.String()), zap.Error(err))
+		return nil, err
+	}
+	uc.releaseQuota(ctx, message, reservation)
+
+	uc.recordAudit(ctx, entities.AuditActionMessageCancel, &before, message)
+	uc.logger.Info("Message cancelled", zap.String("message_id", id.String()))
+	return message, nil
+}
+
+// UpdateMessage pending mesajin icerigini, numarasini, e-posta adresi ve konusunu ya da webhook URL'ini degistirir. Yeni icerik de content policy ve
+// link kisaltmadan gecer.
+func (uc *messageUseCaseImpl) UpdateMessage(ctx context.Context, id uuid.UUID, input usecases.UpdateMessageInput) (*entities.Message, error) {
+	message, err := uc.messageRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !message.IsPending() {
+		return nil, entities.ErrMessageNotPending
+	}
+
+	before := *message
+	if input.Content != nil {
+		message.Content = *input.Content
+	}
+	if input.PhoneNumber != nil {
+		message.PhoneNumber = *input.PhoneNumber
+	}
+	if input.Email != nil {
+		message.Email = input.Email
+	}
+	if input.Subject != nil {
+		message.Subject = input.Subject
+	}
+	if input.WebhookURL != nil {
+		message.WebhookURL = input.WebhookURL
+	}
+	message.UpdatedAt = time.Now()
+
+	if err := message.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.checkWebhookHost(message); err != nil {
+		return nil, err
+	}
+
+	if uc.contentPolicy != nil {
+		if err := uc.contentPolicy.Evaluate(message); err != nil {
+			uc.logger.Info("Message edit rejected by content policy", zap.String("message_id", id.String()), zap.Error(err))
+			return nil, err
+		}
+
+		if err := message.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var linkCodes []string
+	if uc.linkShortener != nil && message.Content != before.Content {
+		if linkCodes, err = uc.linkShortener.ShortenLinks(ctx, message); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.estimateCost(message)
+
+	// segment sayisi degistiyse yeni pay ayrilir, eskisi ancak mesaj kaydedildikten sonra geri verilir
+	var replaced, reservation *entities.QuotaReservation
+	if previous := message.QuotaReservation(); previous != nil && previous.Segments != int64(message.Segments()) {
+		message.SetQuotaReservation(nil)
+		if reservation, err = uc.reserveQuota(ctx, message); err != nil {
+			uc.logger.Info("Message edit rejected by tenant quota", zap.String("message_id", id.String()), zap.Error(err))
+			uc.discardLinks(ctx, message, linkCodes)
+			return nil, err
+		}
+		replaced = previous
+	}
+
+	if err := uc.messageRepo.Update(ctx, message); err != nil {
+		uc.logger.Error("Failed to update message", zap.String("message_id", id.String()), zap.Error(err))
+		uc.discardLinks(ctx, message, linkCodes)
+		uc.releaseQuota(ctx, message, reservation)
+		return nil, err
+	}
+	uc.releaseQuota(ctx, message, replaced)
+
+	uc.recordAudit(ctx, entities.AuditActionMessageEdit, &before, message)
+	uc.logger.Info("Message edited", zap.String("message_id", id.String()))
+	return message, nil
+}
+
+func (uc *messageUseCaseImpl) RequeueMessage(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
+	message, err := uc.messageRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *message
+	if err := message.Requeue(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.messageRepo.Update(ctx, message); err != nil {
+		uc.logger.Error("Failed to requeue message", zap.String("message_id", id.String()), zap.Error(err))
+		return nil, err
+	}
+
+	uc.recordAudit(ctx, entities.AuditActionMessageRequeue, &before, message)
+	uc.logger.Info("Message requeued", zap.String("message_id", id.String()))
+	return message, nil
+}
+
+func (uc *messageUseCaseImpl) RecordDeliveryReceipt(ctx context.Context, receipt entities.DeliveryReceipt) error {
+	message, err := uc.messageRepo.GetByExternalID(ctx, receipt.Provider, receipt.ExternalMessageID)
+	if err != nil {
+		if !errors.Is(err, entities.ErrMessageNotFound) {
+			uc.logger.Error("Failed to find message for delivery receipt",
+				zap.String("provider", receipt.Provider),
+				zap.String("external_message_id", receipt.ExternalMessageID),
+				zap.Error(err))
+		}
+		return err
+	}
+
+	message.ApplyDeliveryReceipt(receipt)
+	if err := uc.messageRepo.UpdateDelivery(entities.ContextWithTenant(ctx, message.TenantID), message); err != nil {
+		uc.logger.Error("Failed to store delivery receipt", zap.String("message_id", message.ID.String()), zap.Error(err))
+		return err
+	}
+
+	uc.logger.Info("Delivery receipt recorded",
+		zap.String("message_id", message.ID.String()),
+		zap.String("provider", receipt.Provider),
+		zap.String("delivery_status", string(receipt.Status)))
+	return nil
+}
+
+func (uc *messageUseCaseImpl) recordAudit(ctx context.Context, action string, before, after *entities.Message) {
+	if uc.audit == nil {
+		return
+	}
+
+	uc.audit.Record(ctx, usecases.RecordAuditInput{
+		Action:     action,
+		TargetType: entities.AuditTargetMessage,
+		TargetID:   after.ID.String(),
+		Before:     before,
+		After:      after,
+	})
 }
@@ -2,7 +2,11 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,7 +14,8 @@ import (
 	"go.uber.org/zap"
 
 	"message-sending-service/internal/domain/entities"
-	"message-sending-service/internal/infrastructure/external"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+	"message-sending-service/internal/infrastructure/config"
 )
 
 type mockMessageRepository struct {
@@ -51,6 +56,21 @@ func (m *mockMessageRepository) Update(ctx context.Context, message *entities.Me
 	return nil
 }
 
+// UpdateDelivery gercek repository gibi kayitli mesajin sadece teslim alanlarini degistirir
+func (m *mockMessageRepository) UpdateDelivery(ctx context.Context, message *entities.Message) error {
+	if m.shouldFail {
+		return errors.New("database error")
+	}
+	stored, ok := m.messages[message.ID]
+	if !ok {
+		return entities.ErrMessageNotFound
+	}
+	stored.DeliveryStatus = message.DeliveryStatus
+	stored.DeliveryError = message.DeliveryError
+	stored.DeliveryReportedAt = message.DeliveryReportedAt
+	return nil
+}
+
 func (m *mockMessageRepository) GetPendingMessages(ctx context.Context, limit int) ([]*entities.Message, error) {
 	if m.shouldFail {
 		return nil, errors.New("database error")
@@ -82,6 +102,25 @@ func (m *mockMessageRepository) GetSentMessages(ctx context.Context, offset, lim
 	return sent, nil
 }
 
+func (m *mockMessageRepository) CountFailuresByCode(ctx context.Context) (map[entities.ErrorCode]int64, error) {
+	if m.shouldFail {
+		return nil, errors.New("database error")
+	}
+
+	counts := make(map[entities.ErrorCode]int64)
+	for _, msg := range m.messages {
+		if msg.Status != entities.MessageStatusFailed {
+			continue
+		}
+		code := entities.ErrorCodeUnknown
+		if msg.ErrorCode != nil {
+			code = *msg.ErrorCode
+		}
+		counts[code]++
+	}
+	return counts, nil
+}
+
 func (m *mockMessageRepository) CountByStatus(ctx context.Context, status entities.MessageStatus) (int64, error) {
 	if m.shouldFail {
 		return 0, errors.New("database error")
@@ -96,6 +135,20 @@ func (m *mockMessageRepository) CountByStatus(ctx context.Context, status entiti
 	return count, nil
 }
 
+func (m *mockMessageRepository) GetByExternalID(ctx context.Context, provider, externalMessageID string) (*entities.Message, error) {
+	tenantID, scoped := entities.TenantFromContext(ctx)
+	for _, message := range m.messages {
+		if scoped && message.TenantID != tenantID {
+			continue
+		}
+		if message.Provider != nil && *message.Provider == provider &&
+			message.ExternalMessageID != nil && *message.ExternalMessageID == externalMessageID {
+			return message, nil
+		}
+	}
+	return nil, entities.ErrMessageNotFound
+}
+
 func (m *mockMessageRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if m.shouldFail {
 		return errors.New("database error")
@@ -116,9 +169,54 @@ func (m *mockMessageRepository) GetAll(ctx context.Context, offset, limit int) (
 	return all, nil
 }
 
+// GetCostReport sadece kampanya ya da tek toplam gruplamasini destekler
+func (m *mockMessageRepository) GetCostReport(ctx context.Context, filter entities.CostReportFilter) ([]*entities.CostReportRow, error) {
+	if m.shouldFail {
+		return nil, errors.New("database error")
+	}
+
+	rows := map[string]*entities.CostReportRow{}
+	var keys []string
+	for _, msg := range m.messages {
+		if msg.Status == entities.MessageStatusCancelled {
+			continue
+		}
+
+		key := ""
+		if filter.GroupBy == entities.CostGroupCampaign && msg.Campaign != nil {
+			key = *msg.Campaign
+		}
+		row, ok := rows[key]
+		if !ok {
+			row = &entities.CostReportRow{Key: key}
+			rows[key] = row
+			keys = append(keys, key)
+		}
+
+		row.Messages++
+		if msg.EstimatedCost != nil && (msg.IsPending() || msg.IsSent()) {
+			row.EstimatedCost += *msg.EstimatedCost
+		}
+		if msg.IsSent() {
+			row.SentMessages++
+			if msg.ActualCost != nil {
+				row.ActualCost += *msg.ActualCost
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	report := make([]*entities.CostReportRow, len(keys))
+	for i, key := range keys {
+		report[i] = rows[key]
+	}
+	return report, nil
+}
+
 type mockCacheRepository struct {
 	shouldFail   bool
 	sentMessages map[string]mockSentMessage
+	values       map[string]string
 }
 
 type mockSentMessage struct {
@@ -129,23 +227,47 @@ type mockSentMessage struct {
 func newMockCacheRepository() *mockCacheRepository {
 	return &mockCacheRepository{
 		sentMessages: make(map[string]mockSentMessage),
+		values:       make(map[string]string),
 	}
 }
 
 func (m *mockCacheRepository) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if m.shouldFail {
+		return errors.New("cache error")
+	}
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.values[key] = string(jsonValue)
 	return nil
 }
 
+func (m *mockCacheRepository) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	if m.shouldFail {
+		return false, errors.New("cache error")
+	}
+	if _, exists := m.values[key]; exists {
+		return false, nil
+	}
+	return true, m.Set(ctx, key, value, expiration)
+}
+
 func (m *mockCacheRepository) Get(ctx context.Context, key string) (string, error) {
-	return "", nil
+	if val, exists := m.values[key]; exists {
+		return val, nil
+	}
+	return "", errors.New("not found")
 }
 
 func (m *mockCacheRepository) Delete(ctx context.Context, key string) error {
+	delete(m.values, key)
 	return nil
 }
 
 func (m *mockCacheRepository) Exists(ctx context.Context, key string) (bool, error) {
-	return false, nil
+	_, exists := m.values[key]
+	return exists, nil
 }
 
 func (m *mockCacheRepository) SetMessageSent(ctx context.Context, messageID, externalMessageID string, sentAt time.Time) error {
@@ -177,45 +299,39 @@ func (m *mockCacheRepository) GetSchedulerStatus(ctx context.Context) (string, e
 	return "", nil
 }
 
-type mockAPIWrapper struct {
+type mockMessageProvider struct {
 	shouldFail  bool
-	response    *external.SendMessageResponse
+	result      *entities.SendResult
 	callCount   int
-	lastRequest mockAPIRequest
-	sendFunc    func(ctx context.Context, phoneNumber, message string) (*external.SendMessageResponse, error)
-}
-
-type mockAPIRequest struct {
-	PhoneNumber string
-	Message     string
+	lastRequest entities.SendRequest
+	sendFunc    func(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error)
 }
 
-func newMockAPIClient() *mockAPIClient {
-	return &mockAPIClient{
-		response: &external.SendMessageResponse{
-			MessageID: "ext_123",
-			Status:    "sent",
-			Message:   "Success",
+func newMockMessageProvider() *mockMessageProvider {
+	return &mockMessageProvider{
+		result: &entities.SendResult{
+			ExternalMessageID: "ext_123",
 		},
 	}
 }
 
-func (m *mockAPIClient) SendMessage(ctx context.Context, phoneNumber, message string) (*external.SendMessageResponse, error) {
+func (m *mockMessageProvider) Name() string {
+	return "mock"
+}
+
+func (m *mockMessageProvider) Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
 	m.callCount++
-	m.lastRequest = mockAPIRequest{
-		PhoneNumber: phoneNumber,
-		Message:     message,
-	}
+	m.lastRequest = request
 
 	if m.sendFunc != nil {
-		return m.sendFunc(ctx, phoneNumber, message)
+		return m.sendFunc(ctx, request)
 	}
 
 	if m.shouldFail {
 		return nil, errors.New("API error")
 	}
 
-	return m.response, nil
+	return m.result, nil
 }
 
 func TestMessageUseCase_CreateMessage(t *testing.T) {
@@ -272,13 +388,13 @@ func TestMessageUseCase_CreateMessage(t *testing.T) {
 			mockRepo := newMockMessageRepository()
 			mockRepo.shouldFail = tt.repoFail
 			mockCache := newMockCacheRepository()
-			mockAPI := newMockAPIClient()
+			mockAPI := newMockMessageProvider()
 			logger, _ := zap.NewNop(), zap.NewNop()
 
-			useCase := NewMessageUseCase(mockRepo, mockCache, (*external.MessageAPIClient)(mockAPI), logger)
+			useCase := NewMessageUseCase(mockRepo, nil, mockCache, mockAPI, nil, nil, nil, nil, nil, nil, logger)
 
 			ctx := context.Background()
-			result, err := useCase.CreateMessage(ctx, tt.content, tt.phoneNumber)
+			result, err := useCase.CreateMessage(ctx, domainUsecases.CreateMessageInput{Content: tt.content, PhoneNumber: tt.phoneNumber})
 
 			if tt.wantErr {
 				if err == nil {
@@ -315,6 +431,130 @@ func TestMessageUseCase_CreateMessage(t *testing.T) {
 	}
 }
 
+func TestMessageUseCase_CreateMessage_Duplicate(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		wantErr   error
+		wantCount int
+	}{
+		{
+			name:      "reject mode returns duplicate error",
+			mode:      config.DedupModeReject,
+			wantErr:   entities.ErrDuplicateMessage,
+			wantCount: 1,
+		},
+		{
+			name:      "link mode returns original message",
+			mode:      config.DedupModeLink,
+			wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := newMockMessageRepository()
+			mockCache := newMockCacheRepository()
+			cfg := &config.Config{
+				Dedup: config.DedupConfig{
+					Enabled: true,
+					Window:  time.Minute,
+					Mode:    tt.mode,
+				},
+			}
+			logger := zap.NewNop()
+
+			useCase := NewMessageUseCase(mockRepo, nil, mockCache, nil, nil, nil, nil, nil, nil, cfg, logger)
+
+			ctx := context.Background()
+			original, err := useCase.CreateMessage(ctx, domainUsecases.CreateMessageInput{Content: "Test message", PhoneNumber: "+1234567890"})
+			if err != nil {
+				t.Fatalf("Expected no error on first create but got: %v", err)
+			}
+
+			duplicate, err := useCase.CreateMessage(ctx, domainUsecases.CreateMessageInput{Content: "  test   MESSAGE", PhoneNumber: "+1 234 567 890"})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Expected no error but got: %v", err)
+				}
+				if duplicate.ID != original.ID {
+					t.Errorf("Expected duplicate to be linked to %v, got %v", original.ID, duplicate.ID)
+				}
+			}
+
+			if len(mockRepo.messages) != tt.wantCount {
+				t.Errorf("Expected %d stored messages, got %d", tt.wantCount, len(mockRepo.messages))
+			}
+		})
+	}
+}
+
+func TestMessageUseCase_CreateMessage_FingerprintReservation(t *testing.T) {
+	cfg := &config.Config{Dedup: config.DedupConfig{Enabled: true, Window: time.Minute, Mode: config.DedupModeLink}}
+	input := domainUsecases.CreateMessageInput{Content: "Test message", PhoneNumber: "+1234567890"}
+	key := dedupKeyPrefix + (&entities.Message{Content: input.Content, PhoneNumber: input.PhoneNumber}).Fingerprint()
+
+	t.Run("in-flight original is a duplicate in link mode", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		mockCache := newMockCacheRepository()
+		useCase := NewMessageUseCase(mockRepo, nil, mockCache, nil, nil, nil, nil, nil, nil, cfg, zap.NewNop())
+
+		if _, err := mockCache.SetNX(context.Background(), key, dedupReservation, time.Minute); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if _, err := useCase.CreateMessage(context.Background(), input); !errors.Is(err, entities.ErrDuplicateMessage) {
+			t.Errorf("Expected ErrDuplicateMessage while the original is being created, got %v", err)
+		}
+		if len(mockRepo.messages) != 0 {
+			t.Errorf("Expected no message to be stored, got %d", len(mockRepo.messages))
+		}
+	})
+
+	t.Run("failed create releases the reservation", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		mockRepo.shouldFail = true
+		mockCache := newMockCacheRepository()
+		useCase := NewMessageUseCase(mockRepo, nil, mockCache, nil, nil, nil, nil, nil, nil, cfg, zap.NewNop())
+
+		if _, err := useCase.CreateMessage(context.Background(), input); err == nil {
+			t.Fatal("Expected error but got none")
+		}
+		if _, exists := mockCache.values[key]; exists {
+			t.Error("Expected the fingerprint reservation to be released")
+		}
+
+		mockRepo.shouldFail = false
+		message, err := useCase.CreateMessage(context.Background(), input)
+		if err != nil {
+			t.Fatalf("Expected the retry to succeed, got %v", err)
+		}
+		if want, _ := json.Marshal(message.ID); mockCache.values[key] != string(want) {
+			t.Errorf("Expected the fingerprint to point at %s, got %s", message.ID, mockCache.values[key])
+		}
+	})
+
+	t.Run("deleted original does not block a new message", func(t *testing.T) {
+		mockRepo := newMockMessageRepository()
+		mockCache := newMockCacheRepository()
+		useCase := NewMessageUseCase(mockRepo, nil, mockCache, nil, nil, nil, nil, nil, nil, cfg, zap.NewNop())
+
+		if err := mockCache.Set(context.Background(), key, uuid.New(), time.Minute); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		message, err := useCase.CreateMessage(context.Background(), input)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if want, _ := json.Marshal(message.ID); mockCache.values[key] != string(want) {
+			t.Errorf("Expected the fingerprint to point at %s, got %s", message.ID, mockCache.values[key])
+		}
+	})
+}
+
 func TestMessageUseCase_SendMessage(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -338,12 +578,26 @@ func TestMessageUseCase_SendMessage(t *testing.T) {
 			expectedStatus: entities.MessageStatusSent,
 		},
 		{
-			name: "API failure",
+			name: "API failure is retried later",
+			message: &entities.Message{
+				ID:          uuid.New(),
+				Content:     "Test message",
+				PhoneNumber: "+1234567890",
+				Status:      entities.MessageStatusPending,
+			},
+			apiShouldFail:  true,
+			cacheFail:      false,
+			wantErr:        true,
+			expectedStatus: entities.MessageStatusPending,
+		},
+		{
+			name: "API failure on the last attempt",
 			message: &entities.Message{
 				ID:          uuid.New(),
 				Content:     "Test message",
 				PhoneNumber: "+1234567890",
 				Status:      entities.MessageStatusPending,
+				Attempts:    defaultSendMaxAttempts - 1,
 			},
 			apiShouldFail:  true,
 			cacheFail:      false,
@@ -370,11 +624,11 @@ func TestMessageUseCase_SendMessage(t *testing.T) {
 			mockRepo := newMockMessageRepository()
 			mockCache := newMockCacheRepository()
 			mockCache.shouldFail = tt.cacheFail
-			mockAPI := newMockAPIClient()
+			mockAPI := newMockMessageProvider()
 			mockAPI.shouldFail = tt.apiShouldFail
 			logger, _ := zap.NewNop(), zap.NewNop()
 
-			useCase := NewMessageUseCase(mockRepo, mockCache, (*external.MessageAPIClient)(mockAPI), logger)
+			useCase := NewMessageUseCase(mockRepo, nil, mockCache, mockAPI, nil, nil, nil, nil, nil, nil, logger)
 
 			ctx := context.Background()
 			err := useCase.SendMessage(ctx, tt.message)
@@ -390,6 +644,20 @@ func TestMessageUseCase_SendMessage(t *testing.T) {
 				t.Errorf("Expected status %v, got %v", tt.expectedStatus, tt.message.Status)
 			}
 
+			if tt.message.Provider == nil || *tt.message.Provider != "mock" {
+				t.Errorf("Expected provider mock to be recorded, got %v", tt.message.Provider)
+			}
+			if mockAPI.lastRequest.MessageID != tt.message.ID || mockAPI.lastRequest.PhoneNumber != tt.message.PhoneNumber {
+				t.Errorf("Unexpected send request %+v", mockAPI.lastRequest)
+			}
+
+			if tt.apiShouldFail && tt.message.ErrorCode == nil {
+				t.Error("Expected ErrorCode to be set")
+			}
+			if tt.apiShouldFail && (tt.message.NextAttemptAt != nil) != (tt.expectedStatus == entities.MessageStatusPending) {
+				t.Errorf("Expected NextAttemptAt only while a retry is pending, got %v", tt.message.NextAttemptAt)
+			}
+
 			if tt.expectedStatus == entities.MessageStatusSent {
 				if tt.message.SentAt == nil {
 					t.Error("Expected SentAt to be set")
@@ -402,6 +670,311 @@ func TestMessageUseCase_SendMessage(t *testing.T) {
 	}
 }
 
+func TestMessageUseCase_SendMessage_CircuitOpen(t *testing.T) {
+	mockRepo := newMockMessageRepository()
+	mockAPI := newMockMessageProvider()
+	mockAPI.sendFunc = func(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+		return nil, &entities.ProviderSendError{Provider: "primary", Err: entities.ErrCircuitOpen}
+	}
+	useCase := NewMessageUseCase(mockRepo, nil, nil, mockAPI, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	message := &entities.Message{
+		ID:          uuid.New(),
+		Content:     "Test message",
+		PhoneNumber: "+1234567890",
+		Status:      entities.MessageStatusPending,
+	}
+	mockRepo.messages[message.ID] = message
+
+	err := useCase.SendMessage(context.Background(), message)
+	if !errors.Is(err, entities.ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if !message.IsPending() || message.ErrorMessage != nil || message.Provider != nil {
+		t.Errorf("Expected message to stay pending untouched, got %+v", message)
+	}
+}
+
+func TestMessageUseCase_SendMessage_ErrorCode(t *testing.T) {
+	mockRepo := newMockMessageRepository()
+	mockAPI := newMockMessageProvider()
+	mockAPI.sendFunc = func(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+		err := entities.NewProviderError(entities.ErrorCodeBlockedContent, fmt.Errorf("%w: carrier filter", entities.ErrProviderRejected))
+		return nil, &entities.ProviderSendError{Provider: "primary", Err: err}
+	}
+	useCase := NewMessageUseCase(mockRepo, nil, nil, mockAPI, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	message := &entities.Message{
+		ID:          uuid.New(),
+		Content:     "Test message",
+		PhoneNumber: "+1234567890",
+		Status:      entities.MessageStatusPending,
+	}
+	mockRepo.messages[message.ID] = message
+
+	if err := useCase.SendMessage(context.Background(), message); err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	stored := mockRepo.messages[message.ID]
+	if stored.Status != entities.MessageStatusFailed || stored.ErrorCode == nil || *stored.ErrorCode != entities.ErrorCodeBlockedContent {
+		t.Errorf("Expected failed message with blocked_content code, got %+v", stored)
+	}
+
+	if err := stored.Requeue(); err != nil || stored.ErrorCode != nil {
+		t.Errorf("Expected requeue to clear the error code, got %v %v", err, stored.ErrorCode)
+	}
+}
+
+func TestMessageUseCase_SendMessage_RetryBackoff(t *testing.T) {
+	mockRepo := newMockMessageRepository()
+	mockAPI := newMockMessageProvider()
+	mockAPI.sendFunc = func(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+		return nil, entities.NewProviderError(entities.ErrorCodeTimeout, errors.New("provider timed out"))
+	}
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxAttempts: 3, RetryBackoff: time.Minute}}
+	useCase := NewMessageUseCase(mockRepo, nil, nil, mockAPI, nil, nil, nil, nil, nil, cfg, zap.NewNop())
+
+	message := &entities.Message{
+		ID:          uuid.New(),
+		Content:     "Test message",
+		PhoneNumber: "+1234567890",
+		Status:      entities.MessageStatusPending,
+	}
+	mockRepo.messages[message.ID] = message
+
+	for attempt, wantDelay := range []time.Duration{time.Minute, 2 * time.Minute} {
+		before := time.Now()
+		if err := useCase.SendMessage(context.Background(), message); err == nil {
+			t.Fatal("Expected error but got none")
+		}
+		if !message.IsPending() || message.Attempts != attempt+1 || message.NextAttemptAt == nil {
+			t.Fatalf("Expected attempt %d to stay pending with a retry time, got %+v", attempt+1, message)
+		}
+		if delay := message.NextAttemptAt.Sub(before); delay < wantDelay || delay > wantDelay+time.Second {
+			t.Errorf("Expected a backoff of %s, got %s", wantDelay, delay)
+		}
+		if message.ErrorCode == nil || *message.ErrorCode != entities.ErrorCodeTimeout {
+			t.Errorf("Expected the last error code to be kept, got %v", message.ErrorCode)
+		}
+	}
+
+	if err := useCase.SendMessage(context.Background(), message); err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if message.Status != entities.MessageStatusFailed || message.Attempts != 3 || message.NextAttemptAt != nil {
+		t.Errorf("Expected the message to fail after 3 attempts, got %+v", message)
+	}
+
+	if err := message.Requeue(); err != nil || message.Attempts != 0 {
+		t.Errorf("Expected requeue to reset attempts, got %v %d", err, message.Attempts)
+	}
+}
+
+func TestMessageUseCase_SendMessage_ThrottledWebhook(t *testing.T) {
+	mockRepo := newMockMessageRepository()
+	mockAPI := newMockMessageProvider()
+	retryAfter := time.Duration(0)
+	mockAPI.sendFunc = func(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+		throttled := &entities.ProviderThrottledError{RetryAfter: retryAfter}
+		return nil, &entities.ProviderSendError{Provider: "webhook", Err: fmt.Errorf("webhook responded with status 503: %w", throttled)}
+	}
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{MaxAttempts: 3, RetryBackoff: time.Minute}}
+	useCase := NewMessageUseCase(mockRepo, nil, nil, mockAPI, nil, nil, nil, nil, nil, cfg, zap.NewNop())
+
+	webhookURL := "https://hooks.example.com/notify"
+	message := &entities.Message{
+		ID:         uuid.New(),
+		Content:    `{"event":"test"}`,
+		Channel:    entities.MessageChannelWebhook,
+		WebhookURL: &webhookURL,
+		Status:     entities.MessageStatusPending,
+	}
+	mockRepo.messages[message.ID] = message
+
+	// webhook'un Retry-After'i varsa o kadar, yoksa backoff kadar beklenir
+	for attempt, step := range []struct {
+		retryAfter time.Duration
+		wantDelay  time.Duration
+	}{
+		{retryAfter: 0, wantDelay: time.Minute},
+		{retryAfter: 10 * time.Minute, wantDelay: 10 * time.Minute},
+	} {
+		retryAfter = step.retryAfter
+		before := time.Now()
+		if err := useCase.SendMessage(context.Background(), message); err == nil {
+			t.Fatal("Expected error but got none")
+		}
+		if !message.IsPending() || message.Attempts != attempt+1 || message.NextAttemptAt == nil {
+			t.Fatalf("Expected attempt %d to stay pending with a retry time, got %+v", attempt+1, message)
+		}
+		if delay := message.NextAttemptAt.Sub(before); delay < step.wantDelay || delay > step.wantDelay+time.Second {
+			t.Errorf("Expected to wait %s, got %s", step.wantDelay, delay)
+		}
+		if message.ErrorCode == nil || *message.ErrorCode != entities.ErrorCodeThrottled {
+			t.Errorf("Expected throttled error code, got %v", message.ErrorCode)
+		}
+	}
+
+	if err := useCase.SendMessage(context.Background(), message); err == nil {
+		t.Fatal("Expected error but got none")
+	}
+	if message.Status != entities.MessageStatusFailed || message.Attempts != 3 || message.NextAttemptAt != nil {
+		t.Errorf("Expected the webhook to fail after 3 throttled attempts, got %+v", message)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{4, 4 * time.Minute},
+		{20, maxSendRetryBackoff},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(30*time.Second, tt.attempt); got != tt.want {
+			t.Errorf("retryDelay(30s, %d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestMessageUseCase_RecordDeliveryReceipt(t *testing.T) {
+	mockRepo := newMockMessageRepository()
+	useCase := NewMessageUseCase(mockRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	provider, externalID := "smsc", "smsc-1"
+	message := &entities.Message{
+		ID:                uuid.New(),
+		PhoneNumber:       "+1234567890",
+		Status:            entities.MessageStatusSent,
+		Provider:          &provider,
+		ExternalMessageID: &externalID,
+	}
+	mockRepo.messages[message.ID] = message
+
+	reportedAt := time.Date(2024, 1, 2, 15, 5, 0, 0, time.UTC)
+	err := useCase.RecordDeliveryReceipt(context.Background(), entities.DeliveryReceipt{
+		Provider:          "smsc",
+		ExternalMessageID: "smsc-1",
+		Status:            entities.DeliveryStatusUndelivered,
+		ErrorCode:         "069",
+		ReportedAt:        reportedAt,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if message.DeliveryStatus == nil || *message.DeliveryStatus != entities.DeliveryStatusUndelivered {
+		t.Errorf("Expected delivery status undelivered, got %v", message.DeliveryStatus)
+	}
+	if message.DeliveryError == nil || *message.DeliveryError != "069" || !message.DeliveryReportedAt.Equal(reportedAt) {
+		t.Errorf("Unexpected delivery details %v %v", message.DeliveryError, message.DeliveryReportedAt)
+	}
+	if message.Status != entities.MessageStatusSent {
+		t.Errorf("Expected send status to stay sent, got %s", message.Status)
+	}
+
+	// ayni id baska provider'dan gelirse eslesmez
+	err = useCase.RecordDeliveryReceipt(context.Background(), entities.DeliveryReceipt{Provider: "other", ExternalMessageID: "smsc-1"})
+	if !errors.Is(err, entities.ErrMessageNotFound) {
+		t.Errorf("Expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+// snapshotMessageRepository GetByExternalID'de kaydin kopyasini doner ve okumadan hemen sonra araya baska bir
+// yazma sokar; teslim raporunun bu yazmayi ezip ezmedigini gormek icin
+type snapshotMessageRepository struct {
+	*mockMessageRepository
+	afterRead func()
+}
+
+func (m *snapshotMessageRepository) GetByExternalID(ctx context.Context, provider, externalMessageID string) (*entities.Message, error) {
+	message, err := m.mockMessageRepository.GetByExternalID(ctx, provider, externalMessageID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := *message
+	m.afterRead()
+	return &snapshot, nil
+}
+
+func TestMessageUseCase_RecordDeliveryReceipt_KeepsConcurrentWrites(t *testing.T) {
+	repo := &snapshotMessageRepository{mockMessageRepository: newMockMessageRepository()}
+	useCase := NewMessageUseCase(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	provider, externalID := "fake", "fake-1"
+	stored := &entities.Message{
+		ID:                uuid.New(),
+		PhoneNumber:       "+1234567890",
+		Status:            entities.MessageStatusSent,
+		Attempts:          1,
+		Provider:          &provider,
+		ExternalMessageID: &externalID,
+	}
+	repo.messages[stored.ID] = stored
+
+	// rapor okunduktan sonra mesaj baska bir yerden guncellenir (ornegin gonderim sonucu, kota alanlari)
+	reservedOn := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	repo.afterRead = func() {
+		stored.Attempts = 2
+		stored.QuotaReservedOn = &reservedOn
+	}
+
+	err := useCase.RecordDeliveryReceipt(context.Background(), entities.DeliveryReceipt{
+		Provider:          provider,
+		ExternalMessageID: externalID,
+		Status:            entities.DeliveryStatusDelivered,
+		ReportedAt:        time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	current := repo.messages[stored.ID]
+	if current.DeliveryStatus == nil || *current.DeliveryStatus != entities.DeliveryStatusDelivered {
+		t.Errorf("Expected delivery status delivered, got %v", current.DeliveryStatus)
+	}
+	if current.Attempts != 2 || current.QuotaReservedOn == nil {
+		t.Errorf("Expected the receipt not to overwrite the concurrent write, got attempts %d, reservation %v", current.Attempts, current.QuotaReservedOn)
+	}
+}
+
+func TestMessageUseCase_RecordDeliveryReceipt_TenantScoped(t *testing.T) {
+	mockRepo := newMockMessageRepository()
+	useCase := NewMessageUseCase(mockRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+
+	tenantA, tenantB := uuid.New(), uuid.New()
+	provider, externalID := "fake", "fake-1"
+	message := &entities.Message{
+		ID:                uuid.New(),
+		TenantID:          tenantB,
+		PhoneNumber:       "+1234567890",
+		Status:            entities.MessageStatusSent,
+		Provider:          &provider,
+		ExternalMessageID: &externalID,
+	}
+	mockRepo.messages[message.ID] = message
+
+	// A tenant'inin receipts:write anahtari B'nin mesajini tahmin edilen id ile degistiremez
+	receipt := entities.DeliveryReceipt{Provider: provider, ExternalMessageID: externalID, Status: entities.DeliveryStatusRejected, ReportedAt: time.Now()}
+	err := useCase.RecordDeliveryReceipt(entities.ContextWithTenant(context.Background(), tenantA), receipt)
+	if !errors.Is(err, entities.ErrMessageNotFound) {
+		t.Errorf("Expected ErrMessageNotFound for another tenant's message, got %v", err)
+	}
+	if message.DeliveryStatus != nil {
+		t.Errorf("Expected another tenant's receipt not to touch the message, got %v", *message.DeliveryStatus)
+	}
+
+	receipt.Status = entities.DeliveryStatusDelivered
+	if err := useCase.RecordDeliveryReceipt(entities.ContextWithTenant(context.Background(), tenantB), receipt); err != nil {
+		t.Fatalf("Expected the owning tenant's receipt to be recorded, got %v", err)
+	}
+	if message.DeliveryStatus == nil || *message.DeliveryStatus != entities.DeliveryStatusDelivered {
+		t.Errorf("Expected delivery status delivered, got %v", message.DeliveryStatus)
+	}
+}
+
 func TestMessageUseCase_ProcessPendingMessages(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -444,7 +1017,7 @@ func TestMessageUseCase_ProcessPendingMessages(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := newMockMessageRepository()
 			mockCache := newMockCacheRepository()
-			mockAPI := newMockAPIClient()
+			mockAPI := newMockMessageProvider()
 			logger, _ := zap.NewNop(), zap.NewNop()
 
 			// Create pending messages
@@ -461,16 +1034,15 @@ func TestMessageUseCase_ProcessPendingMessages(t *testing.T) {
 			}
 
 			apiCallCount := 0
-			originalSendMessage := mockAPI.SendMessage
-			mockAPI.SendMessage = func(ctx context.Context, phoneNumber, message string) (*external.SendMessageResponse, error) {
+			mockAPI.sendFunc = func(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
 				apiCallCount++
 				if apiCallCount <= tt.apiFailCount {
 					return nil, errors.New("API error")
 				}
-				return originalSendMessage(ctx, phoneNumber, message)
+				return mockAPI.result, nil
 			}
 
-			useCase := NewMessageUseCase(mockRepo, mockCache, (*external.MessageAPIClient)(mockAPI), logger)
+			useCase := NewMessageUseCase(mockRepo, nil, mockCache, mockAPI, nil, nil, nil, nil, nil, nil, logger)
 
 			ctx := context.Background()
 			sentCount, err := useCase.ProcessPendingMessages(ctx, tt.batchSize)
@@ -494,13 +1066,15 @@ func TestMessageUseCase_ProcessPendingMessages(t *testing.T) {
 func TestMessageUseCase_GetMessageStats(t *testing.T) {
 	mockRepo := newMockMessageRepository()
 	mockCache := newMockCacheRepository()
-	mockAPI := newMockAPIClient()
+	mockAPI := newMockMessageProvider()
 	logger, _ := zap.NewNop(), zap.NewNop()
 
+	invalidRecipient := entities.ErrorCodeInvalidRecipient
 	messages := []*entities.Message{
 		{ID: uuid.New(), Status: entities.MessageStatusPending},
 		{ID: uuid.New(), Status: entities.MessageStatusPending},
 		{ID: uuid.New(), Status: entities.MessageStatusSent},
+		{ID: uuid.New(), Status: entities.MessageStatusFailed, ErrorCode: &invalidRecipient},
 		{ID: uuid.New(), Status: entities.MessageStatusFailed},
 	}
 
@@ -508,7 +1082,7 @@ func TestMessageUseCase_GetMessageStats(t *testing.T) {
 		mockRepo.Create(context.Background(), msg)
 	}
 
-	useCase := NewMessageUseCase(mockRepo, mockCache, mockAPI, logger)
+	useCase := NewMessageUseCase(mockRepo, nil, mockCache, mockAPI, nil, nil, nil, nil, nil, nil, logger)
 
 	ctx := context.Background()
 	stats, err := useCase.GetMessageStats(ctx)
@@ -517,8 +1091,8 @@ func TestMessageUseCase_GetMessageStats(t *testing.T) {
 		t.Errorf("Expected no error but got: %v", err)
 	}
 
-	if stats.TotalMessages != 4 {
-		t.Errorf("Expected 4 total messages, got %d", stats.TotalMessages)
+	if stats.TotalMessages != 5 {
+		t.Errorf("Expected 5 total messages, got %d", stats.TotalMessages)
 	}
 
 	if stats.PendingMessages != 2 {
@@ -529,8 +1103,12 @@ func TestMessageUseCase_GetMessageStats(t *testing.T) {
 		t.Errorf("Expected 1 sent message, got %d", stats.SentMessages)
 	}
 
-	if stats.FailedMessages != 1 {
-		t.Errorf("Expected 1 failed message, got %d", stats.FailedMessages)
+	if stats.FailedMessages != 2 {
+		t.Errorf("Expected 2 failed messages, got %d", stats.FailedMessages)
+	}
+
+	if stats.FailuresByCode["invalid_recipient"] != 1 || stats.FailuresByCode["unknown"] != 1 {
+		t.Errorf("Unexpected failure breakdown %v", stats.FailuresByCode)
 	}
 }
 
@@ -540,3 +1118,396 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+func TestMessageUseCase_CreateMessage_SenderID(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist []string
+		senderID  string
+		wantErr   error
+	}{
+		{
+			name:     "no sender id is allowed without allowlist",
+			senderID: "INSIDER",
+			wantErr:  entities.ErrSenderIDNotAllowed,
+		},
+		{
+			name:      "allowed sender id",
+			allowlist: []string{"INSIDER", "+905551112233"},
+			senderID:  "+905551112233",
+		},
+		{
+			name:      "sender id not in allowlist",
+			allowlist: []string{"INSIDER"},
+			senderID:  "OTHER",
+			wantErr:   entities.ErrSenderIDNotAllowed,
+		},
+		{
+			name:     "invalid sender id",
+			senderID: "NOT-A-SENDER",
+			wantErr:  entities.ErrInvalidSenderID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := newMockMessageRepository()
+			cfg := &config.Config{
+				Sender: config.SenderConfig{AllowedIDs: tt.allowlist},
+			}
+
+			useCase := NewMessageUseCase(mockRepo, nil, nil, nil, nil, nil, nil, nil, nil, cfg, zap.NewNop())
+
+			senderID := tt.senderID
+			message, err := useCase.CreateMessage(context.Background(), domainUsecases.CreateMessageInput{
+				Content:     "Test message",
+				PhoneNumber: "+1234567890",
+				SenderID:    &senderID,
+			})
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if message.SenderID == nil || *message.SenderID != tt.senderID {
+				t.Errorf("Expected sender id %q, got %v", tt.senderID, message.SenderID)
+			}
+		})
+	}
+}
+
+func TestMessageUseCase_CreateMessage_Email(t *testing.T) {
+	email, subject := "jane@example.com", "Order shipped"
+	input := domainUsecases.CreateMessageInput{
+		Content: "Your order has shipped.",
+		Channel: entities.MessageChannelEmail,
+		Email:   &email,
+		Subject: &subject,
+	}
+
+	disabled := NewMessageUseCase(newMockMessageRepository(), nil, nil, nil, nil, nil, nil, nil, nil, &config.Config{}, zap.NewNop())
+	if _, err := disabled.CreateMessage(context.Background(), input); !errors.Is(err, entities.ErrChannelNotConfigured) {
+		t.Errorf("Expected ErrChannelNotConfigured, got %v", err)
+	}
+
+	mockRepo := newMockMessageRepository()
+	cfg := &config.Config{Email: config.EmailConfig{Enabled: true}}
+	useCase := NewMessageUseCase(mockRepo, nil, nil, nil, nil, nil, nil, nil, nil, cfg, zap.NewNop())
+
+	message, err := useCase.CreateMessage(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if message.Channel != entities.MessageChannelEmail || message.Email == nil || *message.Email != email || message.Subject == nil || *message.Subject != subject {
+		t.Errorf("Unexpected email message %+v", message)
+	}
+
+	// email kanalinda telefon numarasi kabul edilmez
+	input.PhoneNumber = "+1234567890"
+	if _, err := useCase.CreateMessage(context.Background(), input); !errors.Is(err, entities.ErrChannelFieldMismatch) {
+		t.Errorf("Expected ErrChannelFieldMismatch, got %v", err)
+	}
+}
+
+func TestMessageUseCase_CreateMessage_Webhook(t *testing.T) {
+	webhookURL := "https://hooks.example.com/orders"
+	input := domainUsecases.CreateMessageInput{
+		Content:    `{"order": 42}`,
+		Channel:    entities.MessageChannelWebhook,
+		WebhookURL: &webhookURL,
+	}
+
+	disabled := NewMessageUseCase(newMockMessageRepository(), nil, nil, nil, nil, nil, nil, nil, nil, &config.Config{}, zap.NewNop())
+	if _, err := disabled.CreateMessage(context.Background(), input); !errors.Is(err, entities.ErrChannelNotConfigured) {
+		t.Errorf("Expected ErrChannelNotConfigured, got %v", err)
+	}
+
+	// allowlist bos ise hicbir host kabul edilmez
+	noHosts := NewMessageUseCase(newMockMessageRepository(), nil, nil, nil, nil, nil, nil, nil, nil, &config.Config{Webhook: config.WebhookConfig{Enabled: true}}, zap.NewNop())
+	if _, err := noHosts.CreateMessage(context.Background(), input); !errors.Is(err, entities.ErrWebhookHostNotAllowed) {
+		t.Errorf("Expected ErrWebhookHostNotAllowed without an allowlist, got %v", err)
+	}
+
+	cfg := &config.Config{Webhook: config.WebhookConfig{Enabled: true, AllowedHosts: []string{"HOOKS.example.com"}}}
+	useCase := NewMessageUseCase(newMockMessageRepository(), nil, nil, nil, nil, nil, nil, nil, nil, cfg, zap.NewNop())
+
+	message, err := useCase.CreateMessage(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if message.Channel != entities.MessageChannelWebhook || message.WebhookURL == nil || *message.WebhookURL != webhookURL {
+		t.Errorf("Unexpected webhook message %+v", message)
+	}
+
+	otherHost := "https://attacker.example.net/orders"
+	input.WebhookURL = &otherHost
+	if _, err := useCase.CreateMessage(context.Background(), input); !errors.Is(err, entities.ErrWebhookHostNotAllowed) {
+		t.Errorf("Expected ErrWebhookHostNotAllowed, got %v", err)
+	}
+}
+
+type mockTenantRepository struct {
+	pendingTenants []uuid.UUID
+}
+
+func (m *mockTenantRepository) Create(ctx context.Context, tenant *entities.Tenant) error {
+	return nil
+}
+
+func (m *mockTenantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Tenant, error) {
+	return &entities.Tenant{ID: id, Name: "tenant"}, nil
+}
+
+func (m *mockTenantRepository) GetAll(ctx context.Context, offset, limit int) ([]*entities.Tenant, error) {
+	return nil, nil
+}
+
+func (m *mockTenantRepository) GetIDsWithPendingMessages(ctx context.Context) ([]uuid.UUID, error) {
+	return m.pendingTenants, nil
+}
+
+// tenantScopedMessageRepository pending sorgusunu gercek repository gibi context'teki tenant ve next_attempt_at ile sinirlar
+type tenantScopedMessageRepository struct {
+	*mockMessageRepository
+}
+
+func (m *tenantScopedMessageRepository) GetPendingMessages(ctx context.Context, limit int) ([]*entities.Message, error) {
+	tenantID, ok := entities.TenantFromContext(ctx)
+	if !ok {
+		return nil, entities.ErrTenantRequired
+	}
+
+	var pending []*entities.Message
+	for _, msg := range m.messages {
+		due := msg.NextAttemptAt == nil || !msg.NextAttemptAt.After(time.Now())
+		if msg.TenantID == tenantID && msg.IsPending() && due && len(pending) < limit {
+			pending = append(pending, msg)
+		}
+	}
+	return pending, nil
+}
+
+func TestMessageUseCase_CollectPendingMessages_FairAcrossTenants(t *testing.T) {
+	busyTenant, quietTenant := uuid.New(), uuid.New()
+
+	repo := &tenantScopedMessageRepository{newMockMessageRepository()}
+	for i := 0; i < 10; i++ {
+		msg := &entities.Message{ID: uuid.New(), TenantID: busyTenant, Status: entities.MessageStatusPending}
+		repo.messages[msg.ID] = msg
+	}
+	quiet := &entities.Message{ID: uuid.New(), TenantID: quietTenant, Status: entities.MessageStatusPending}
+	repo.messages[quiet.ID] = quiet
+
+	tenantRepo := &mockTenantRepository{pendingTenants: []uuid.UUID{busyTenant, quietTenant}}
+	useCase := NewMessageUseCase(repo, tenantRepo, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop()).(*messageUseCaseImpl)
+
+	messages, err := useCase.collectPendingMessages(context.Background(), 4)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(messages) != 4 {
+		t.Fatalf("Expected 4 messages, got %d", len(messages))
+	}
+
+	counts := map[uuid.UUID]int{}
+	for _, msg := range messages {
+		counts[msg.TenantID]++
+	}
+	if counts[quietTenant] != 1 || counts[busyTenant] != 3 {
+		t.Errorf("Expected quiet tenant to get its message and busy tenant the rest, got %v", counts)
+	}
+
+	// batch tenant sayisindan kucukse ilk sira her calismada kayar
+	first, _ := useCase.collectPendingMessages(context.Background(), 1)
+	second, _ := useCase.collectPendingMessages(context.Background(), 1)
+	if len(first) != 1 || len(second) != 1 || first[0].TenantID == second[0].TenantID {
+		t.Error("Expected consecutive single-message batches to rotate between tenants")
+	}
+}
+
+func TestMessageUseCase_CollectPendingMessages_TenantContext(t *testing.T) {
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	repo := &tenantScopedMessageRepository{newMockMessageRepository()}
+	for _, tenantID := range []uuid.UUID{tenantA, tenantB} {
+		msg := &entities.Message{ID: uuid.New(), TenantID: tenantID, Status: entities.MessageStatusPending}
+		repo.messages[msg.ID] = msg
+	}
+
+	tenantRepo := &mockTenantRepository{pendingTenants: []uuid.UUID{tenantA, tenantB}}
+	useCase := NewMessageUseCase(repo, tenantRepo, nil, nil, nil, nil, nil, nil, nil, nil, zap.NewNop()).(*messageUseCaseImpl)
+
+	messages, err := useCase.collectPendingMessages(entities.ContextWithTenant(context.Background(), tenantA), 10)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(messages) != 1 || messages[0].TenantID != tenantA {
+		t.Errorf("Expected only tenant A messages, got %d messages", len(messages))
+	}
+}
+
+func TestMessageUseCase_ChangeMessage(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockMessageRepository()
+	audit := &recordingAuditUseCase{}
+	useCase := NewMessageUseCase(repo, nil, nil, nil, nil, nil, nil, nil, audit, nil, zap.NewNop())
+
+	pending := &entities.Message{ID: uuid.New(), Content: "Hello", PhoneNumber: "+905551112233", Status: entities.MessageStatusPending}
+	failed := &entities.Message{ID: uuid.New(), Content: "Hello", PhoneNumber: "+905551112233", Status: entities.MessageStatusFailed}
+	errorMessage := "timeout"
+	failed.ErrorMessage = &errorMessage
+	repo.messages[pending.ID] = pending
+	repo.messages[failed.ID] = failed
+
+	content := "Hello again"
+	edited, err := useCase.UpdateMessage(ctx, pending.ID, domainUsecases.UpdateMessageInput{Content: &content})
+	if err != nil || edited.Content != content {
+		t.Fatalf("expected edit to succeed, got %v", err)
+	}
+
+	empty := ""
+	if _, err := useCase.UpdateMessage(ctx, pending.ID, domainUsecases.UpdateMessageInput{Content: &empty}); !errors.Is(err, entities.ErrInvalidMessageContent) {
+		t.Errorf("expected ErrInvalidMessageContent, got %v", err)
+	}
+
+	if _, err := useCase.CancelMessage(ctx, pending.ID); err != nil {
+		t.Fatalf("expected cancel to succeed, got %v", err)
+	}
+	if _, err := useCase.CancelMessage(ctx, pending.ID); !errors.Is(err, entities.ErrMessageNotPending) {
+		t.Errorf("expected ErrMessageNotPending, got %v", err)
+	}
+
+	requeued, err := useCase.RequeueMessage(ctx, failed.ID)
+	if err != nil || !requeued.IsPending() || requeued.ErrorMessage != nil {
+		t.Fatalf("expected requeue to reset message, got %+v, %v", requeued, err)
+	}
+	if _, err := useCase.RequeueMessage(ctx, failed.ID); !errors.Is(err, entities.ErrMessageNotRequeueable) {
+		t.Errorf("expected ErrMessageNotRequeueable, got %v", err)
+	}
+
+	actions := make([]string, len(audit.records))
+	for i, record := range audit.records {
+		actions[i] = record.Action
+	}
+	expected := []string{entities.AuditActionMessageEdit, entities.AuditActionMessageCancel, entities.AuditActionMessageRequeue}
+	if strings.Join(actions, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected audit actions %v, got %v", expected, actions)
+	}
+
+	before := audit.records[1].Before.(*entities.Message)
+	after := audit.records[1].After.(*entities.Message)
+	if before.Status != entities.MessageStatusPending || after.Status != entities.MessageStatusCancelled {
+		t.Errorf("expected pending -> cancelled, got %s -> %s", before.Status, after.Status)
+	}
+}
+
+// stubQuotaEnforcer exceeded'daki tenant'lar icin QuotaExceededError doner, digerlerinin ayrilan paylarini sayar
+type stubQuotaEnforcer struct {
+	exceeded map[uuid.UUID]bool
+	resetsAt time.Time
+	reserved map[uuid.UUID]int64
+}
+
+func (s *stubQuotaEnforcer) ReserveQuota(ctx context.Context, tenantID uuid.UUID, messages, segments int64) (*entities.QuotaReservation, error) {
+	if s.exceeded[tenantID] {
+		return nil, &entities.QuotaExceededError{Period: entities.UsagePeriodDay, Metric: entities.QuotaMetricMessages, Limit: 10, Used: 10, Requested: messages, ResetsAt: s.resetsAt}
+	}
+	if s.reserved == nil {
+		s.reserved = map[uuid.UUID]int64{}
+	}
+	s.reserved[tenantID] += messages
+	return &entities.QuotaReservation{Day: entities.UsagePeriodStart(entities.UsagePeriodDay, time.Now()), Messages: messages, Segments: segments}, nil
+}
+
+func (s *stubQuotaEnforcer) ReleaseQuota(ctx context.Context, tenantID uuid.UUID, reservation entities.QuotaReservation) error {
+	s.reserved[tenantID] -= reservation.Messages
+	return nil
+}
+
+func TestMessageUseCase_Quota(t *testing.T) {
+	limitedTenant, freeTenant := uuid.New(), uuid.New()
+	resetsAt := time.Now().Add(time.Hour)
+	quota := &stubQuotaEnforcer{exceeded: map[uuid.UUID]bool{limitedTenant: true}, resetsAt: resetsAt}
+
+	repo := &tenantScopedMessageRepository{newMockMessageRepository()}
+	tenantRepo := &mockTenantRepository{pendingTenants: []uuid.UUID{limitedTenant, freeTenant}}
+	provider := newMockMessageProvider()
+	useCase := NewMessageUseCase(repo, tenantRepo, nil, provider, nil, nil, quota, nil, nil, &config.Config{}, zap.NewNop()).(*messageUseCaseImpl)
+
+	input := domainUsecases.CreateMessageInput{Content: "Test message", PhoneNumber: "+1234567890"}
+	_, err := useCase.CreateMessage(entities.ContextWithTenant(context.Background(), limitedTenant), input)
+	var quotaErr *entities.QuotaExceededError
+	if !errors.As(err, &quotaErr) || !errors.Is(err, entities.ErrQuotaExceeded) {
+		t.Fatalf("Expected QuotaExceededError, got %v", err)
+	}
+	if len(repo.messages) != 0 {
+		t.Error("Expected rejected message not to be stored")
+	}
+
+	freeCtx := entities.ContextWithTenant(context.Background(), freeTenant)
+	accepted, err := useCase.CreateMessage(freeCtx, input)
+	if err != nil {
+		t.Fatalf("Expected message within quota to be created, got %v", err)
+	}
+	if accepted.QuotaReservation() == nil || quota.reserved[freeTenant] != 1 {
+		t.Fatalf("Expected usage to be reserved when the message is accepted, got %d", quota.reserved[freeTenant])
+	}
+
+	// kabulde ayrilan pay gonderimde tekrar sayilmaz
+	if err := useCase.SendMessage(freeCtx, accepted); err != nil {
+		t.Fatalf("Expected reserved message to be sent, got %v", err)
+	}
+	if quota.reserved[freeTenant] != 1 {
+		t.Errorf("Expected a sent message to keep its single reservation, got %d", quota.reserved[freeTenant])
+	}
+
+	cancelled, err := useCase.CreateMessage(freeCtx, domainUsecases.CreateMessageInput{Content: "Cancel me", PhoneNumber: "+1234567890"})
+	if err != nil {
+		t.Fatalf("Expected message to be created, got %v", err)
+	}
+	if _, err := useCase.CancelMessage(freeCtx, cancelled.ID); err != nil {
+		t.Fatalf("Expected message to be cancelled, got %v", err)
+	}
+	if quota.reserved[freeTenant] != 1 || cancelled.QuotaReservation() != nil {
+		t.Errorf("Expected cancelling to release the reservation, got %d", quota.reserved[freeTenant])
+	}
+
+	failed, err := useCase.CreateMessage(freeCtx, domainUsecases.CreateMessageInput{Content: "Fail me", PhoneNumber: "+1234567890"})
+	if err != nil {
+		t.Fatalf("Expected message to be created, got %v", err)
+	}
+	provider.sendFunc = func(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+		return nil, entities.NewProviderError(entities.ErrorCodeInvalidRecipient, errors.New("unknown subscriber"))
+	}
+	if err := useCase.SendMessage(freeCtx, failed); err == nil {
+		t.Fatal("Expected send to fail")
+	}
+	if failed.Status != entities.MessageStatusFailed || quota.reserved[freeTenant] != 1 {
+		t.Errorf("Expected a permanently failed message to release its reservation, got %s, %d", failed.Status, quota.reserved[freeTenant])
+	}
+
+	// kabulde payi ayrilmamis mesaj gonderimde ayirir; kota doluysa reset'e kadar bekletilir
+	held := &entities.Message{ID: uuid.New(), TenantID: limitedTenant, Content: "Held", PhoneNumber: "+1234567890", Status: entities.MessageStatusPending}
+	repo.messages[held.ID] = held
+
+	if err := useCase.SendMessage(entities.ContextWithTenant(context.Background(), limitedTenant), held); !errors.Is(err, entities.ErrQuotaExceeded) {
+		t.Errorf("Expected send to be blocked by quota, got %v", err)
+	}
+	if !held.IsPending() || held.NextAttemptAt == nil || !held.NextAttemptAt.Equal(resetsAt) {
+		t.Errorf("Expected message over quota to stay pending until the quota resets, got %s, %v", held.Status, held.NextAttemptAt)
+	}
+
+	messages, err := useCase.collectPendingMessages(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(messages) != 0 {
+		t.Errorf("Expected held message not to be collected before the quota resets, got %d", len(messages))
+	}
+}
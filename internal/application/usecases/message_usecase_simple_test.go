@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
 	"message-sending-service/internal/infrastructure/config"
 	"message-sending-service/internal/infrastructure/external"
 )
@@ -21,10 +22,13 @@ func TestMessageUseCase_CreateMessage_Simple(t *testing.T) {
 		},
 	}
 
-	apiClient := external.NewMessageAPIClient(cfg)
+	apiClient, err := external.NewMessageAPIClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
 	logger, _ := zap.NewNop(), zap.NewNop()
 
-	useCase := NewMessageUseCase(nil, nil, apiClient, logger)
+	useCase := NewMessageUseCase(nil, nil, nil, apiClient, nil, nil, nil, nil, nil, cfg, logger)
 
 	tests := []struct {
 		name        string
@@ -84,7 +88,7 @@ func TestMessageUseCase_CreateMessage_Simple(t *testing.T) {
 				}
 			}
 
-			_, err = useCase.CreateMessage(ctx, tt.content, tt.phoneNumber)
+			_, err = useCase.CreateMessage(ctx, domainUsecases.CreateMessageInput{Content: tt.content, PhoneNumber: tt.phoneNumber})
 
 			if tt.wantErr {
 				if err == nil {
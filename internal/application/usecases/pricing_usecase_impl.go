@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+	"message-sending-service/internal/domain/usecases"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+type pricingUseCaseImpl struct {
+	priceRepo   repositories.PriceRepository
+	messageRepo repositories.MessageRepository
+	config      *config.Config
+	logger      *zap.Logger
+
+	// table reload sirasinda degisir, Cost her mesajda okur
+	mu    sync.RWMutex
+	table *entities.PriceTable
+}
+
+func NewPricingUseCase(
+	priceRepo repositories.PriceRepository,
+	messageRepo repositories.MessageRepository,
+	config *config.Config,
+	logger *zap.Logger,
+) usecases.PricingUseCase {
+	return &pricingUseCaseImpl{
+		priceRepo:   priceRepo,
+		messageRepo: messageRepo,
+		config:      config,
+		logger:      logger,
+	}
+}
+
+func (uc *pricingUseCaseImpl) Cost(provider string, message *entities.Message) (entities.MessageCost, bool) {
+	uc.mu.RLock()
+	table := uc.table
+	uc.mu.RUnlock()
+
+	return table.Cost(provider, message)
+}
+
+func (uc *pricingUseCaseImpl) ReloadPrices(ctx context.Context) error {
+	entries, err := uc.priceRepo.Load(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to load price table", zap.Error(err))
+		return err
+	}
+
+	table, err := entities.NewPriceTable(entries)
+	if err != nil {
+		uc.logger.Error("Invalid price table", zap.Error(err))
+		return err
+	}
+
+	uc.mu.Lock()
+	uc.table = table
+	uc.mu.Unlock()
+
+	uc.logger.Info("Price table loaded", zap.Int("entries", len(entries)))
+	return nil
+}
+
+func (uc *pricingUseCaseImpl) GetPrices(ctx context.Context) *usecases.PriceList {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	return &usecases.PriceList{
+		Currency: uc.config.Pricing.Currency,
+		Entries:  uc.table.Entries(),
+	}
+}
+
+func (uc *pricingUseCaseImpl) GetCostReport(ctx context.Context, filter entities.CostReportFilter) (*usecases.CostReport, error) {
+	rows, err := uc.messageRepo.GetCostReport(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &usecases.CostReport{
+		Currency: uc.config.Pricing.Currency,
+		GroupBy:  filter.GroupBy,
+		Rows:     rows,
+	}
+	for _, row := range rows {
+		report.Total.Messages += row.Messages
+		report.Total.SentMessages += row.SentMessages
+		report.Total.EstimatedCost += row.EstimatedCost
+		report.Total.ActualCost += row.ActualCost
+	}
+	report.Total.EstimatedCost = entities.RoundCost(report.Total.EstimatedCost)
+	report.Total.ActualCost = entities.RoundCost(report.Total.ActualCost)
+
+	return report, nil
+}
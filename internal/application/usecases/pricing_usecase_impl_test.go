@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	domainUsecases "message-sending-service/internal/domain/usecases"
+	"message-sending-service/internal/infrastructure/config"
+	"message-sending-service/internal/infrastructure/external"
+)
+
+type mockPriceRepository struct {
+	entries []entities.PriceEntry
+	err     error
+}
+
+func (m *mockPriceRepository) Load(ctx context.Context) ([]entities.PriceEntry, error) {
+	return m.entries, m.err
+}
+
+func newPricingTestConfig(apiURL string) *config.Config {
+	return &config.Config{
+		External: config.ExternalConfig{MessageAPIURL: apiURL, Provider: "acme"},
+		Pricing:  config.PricingConfig{Currency: "EUR"},
+	}
+}
+
+func TestPricingUseCase_ReloadPrices(t *testing.T) {
+	priceRepo := &mockPriceRepository{entries: []entities.PriceEntry{{Provider: "acme", Prefix: "90", PricePerSegment: 0.02}}}
+	useCase := NewPricingUseCase(priceRepo, newMockMessageRepository(), newPricingTestConfig(""), zap.NewNop())
+
+	message := &entities.Message{PhoneNumber: "+905551112233", Content: "Hello"}
+	if _, ok := useCase.Cost("acme", message); ok {
+		t.Error("Expected no prices before the first load")
+	}
+
+	if err := useCase.ReloadPrices(context.Background()); err != nil {
+		t.Fatalf("Expected reload to succeed, got %v", err)
+	}
+	if cost, ok := useCase.Cost("acme", message); !ok || cost.Amount != 0.02 {
+		t.Errorf("Expected cost 0.02, got %+v, %v", cost, ok)
+	}
+
+	// hatali tablo reddedilir, eski tablo kullanilmaya devam eder
+	priceRepo.entries = []entities.PriceEntry{{Provider: "acme", Prefix: "90", PricePerSegment: -1}}
+	if err := useCase.ReloadPrices(context.Background()); !errors.Is(err, entities.ErrInvalidPriceEntry) {
+		t.Errorf("Expected ErrInvalidPriceEntry, got %v", err)
+	}
+	if prices := useCase.GetPrices(context.Background()); len(prices.Entries) != 1 || prices.Entries[0].PricePerSegment != 0.02 || prices.Currency != "EUR" {
+		t.Errorf("Expected previous table to stay in use, got %+v", prices)
+	}
+}
+
+func TestMessageUseCase_Cost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"message_id":"ext-1","status":"sent"}`))
+	}))
+	defer server.Close()
+
+	cfg := newPricingTestConfig(server.URL)
+	repo := newMockMessageRepository()
+	priceRepo := &mockPriceRepository{entries: []entities.PriceEntry{
+		{Provider: entities.AnyProvider, Prefix: "90", PricePerSegment: 0.02},
+		{Provider: "acme", Prefix: "90", PricePerSegment: 0.015},
+	}}
+	pricing := NewPricingUseCase(priceRepo, repo, cfg, zap.NewNop())
+	if err := pricing.ReloadPrices(context.Background()); err != nil {
+		t.Fatalf("Expected reload to succeed, got %v", err)
+	}
+
+	apiClient, err := external.NewMessageAPIClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
+	useCase := NewMessageUseCase(repo, nil, nil, apiClient, nil, nil, nil, pricing, nil, cfg, zap.NewNop())
+	ctx := entities.ContextWithTenant(context.Background(), uuid.New())
+
+	campaign := "spring-sale"
+	message, err := useCase.CreateMessage(ctx, domainUsecases.CreateMessageInput{Content: "Hello", PhoneNumber: "+905551112233", Campaign: &campaign})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if message.EstimatedCost == nil || *message.EstimatedCost != 0.015 || message.CostPrefix == nil || *message.CostPrefix != "90" {
+		t.Fatalf("Expected estimated cost from the acme price, got %v", message.EstimatedCost)
+	}
+
+	// fiyati olmayan numaraya duzenlenen mesajin eski tahmini kalmamali
+	phone := "+442071234567"
+	edited, err := useCase.UpdateMessage(ctx, message.ID, domainUsecases.UpdateMessageInput{PhoneNumber: &phone})
+	if err != nil || edited.EstimatedCost != nil {
+		t.Fatalf("Expected estimate to be cleared for unpriced number, got %v, %v", edited.EstimatedCost, err)
+	}
+
+	phone = "+905551112233"
+	if _, err := useCase.UpdateMessage(ctx, message.ID, domainUsecases.UpdateMessageInput{PhoneNumber: &phone}); err != nil {
+		t.Fatalf("Expected edit to succeed, got %v", err)
+	}
+
+	if err := useCase.SendMessage(ctx, message); err != nil {
+		t.Fatalf("Expected send to succeed, got %v", err)
+	}
+	if message.ActualCost == nil || *message.ActualCost != 0.015 {
+		t.Errorf("Expected actual cost 0.015, got %v", message.ActualCost)
+	}
+
+	stats, err := useCase.GetMessageStats(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if stats.EstimatedCost != 0.015 || stats.ActualCost != 0.015 || stats.Currency != "EUR" {
+		t.Errorf("Unexpected cost stats: %+v", stats)
+	}
+
+	report, err := pricing.GetCostReport(ctx, entities.CostReportFilter{GroupBy: entities.CostGroupCampaign})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(report.Rows) != 1 || report.Rows[0].Key != campaign || report.Total.SentMessages != 1 || report.Total.ActualCost != 0.015 {
+		t.Errorf("Unexpected cost report: %+v", report)
+	}
+}
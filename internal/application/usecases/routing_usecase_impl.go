@@ -0,0 +1,258 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+	"message-sending-service/internal/domain/usecases"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+type routingUseCaseImpl struct {
+	ruleRepo   repositories.RoutingRuleRepository
+	tenantRepo repositories.TenantRepository
+	audit      usecases.AuditUseCase
+	config     *config.Config
+	logger     *zap.Logger
+
+	// table reload sirasinda degisir, Route her gonderimde okur
+	mu    sync.RWMutex
+	table *entities.RoutingTable
+
+	// rand.Rand eszamanli kullanima uygun degil
+	rngMu sync.Mutex
+	rng   *rand.Rand
+}
+
+func NewRoutingUseCase(
+	ruleRepo repositories.RoutingRuleRepository,
+	tenantRepo repositories.TenantRepository,
+	audit usecases.AuditUseCase,
+	config *config.Config,
+	logger *zap.Logger,
+) usecases.RoutingUseCase {
+	return &routingUseCaseImpl{
+		ruleRepo:   ruleRepo,
+		tenantRepo: tenantRepo,
+		audit:      audit,
+		config:     config,
+		logger:     logger,
+		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (uc *routingUseCaseImpl) Route(request entities.SendRequest) []string {
+	uc.mu.RLock()
+	rule := uc.table.Match(request)
+	uc.mu.RUnlock()
+
+	if rule == nil {
+		return nil
+	}
+
+	uc.rngMu.Lock()
+	defer uc.rngMu.Unlock()
+	return weightedOrder(rule.Targets, uc.rng)
+}
+
+// weightedOrder agirlikli hedefleri iadesiz agirlikli ornekleme ile siralar, agirligi 0 olanlar sona eklenir
+func weightedOrder(targets []entities.RouteTarget, rng *rand.Rand) []string {
+	weighted := make([]entities.RouteTarget, 0, len(targets))
+	var fallback []string
+	total := 0
+	for _, target := range targets {
+		if target.Weight > 0 {
+			weighted = append(weighted, target)
+			total += target.Weight
+		} else {
+			fallback = append(fallback, target.Provider)
+		}
+	}
+
+	order := make([]string, 0, len(targets))
+	for len(weighted) > 0 {
+		pick := rng.Intn(total)
+		for i, target := range weighted {
+			if pick < target.Weight {
+				order = append(order, target.Provider)
+				total -= target.Weight
+				weighted = append(weighted[:i], weighted[i+1:]...)
+				break
+			}
+			pick -= target.Weight
+		}
+	}
+
+	return append(order, fallback...)
+}
+
+func (uc *routingUseCaseImpl) ReloadRules(ctx context.Context) error {
+	rules, err := uc.ruleRepo.GetAll(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to load routing rules", zap.Error(err))
+		return err
+	}
+
+	table := entities.NewRoutingTable(rules)
+
+	uc.mu.Lock()
+	uc.table = table
+	uc.mu.Unlock()
+
+	uc.logger.Debug("Routing rules loaded", zap.Int("rules", len(rules)), zap.Int("enabled", table.Len()))
+	return nil
+}
+
+func (uc *routingUseCaseImpl) StartReload(ctx context.Context) {
+	if uc.config.Routing.ReloadInterval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(uc.config.Routing.ReloadInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := uc.ReloadRules(ctx); err != nil {
+					uc.logger.Warn("Failed to reload routing rules, keeping previous rules", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+func (uc *routingUseCaseImpl) CreateRule(ctx context.Context, rule *entities.RoutingRule) error {
+	if err := uc.validate(ctx, rule); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	rule.ID = uuid.New()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	if err := uc.ruleRepo.Create(ctx, rule); err != nil {
+		uc.logger.Error("Failed to create routing rule", zap.Error(err))
+		return err
+	}
+
+	uc.recordAudit(ctx, entities.AuditActionRouteCreate, nil, rule)
+	uc.reloadAfterChange(ctx)
+	return nil
+}
+
+func (uc *routingUseCaseImpl) GetRule(ctx context.Context, id uuid.UUID) (*entities.RoutingRule, error) {
+	return uc.ruleRepo.GetByID(ctx, id)
+}
+
+func (uc *routingUseCaseImpl) GetRules(ctx context.Context) ([]*entities.RoutingRule, error) {
+	rules, err := uc.ruleRepo.GetAll(ctx)
+	if err != nil {
+		uc.logger.Error("Failed to get routing rules", zap.Error(err))
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (uc *routingUseCaseImpl) UpdateRule(ctx context.Context, rule *entities.RoutingRule) error {
+	existing, err := uc.ruleRepo.GetByID(ctx, rule.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.validate(ctx, rule); err != nil {
+		return err
+	}
+
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = time.Now()
+
+	if err := uc.ruleRepo.Update(ctx, rule); err != nil {
+		uc.logger.Error("Failed to update routing rule", zap.String("rule_id", rule.ID.String()), zap.Error(err))
+		return err
+	}
+
+	uc.recordAudit(ctx, entities.AuditActionRouteUpdate, existing, rule)
+	uc.reloadAfterChange(ctx)
+	return nil
+}
+
+func (uc *routingUseCaseImpl) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	existing, err := uc.ruleRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.ruleRepo.Delete(ctx, id); err != nil {
+		uc.logger.Error("Failed to delete routing rule", zap.String("rule_id", id.String()), zap.Error(err))
+		return err
+	}
+
+	uc.recordAudit(ctx, entities.AuditActionRouteDelete, existing, nil)
+	uc.reloadAfterChange(ctx)
+	return nil
+}
+
+// validate kuralda sadece config'de tanimli provider'lara ve var olan tenant'lara izin verir
+func (uc *routingUseCaseImpl) validate(ctx context.Context, rule *entities.RoutingRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	if rule.TenantID != nil {
+		if _, err := uc.tenantRepo.GetByID(ctx, *rule.TenantID); err != nil {
+			return err
+		}
+	}
+
+	known := make(map[string]bool)
+	for _, provider := range uc.config.External.ProviderList() {
+		known[provider.Name] = true
+	}
+	for _, target := range rule.Targets {
+		if !known[target.Provider] {
+			return fmt.Errorf("%w: %s", entities.ErrUnknownRouteProvider, target.Provider)
+		}
+	}
+	return nil
+}
+
+// reloadAfterChange degisiklik kaydedildi, reload hatasi istegi bozmasin; periyodik reload tekrar dener
+func (uc *routingUseCaseImpl) reloadAfterChange(ctx context.Context) {
+	if err := uc.ReloadRules(ctx); err != nil {
+		uc.logger.Warn("Routing rule saved but reload failed", zap.Error(err))
+	}
+}
+
+func (uc *routingUseCaseImpl) recordAudit(ctx context.Context, action string, before, after *entities.RoutingRule) {
+	if uc.audit == nil {
+		return
+	}
+
+	input := usecases.RecordAuditInput{
+		Action:     action,
+		TargetType: entities.AuditTargetRouteRule,
+	}
+	if before != nil {
+		input.TargetID = before.ID.String()
+		input.Before = before
+	}
+	if after != nil {
+		input.TargetID = after.ID.String()
+		input.After = after
+	}
+	uc.audit.Record(ctx, input)
+}
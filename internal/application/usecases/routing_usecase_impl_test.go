@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+type mockRoutingRuleRepository struct {
+	rules []*entities.RoutingRule
+}
+
+func (m *mockRoutingRuleRepository) Create(ctx context.Context, rule *entities.RoutingRule) error {
+	m.rules = append(m.rules, rule)
+	return nil
+}
+
+func (m *mockRoutingRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.RoutingRule, error) {
+	for _, rule := range m.rules {
+		if rule.ID == id {
+			copied := *rule
+			return &copied, nil
+		}
+	}
+	return nil, entities.ErrRoutingRuleNotFound
+}
+
+func (m *mockRoutingRuleRepository) GetAll(ctx context.Context) ([]*entities.RoutingRule, error) {
+	return m.rules, nil
+}
+
+func (m *mockRoutingRuleRepository) Update(ctx context.Context, rule *entities.RoutingRule) error {
+	for i, existing := range m.rules {
+		if existing.ID == rule.ID {
+			m.rules[i] = rule
+			return nil
+		}
+	}
+	return entities.ErrRoutingRuleNotFound
+}
+
+func (m *mockRoutingRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	for i, rule := range m.rules {
+		if rule.ID == id {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return nil
+		}
+	}
+	return entities.ErrRoutingRuleNotFound
+}
+
+func newRoutingTestConfig() *config.Config {
+	return &config.Config{
+		External: config.ExternalConfig{
+			Providers: []config.ProviderConfig{{Name: "primary"}, {Name: "backup"}, {Name: "cheap"}},
+		},
+	}
+}
+
+func TestRoutingUseCase_RulesTakeEffectWithoutRestart(t *testing.T) {
+	repo := &mockRoutingRuleRepository{}
+	useCase := NewRoutingUseCase(repo, &mockTenantRepository{}, nil, newRoutingTestConfig(), zap.NewNop())
+	ctx := context.Background()
+	request := entities.SendRequest{PhoneNumber: "+905551112233"}
+
+	if err := useCase.ReloadRules(ctx); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if route := useCase.Route(request); route != nil {
+		t.Errorf("Expected no route without rules, got %v", route)
+	}
+
+	rule := &entities.RoutingRule{Name: "turkey", Prefix: "90", Enabled: true, Targets: []entities.RouteTarget{{Provider: "cheap"}, {Provider: "backup"}}}
+	if err := useCase.CreateRule(ctx, rule); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if route := useCase.Route(request); len(route) != 2 || route[0] != "cheap" || route[1] != "backup" {
+		t.Errorf("Expected route [cheap backup], got %v", route)
+	}
+
+	updated := *rule
+	updated.Enabled = false
+	if err := useCase.UpdateRule(ctx, &updated); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if route := useCase.Route(request); route != nil {
+		t.Errorf("Expected disabled rule to be ignored, got %v", route)
+	}
+
+	if err := useCase.DeleteRule(ctx, rule.ID); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := useCase.DeleteRule(ctx, rule.ID); !errors.Is(err, entities.ErrRoutingRuleNotFound) {
+		t.Errorf("Expected ErrRoutingRuleNotFound, got %v", err)
+	}
+}
+
+func TestRoutingUseCase_CreateRule_UnknownProvider(t *testing.T) {
+	repo := &mockRoutingRuleRepository{}
+	useCase := NewRoutingUseCase(repo, &mockTenantRepository{}, nil, newRoutingTestConfig(), zap.NewNop())
+
+	rule := &entities.RoutingRule{Name: "uk", Prefix: "44", Enabled: true, Targets: []entities.RouteTarget{{Provider: "nowhere"}}}
+	if err := useCase.CreateRule(context.Background(), rule); !errors.Is(err, entities.ErrUnknownRouteProvider) {
+		t.Errorf("Expected ErrUnknownRouteProvider, got %v", err)
+	}
+	if len(repo.rules) != 0 {
+		t.Error("Expected invalid rule not to be stored")
+	}
+}
+
+func TestWeightedOrder(t *testing.T) {
+	targets := []entities.RouteTarget{{Provider: "a", Weight: 80}, {Provider: "b", Weight: 20}, {Provider: "fallback", Weight: 0}}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	first := map[string]int{}
+	for i := 0; i < 2000; i++ {
+		order := weightedOrder(targets, rng)
+		if len(order) != 3 || order[2] != "fallback" {
+			t.Fatalf("Expected zero-weight provider last, got %v", order)
+		}
+		first[order[0]]++
+	}
+
+	// %80 agirlik icin genis bir tolerans, testin rastgele kirilmamasi icin
+	if first["a"] < 1400 || first["a"] > 1800 {
+		t.Errorf("Expected provider a first about 80%% of the time, got %d/2000", first["a"])
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"message-sending-service/internal/domain/entities"
 	"message-sending-service/internal/domain/repositories"
+	"message-sending-service/internal/domain/services"
 	"message-sending-service/internal/domain/usecases"
 	"message-sending-service/internal/infrastructure/config"
 )
@@ -17,6 +18,8 @@ import (
 type schedulerUseCaseImpl struct {
 	messageUseCase usecases.MessageUseCase
 	cacheRepo      repositories.CacheRepository
+	providerHealth services.ProviderHealthReporter
+	audit          usecases.AuditUseCase
 	config         *config.Config
 	logger         *zap.Logger
 
@@ -32,12 +35,16 @@ type schedulerUseCaseImpl struct {
 func NewSchedulerUseCase(
 	messageUseCase usecases.MessageUseCase,
 	cacheRepo repositories.CacheRepository,
+	providerHealth services.ProviderHealthReporter,
+	audit usecases.AuditUseCase,
 	config *config.Config,
 	logger *zap.Logger,
 ) usecases.SchedulerUseCase {
 	return &schedulerUseCaseImpl{
 		messageUseCase: messageUseCase,
 		cacheRepo:      cacheRepo,
+		providerHealth: providerHealth,
+		audit:          audit,
 		config:         config,
 		logger:         logger,
 		cron:           cron.New(),
@@ -77,6 +84,8 @@ func (uc *schedulerUseCaseImpl) StartScheduler(ctx context.Context) error {
 		}
 	}
 
+	uc.recordAudit(ctx, entities.AuditActionSchedulerStart, entities.SchedulerStatusStopped, entities.SchedulerStatusRunning)
+
 	uc.logger.Info("Scheduler started",
 		zap.String("interval", uc.config.Scheduler.Interval.String()),
 		zap.Int("batch_size", uc.config.Scheduler.MessagesPerBatch))
@@ -103,10 +112,25 @@ func (uc *schedulerUseCaseImpl) StopScheduler(ctx context.Context) error {
 		}
 	}
 
+	uc.recordAudit(ctx, entities.AuditActionSchedulerStop, entities.SchedulerStatusRunning, entities.SchedulerStatusStopped)
+
 	uc.logger.Info("Scheduler stopped")
 	return nil
 }
 
+func (uc *schedulerUseCaseImpl) recordAudit(ctx context.Context, action string, before, after entities.SchedulerStatus) {
+	if uc.audit == nil {
+		return
+	}
+
+	uc.audit.Record(ctx, usecases.RecordAuditInput{
+		Action:     action,
+		TargetType: entities.AuditTargetScheduler,
+		Before:     map[string]entities.SchedulerStatus{"status": before},
+		After:      map[string]entities.SchedulerStatus{"status": after},
+	})
+}
+
 func (uc *schedulerUseCaseImpl) GetSchedulerStatus(ctx context.Context) (*entities.SchedulerInfo, error) {
 	uc.mu.RLock()
 	defer uc.mu.RUnlock()
@@ -124,6 +148,9 @@ func (uc *schedulerUseCaseImpl) GetSchedulerStatus(ctx context.Context) (*entiti
 		Interval:      uc.config.Scheduler.Interval,
 		BatchSize:     uc.config.Scheduler.MessagesPerBatch,
 	}
+	if uc.providerHealth != nil {
+		info.Providers = uc.providerHealth.ProviderHealth()
+	}
 
 	return info, nil
 }
@@ -23,7 +23,7 @@ func newMockMessageUseCase() *mockMessageUseCase {
 	return &mockMessageUseCase{}
 }
 
-func (m *mockMessageUseCase) CreateMessage(ctx context.Context, content, phoneNumber string) (*entities.Message, error) {
+func (m *mockMessageUseCase) CreateMessage(ctx context.Context, input domainUsecases.CreateMessageInput) (*entities.Message, error) {
 	return nil, nil
 }
 
@@ -55,6 +55,22 @@ func (m *mockMessageUseCase) GetMessageStats(ctx context.Context) (*domainUsecas
 	return nil, nil
 }
 
+func (m *mockMessageUseCase) CancelMessage(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
+	return nil, nil
+}
+
+func (m *mockMessageUseCase) UpdateMessage(ctx context.Context, id uuid.UUID, input domainUsecases.UpdateMessageInput) (*entities.Message, error) {
+	return nil, nil
+}
+
+func (m *mockMessageUseCase) RequeueMessage(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
+	return nil, nil
+}
+
+func (m *mockMessageUseCase) RecordDeliveryReceipt(ctx context.Context, receipt entities.DeliveryReceipt) error {
+	return nil
+}
+
 func TestSchedulerUseCase_StartScheduler(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -87,7 +103,7 @@ func TestSchedulerUseCase_StartScheduler(t *testing.T) {
 			}
 			logger, _ := zap.NewNop(), zap.NewNop()
 
-			useCase := NewSchedulerUseCase(mockMessageUC, mockCache, cfg, logger)
+			useCase := NewSchedulerUseCase(mockMessageUC, mockCache, nil, nil, cfg, logger)
 
 			if tt.alreadyRunning {
 				err := useCase.StartScheduler(context.Background())
@@ -157,7 +173,7 @@ func TestSchedulerUseCase_StopScheduler(t *testing.T) {
 			}
 			logger, _ := zap.NewNop(), zap.NewNop()
 
-			useCase := NewSchedulerUseCase(mockMessageUC, mockCache, cfg, logger)
+			useCase := NewSchedulerUseCase(mockMessageUC, mockCache, nil, nil, cfg, logger)
 
 			ctx := context.Background()
 
@@ -205,7 +221,7 @@ func TestSchedulerUseCase_GetSchedulerStatus(t *testing.T) {
 	}
 	logger, _ := zap.NewNop(), zap.NewNop()
 
-	useCase := NewSchedulerUseCase(mockMessageUC, mockCache, cfg, logger)
+	useCase := NewSchedulerUseCase(mockMessageUC, mockCache, nil, nil, cfg, logger)
 
 	ctx := context.Background()
 
@@ -246,6 +262,30 @@ func TestSchedulerUseCase_GetSchedulerStatus(t *testing.T) {
 	}
 }
 
+type stubProviderHealth []entities.ProviderHealth
+
+func (s stubProviderHealth) ProviderHealth() []entities.ProviderHealth {
+	return s
+}
+
+func TestSchedulerUseCase_GetSchedulerStatus_Providers(t *testing.T) {
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{Interval: time.Minute, MessagesPerBatch: 2}}
+	health := stubProviderHealth{
+		{Name: "primary", Priority: 1, Healthy: false, Score: 0.2},
+		{Name: "backup", Priority: 2, Healthy: true, Score: 1},
+	}
+
+	useCase := NewSchedulerUseCase(newMockMessageUseCase(), nil, health, nil, cfg, zap.NewNop())
+
+	status, err := useCase.GetSchedulerStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(status.Providers) != 2 || status.Providers[0].Name != "primary" || !status.Providers[1].Healthy {
+		t.Errorf("Expected provider health in status, got %+v", status.Providers)
+	}
+}
+
 func TestSchedulerUseCase_IsRunning(t *testing.T) {
 	mockMessageUC := newMockMessageUseCase()
 	mockCache := newMockCacheRepository()
@@ -257,7 +297,7 @@ func TestSchedulerUseCase_IsRunning(t *testing.T) {
 	}
 	logger, _ := zap.NewNop(), zap.NewNop()
 
-	useCase := NewSchedulerUseCase(mockMessageUC, mockCache, cfg, logger)
+	useCase := NewSchedulerUseCase(mockMessageUC, mockCache, nil, nil, cfg, logger)
 
 	ctx := context.Background()
 
@@ -290,13 +330,13 @@ func TestSchedulerUseCase_ProcessMessages_Integration(t *testing.T) {
 	mockCache := newMockCacheRepository()
 	cfg := &config.Config{
 		Scheduler: config.SchedulerConfig{
-			Interval:         100 * time.Millisecond,
+			Interval:         time.Minute,
 			MessagesPerBatch: 2,
 		},
 	}
 	logger, _ := zap.NewNop(), zap.NewNop()
 
-	useCase := NewSchedulerUseCase(mockMessageUC, mockCache, cfg, logger)
+	useCase := NewSchedulerUseCase(mockMessageUC, mockCache, nil, nil, cfg, logger)
 
 	ctx := context.Background()
 
@@ -306,10 +346,14 @@ func TestSchedulerUseCase_ProcessMessages_Integration(t *testing.T) {
 	}
 	defer useCase.StopScheduler(ctx)
 
-	time.Sleep(200 * time.Millisecond)
+	// cron @every en az 1 saniye; beklemek yerine tick dogrudan calistirilir
+	scheduler := useCase.(*schedulerUseCaseImpl)
+	before := time.Now()
+	scheduler.processMessages()
+	scheduler.processMessages()
 
-	if mockMessageUC.callCount == 0 {
-		t.Error("Expected ProcessPendingMessages to be called but it wasn't")
+	if mockMessageUC.callCount != 2 {
+		t.Errorf("Expected ProcessPendingMessages to be called twice, got %d", mockMessageUC.callCount)
 	}
 
 	status, err := useCase.GetSchedulerStatus(ctx)
@@ -317,8 +361,11 @@ func TestSchedulerUseCase_ProcessMessages_Integration(t *testing.T) {
 		t.Errorf("Failed to get scheduler status: %v", err)
 	}
 
-	if status.LastRun == nil {
-		t.Error("Expected LastRun to be set after processing")
+	if status.LastRun == nil || status.LastRun.Before(before) {
+		t.Errorf("Expected LastRun to be set after processing, got %v", status.LastRun)
+	}
+	if status.NextRun == nil || status.NextRun.Sub(*status.LastRun) != cfg.Scheduler.Interval {
+		t.Errorf("Expected NextRun one interval after LastRun, got %v", status.NextRun)
 	}
 
 	expectedCount := mockMessageUC.callCount * mockMessageUC.processedCount
@@ -338,7 +385,7 @@ func TestSchedulerUseCase_CacheIntegration(t *testing.T) {
 	}
 	logger, _ := zap.NewNop(), zap.NewNop()
 
-	useCase := NewSchedulerUseCase(mockMessageUC, mockCache, cfg, logger)
+	useCase := NewSchedulerUseCase(mockMessageUC, mockCache, nil, nil, cfg, logger)
 
 	ctx := context.Background()
 
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+	"message-sending-service/internal/domain/usecases"
+)
+
+type tenantUseCaseImpl struct {
+	tenantRepo repositories.TenantRepository
+	logger     *zap.Logger
+}
+
+func NewTenantUseCase(tenantRepo repositories.TenantRepository, logger *zap.Logger) usecases.TenantUseCase {
+	return &tenantUseCaseImpl{
+		tenantRepo: tenantRepo,
+		logger:     logger,
+	}
+}
+
+func (uc *tenantUseCaseImpl) CreateTenant(ctx context.Context, tenant *entities.Tenant) error {
+	if err := tenant.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	tenant.ID = uuid.New()
+	tenant.CreatedAt = now
+	tenant.UpdatedAt = now
+
+	if err := uc.tenantRepo.Create(ctx, tenant); err != nil {
+		uc.logger.Error("Failed to create tenant", zap.Error(err))
+		return err
+	}
+
+	uc.logger.Info("Tenant created", zap.String("tenant_id", tenant.ID.String()), zap.String("name", tenant.Name))
+	return nil
+}
+
+func (uc *tenantUseCaseImpl) GetTenant(ctx context.Context, id uuid.UUID) (*entities.Tenant, error) {
+	return uc.tenantRepo.GetByID(ctx, id)
+}
+
+func (uc *tenantUseCaseImpl) GetTenants(ctx context.Context, page, limit int) ([]*entities.Tenant, error) {
+	offset := (page - 1) * limit
+
+	tenants, err := uc.tenantRepo.GetAll(ctx, offset, limit)
+	if err != nil {
+		uc.logger.Error("Failed to get tenants", zap.Error(err))
+		return nil, err
+	}
+
+	return tenants, nil
+}
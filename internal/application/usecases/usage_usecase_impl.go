@@ -0,0 +1,201 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+	"message-sending-service/internal/domain/usecases"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+type usageUseCaseImpl struct {
+	usageRepo  repositories.UsageRepository
+	quotaRepo  repositories.QuotaRepository
+	tenantRepo repositories.TenantRepository
+	config     *config.Config
+	logger     *zap.Logger
+	now        func() time.Time
+}
+
+func NewUsageUseCase(
+	usageRepo repositories.UsageRepository,
+	quotaRepo repositories.QuotaRepository,
+	tenantRepo repositories.TenantRepository,
+	config *config.Config,
+	logger *zap.Logger,
+) usecases.UsageUseCase {
+	return &usageUseCaseImpl{
+		usageRepo:  usageRepo,
+		quotaRepo:  quotaRepo,
+		tenantRepo: tenantRepo,
+		config:     config,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// ReserveQuota kullanimi her zaman sayar, limitleri sadece quota aciksa kontrol eder. Kontrol sayacin kilitli
+// okumasiyla yapildigi icin bekleyen ve zamanlanmis mesajlar da limite dahildir.
+// Quota ya da kullanim okunamazsa gonderim engellenmez.
+func (uc *usageUseCaseImpl) ReserveQuota(ctx context.Context, tenantID uuid.UUID, messages, segments int64) (*entities.QuotaReservation, error) {
+	var quota *entities.TenantQuota
+	if uc.config.Quota.Enabled {
+		var err error
+		if quota, err = uc.GetQuota(ctx, tenantID); err != nil {
+			uc.logger.Warn("Failed to load quota, allowing message", zap.String("tenant_id", tenantID.String()), zap.Error(err))
+		}
+	}
+
+	reservation := &entities.QuotaReservation{
+		Day:      entities.UsagePeriodStart(entities.UsagePeriodDay, uc.now()),
+		Messages: messages,
+		Segments: segments,
+	}
+	err := uc.usageRepo.Reserve(ctx, tenantID, reservation.Day, messages, segments, func(day, month entities.UsageRecord) error {
+		if quota == nil {
+			return nil
+		}
+		return uc.checkLimits(tenantID, quota.Limits(day, month), messages, segments)
+	})
+	if errors.Is(err, entities.ErrQuotaExceeded) {
+		return nil, err
+	}
+	if err != nil {
+		uc.logger.Warn("Failed to reserve usage, allowing message", zap.String("tenant_id", tenantID.String()), zap.Error(err))
+		return nil, nil
+	}
+
+	return reservation, nil
+}
+
+// checkLimits once soft limitleri (sadece uyari) sonra hard limitleri kontrol eder
+func (uc *usageUseCaseImpl) checkLimits(tenantID uuid.UUID, limits []entities.QuotaLimit, messages, segments int64) error {
+	for _, limit := range limits {
+		requested := limit.Requested(messages, segments)
+		if limit.Used+requested > limit.Hard {
+			return &entities.QuotaExceededError{
+				Period:    limit.Period,
+				Metric:    limit.Metric,
+				Limit:     limit.Hard,
+				Used:      limit.Used,
+				Requested: requested,
+				ResetsAt:  limit.ResetsAt,
+			}
+		}
+
+		if limit.Soft > 0 && limit.Used < limit.Soft && limit.Used+requested >= limit.Soft {
+			uc.logger.Warn("Tenant crossed soft quota",
+				zap.String("tenant_id", tenantID.String()),
+				zap.String("period", limit.Period),
+				zap.String("metric", limit.Metric),
+				zap.Int64("soft_limit", limit.Soft),
+				zap.Int64("hard_limit", limit.Hard))
+		}
+	}
+
+	return nil
+}
+
+func (uc *usageUseCaseImpl) ReleaseQuota(ctx context.Context, tenantID uuid.UUID, reservation entities.QuotaReservation) error {
+	if err := uc.usageRepo.Release(ctx, tenantID, reservation.Day, reservation.Messages, reservation.Segments); err != nil {
+		uc.logger.Error("Failed to release reserved usage", zap.String("tenant_id", tenantID.String()), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (uc *usageUseCaseImpl) GetUsageSummary(ctx context.Context, tenantID uuid.UUID) (*usecases.UsageSummary, error) {
+	quota, err := uc.GetQuota(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	summary := &usecases.UsageSummary{
+		TenantID: tenantID,
+		Quota:    quota,
+		Today:    uc.emptyRecord(tenantID, entities.UsagePeriodDay, now),
+		Month:    uc.emptyRecord(tenantID, entities.UsagePeriodMonth, now),
+	}
+
+	monthRecords, err := uc.usageRepo.GetUsage(ctx, tenantID, entities.UsagePeriodMonth, summary.Month.PeriodStart, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(monthRecords) > 0 {
+		summary.Month = *monthRecords[0]
+	}
+
+	dayRecords, err := uc.usageRepo.GetUsage(ctx, tenantID, entities.UsagePeriodDay, summary.Today.PeriodStart, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(dayRecords) > 0 {
+		summary.Today = *dayRecords[0]
+	}
+
+	summary.Limits = quota.Limits(summary.Today, summary.Month)
+	return summary, nil
+}
+
+func (uc *usageUseCaseImpl) GetUsageHistory(ctx context.Context, tenantID uuid.UUID, period string, from, to time.Time) ([]*entities.UsageRecord, error) {
+	records, err := uc.usageRepo.GetUsage(ctx, tenantID, period, entities.UsagePeriodStart(period, from), to)
+	if err != nil {
+		uc.logger.Error("Failed to get usage history", zap.String("tenant_id", tenantID.String()), zap.Error(err))
+		return nil, err
+	}
+	return records, nil
+}
+
+func (uc *usageUseCaseImpl) GetQuota(ctx context.Context, tenantID uuid.UUID) (*entities.TenantQuota, error) {
+	quota, err := uc.quotaRepo.GetByTenantID(ctx, tenantID)
+	if err == nil {
+		return quota, nil
+	}
+	if !errors.Is(err, entities.ErrQuotaNotFound) {
+		return nil, err
+	}
+
+	defaults := uc.config.Quota
+	return &entities.TenantQuota{
+		TenantID:         tenantID,
+		DailyMessages:    defaults.DailyMessages,
+		DailySegments:    defaults.DailySegments,
+		MonthlyMessages:  defaults.MonthlyMessages,
+		MonthlySegments:  defaults.MonthlySegments,
+		SoftLimitPercent: defaults.SoftLimitPercent,
+	}, nil
+}
+
+func (uc *usageUseCaseImpl) SetQuota(ctx context.Context, quota *entities.TenantQuota) error {
+	if err := quota.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := uc.tenantRepo.GetByID(ctx, quota.TenantID); err != nil {
+		return err
+	}
+
+	quota.UpdatedAt = uc.now()
+	if err := uc.quotaRepo.Upsert(ctx, quota); err != nil {
+		uc.logger.Error("Failed to save tenant quota", zap.String("tenant_id", quota.TenantID.String()), zap.Error(err))
+		return err
+	}
+
+	uc.logger.Info("Tenant quota updated", zap.String("tenant_id", quota.TenantID.String()))
+	return nil
+}
+
+func (uc *usageUseCaseImpl) emptyRecord(tenantID uuid.UUID, period string, now time.Time) entities.UsageRecord {
+	return entities.UsageRecord{
+		TenantID:    tenantID,
+		Period:      period,
+		PeriodStart: entities.UsagePeriodStart(period, now),
+	}
+}
@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+type mockUsageRepository struct {
+	days map[time.Time]*entities.UsageRecord
+}
+
+func newMockUsageRepository() *mockUsageRepository {
+	return &mockUsageRepository{days: make(map[time.Time]*entities.UsageRecord)}
+}
+
+func (m *mockUsageRepository) Reserve(ctx context.Context, tenantID uuid.UUID, day time.Time, messages, segments int64, check func(day, month entities.UsageRecord) error) error {
+	dayUsage := entities.UsageRecord{TenantID: tenantID, Period: entities.UsagePeriodDay, PeriodStart: day}
+	monthUsage := entities.UsageRecord{TenantID: tenantID, Period: entities.UsagePeriodMonth, PeriodStart: entities.UsagePeriodStart(entities.UsagePeriodMonth, day)}
+	for recordDay, record := range m.days {
+		if recordDay.Equal(day) {
+			dayUsage.Messages += record.Messages
+			dayUsage.Segments += record.Segments
+		}
+		if entities.UsagePeriodStart(entities.UsagePeriodMonth, recordDay).Equal(monthUsage.PeriodStart) {
+			monthUsage.Messages += record.Messages
+			monthUsage.Segments += record.Segments
+		}
+	}
+	if err := check(dayUsage, monthUsage); err != nil {
+		return err
+	}
+
+	record, ok := m.days[day]
+	if !ok {
+		record = &entities.UsageRecord{TenantID: tenantID, Period: entities.UsagePeriodDay, PeriodStart: day}
+		m.days[day] = record
+	}
+	record.Messages += messages
+	record.Segments += segments
+	return nil
+}
+
+func (m *mockUsageRepository) Release(ctx context.Context, tenantID uuid.UUID, day time.Time, messages, segments int64) error {
+	if record, ok := m.days[day]; ok {
+		record.Messages -= messages
+		record.Segments -= segments
+	}
+	return nil
+}
+
+func (m *mockUsageRepository) GetUsage(ctx context.Context, tenantID uuid.UUID, period string, from, to time.Time) ([]*entities.UsageRecord, error) {
+	totals := map[time.Time]*entities.UsageRecord{}
+	for day, record := range m.days {
+		if day.Before(from) || !day.Before(to) {
+			continue
+		}
+		start := entities.UsagePeriodStart(period, day)
+		total, ok := totals[start]
+		if !ok {
+			total = &entities.UsageRecord{TenantID: tenantID, Period: period, PeriodStart: start}
+			totals[start] = total
+		}
+		total.Messages += record.Messages
+		total.Segments += record.Segments
+	}
+
+	records := make([]*entities.UsageRecord, 0, len(totals))
+	for _, record := range totals {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+type mockQuotaRepository struct {
+	quotas map[uuid.UUID]*entities.TenantQuota
+}
+
+func (m *mockQuotaRepository) GetByTenantID(ctx context.Context, tenantID uuid.UUID) (*entities.TenantQuota, error) {
+	quota, ok := m.quotas[tenantID]
+	if !ok {
+		return nil, entities.ErrQuotaNotFound
+	}
+	return quota, nil
+}
+
+func (m *mockQuotaRepository) Upsert(ctx context.Context, quota *entities.TenantQuota) error {
+	m.quotas[quota.TenantID] = quota
+	return nil
+}
+
+func newUsageTestUseCase(quotaCfg config.QuotaConfig, now time.Time) (*usageUseCaseImpl, *mockUsageRepository, *mockQuotaRepository) {
+	usageRepo := newMockUsageRepository()
+	quotaRepo := &mockQuotaRepository{quotas: make(map[uuid.UUID]*entities.TenantQuota)}
+	cfg := &config.Config{Quota: quotaCfg}
+
+	useCase := NewUsageUseCase(usageRepo, quotaRepo, &mockTenantRepository{}, cfg, zap.NewNop()).(*usageUseCaseImpl)
+	useCase.now = func() time.Time { return now }
+	return useCase, usageRepo, quotaRepo
+}
+
+func TestUsageUseCase_ReserveQuota(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	useCase, usageRepo, quotaRepo := newUsageTestUseCase(config.QuotaConfig{Enabled: true, DailyMessages: 3, SoftLimitPercent: 50}, now)
+
+	var reservations []*entities.QuotaReservation
+	for i := 0; i < 3; i++ {
+		reservation, err := useCase.ReserveQuota(ctx, tenantID, 1, 1)
+		if err != nil {
+			t.Fatalf("Expected message %d to be within quota, got %v", i+1, err)
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	// bekleyen mesajlarin paylari da sayilir, gonderim beklenmeden limit dolar
+	reservation, err := useCase.ReserveQuota(ctx, tenantID, 1, 1)
+	var quotaErr *entities.QuotaExceededError
+	if !errors.As(err, &quotaErr) || reservation != nil {
+		t.Fatalf("Expected QuotaExceededError, got %+v, %v", reservation, err)
+	}
+	if quotaErr.Period != entities.UsagePeriodDay || quotaErr.Limit != 3 || quotaErr.Used != 3 {
+		t.Errorf("Unexpected quota error: %+v", quotaErr)
+	}
+	if !quotaErr.ResetsAt.Equal(time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected quota to reset at next UTC midnight, got %v", quotaErr.ResetsAt)
+	}
+	if usageRepo.days[reservations[0].Day].Messages != 3 {
+		t.Errorf("Expected the rejected request not to be counted, got %d", usageRepo.days[reservations[0].Day].Messages)
+	}
+
+	// geri verilen pay tekrar kullanilabilir
+	if err := useCase.ReleaseQuota(ctx, tenantID, *reservations[0]); err != nil {
+		t.Fatalf("Expected reservation to be released, got %v", err)
+	}
+	if _, err := useCase.ReserveQuota(ctx, tenantID, 1, 1); err != nil {
+		t.Errorf("Expected released usage to be available again, got %v", err)
+	}
+
+	// ertesi gun gunluk sayac sifirlanir
+	useCase.now = func() time.Time { return now.AddDate(0, 0, 1) }
+	if _, err := useCase.ReserveQuota(ctx, tenantID, 1, 1); err != nil {
+		t.Errorf("Expected daily quota to reset, got %v", err)
+	}
+
+	// tenant override'i aylik segment limiti koyar, varsayilani ezer
+	quotaRepo.quotas[tenantID] = &entities.TenantQuota{TenantID: tenantID, MonthlySegments: 6, SoftLimitPercent: 80}
+	if _, err := useCase.ReserveQuota(ctx, tenantID, 1, 3); !errors.Is(err, entities.ErrQuotaExceeded) {
+		t.Errorf("Expected monthly segment quota to be exceeded, got %v", err)
+	}
+	if _, err := useCase.ReserveQuota(ctx, tenantID, 1, 2); err != nil {
+		t.Errorf("Expected request within monthly segments to pass, got %v", err)
+	}
+
+	if len(usageRepo.days) != 2 {
+		t.Errorf("Expected usage to be stored on two days, got %d", len(usageRepo.days))
+	}
+}
+
+func TestUsageUseCase_ReserveQuota_Disabled(t *testing.T) {
+	useCase, usageRepo, _ := newUsageTestUseCase(config.QuotaConfig{Enabled: false, DailyMessages: 1}, time.Now())
+	tenantID := uuid.New()
+	day := entities.UsagePeriodStart(entities.UsagePeriodDay, time.Now())
+	usageRepo.days[day] = &entities.UsageRecord{Messages: 100}
+
+	reservation, err := useCase.ReserveQuota(context.Background(), tenantID, 1, 1)
+	if err != nil {
+		t.Fatalf("Expected quotas to be ignored when disabled, got %v", err)
+	}
+	if reservation == nil || usageRepo.days[day].Messages != 101 {
+		t.Errorf("Expected usage to be counted even when quotas are disabled, got %+v", reservation)
+	}
+}
+
+func TestUsageUseCase_SetQuota(t *testing.T) {
+	useCase, _, quotaRepo := newUsageTestUseCase(config.QuotaConfig{DailyMessages: 100, SoftLimitPercent: 80}, time.Now())
+	tenantID := uuid.New()
+
+	quota, err := useCase.GetQuota(context.Background(), tenantID)
+	if err != nil || quota.DailyMessages != 100 {
+		t.Fatalf("Expected config default quota, got %+v, %v", quota, err)
+	}
+
+	if err := useCase.SetQuota(context.Background(), &entities.TenantQuota{TenantID: tenantID, DailyMessages: -1}); !errors.Is(err, entities.ErrInvalidQuota) {
+		t.Errorf("Expected ErrInvalidQuota, got %v", err)
+	}
+
+	if err := useCase.SetQuota(context.Background(), &entities.TenantQuota{TenantID: tenantID, DailyMessages: 5, SoftLimitPercent: 90}); err != nil {
+		t.Fatalf("Expected quota to be saved, got %v", err)
+	}
+	if quotaRepo.quotas[tenantID] == nil || quotaRepo.quotas[tenantID].UpdatedAt.IsZero() {
+		t.Error("Expected stored quota with updated_at set")
+	}
+}
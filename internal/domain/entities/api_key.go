@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeMessagesRead   = "messages:read"
+	ScopeMessagesWrite  = "messages:write"
+	ScopeContactsRead   = "contacts:read"
+	ScopeContactsWrite  = "contacts:write"
+	ScopeSchedulerAdmin = "scheduler:admin"
+	// ScopeReceiptsWrite teslim raporunu HTTP ile geri bildiren provider'larin anahtari icin
+	ScopeReceiptsWrite = "receipts:write"
+	// ScopeAdmin tenant ve api key yonetimi icin, butun scope'lari kapsar ve X-Tenant-ID ile baska tenant adina calisabilir
+	ScopeAdmin = "admin"
+)
+
+var AllScopes = []string{
+	ScopeMessagesRead,
+	ScopeMessagesWrite,
+	ScopeContactsRead,
+	ScopeContactsWrite,
+	ScopeSchedulerAdmin,
+	ScopeReceiptsWrite,
+	ScopeAdmin,
+}
+
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	KeyHash    string     `json:"-" db:"key_hash"`
+	Scopes     []string   `json:"scopes" db:"scopes"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+func (k *APIKey) Validate() error {
+	if strings.TrimSpace(k.Name) == "" {
+		return ErrInvalidAPIKeyName
+	}
+
+	if len(k.Scopes) == 0 {
+		return ErrInvalidAPIKeyScope
+	}
+	for _, scope := range k.Scopes {
+		if !IsKnownScope(scope) {
+			return ErrInvalidAPIKeyScope
+		}
+	}
+
+	return nil
+}
+
+func (k *APIKey) HasScope(scope string) bool {
+	return k.Principal().HasScope(scope)
+}
+
+func (k *APIKey) Principal() *Principal {
+	return &Principal{
+		Subject:    k.ID.String(),
+		Name:       k.Name,
+		TenantID:   k.TenantID,
+		Scopes:     k.Scopes,
+		AuthMethod: AuthMethodAPIKey,
+	}
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// HashAPIKey anahtarlar rastgele ve uzun oldugu icin duz SHA-256 yeterli, DB'de sadece hash tutulur
+func HashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsKnownScope(scope string) bool {
+	for _, s := range AllScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package entities
+
+import "testing"
+
+func TestAPIKey_HasScope(t *testing.T) {
+	key := &APIKey{Scopes: []string{ScopeMessagesRead}}
+	if !key.HasScope(ScopeMessagesRead) {
+		t.Error("Expected key to have messages:read")
+	}
+	if key.HasScope(ScopeMessagesWrite) {
+		t.Error("Expected key not to have messages:write")
+	}
+
+	admin := &APIKey{Scopes: []string{ScopeAdmin}}
+	if !admin.HasScope(ScopeSchedulerAdmin) {
+		t.Error("Expected admin scope to cover every scope")
+	}
+}
+
+func TestAPIKey_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     APIKey
+		wantErr error
+	}{
+		{name: "valid key", key: APIKey{Name: "checkout", Scopes: []string{ScopeMessagesWrite}}},
+		{name: "empty name", key: APIKey{Scopes: []string{ScopeMessagesWrite}}, wantErr: ErrInvalidAPIKeyName},
+		{name: "no scopes", key: APIKey{Name: "checkout"}, wantErr: ErrInvalidAPIKeyScope},
+		{name: "unknown scope", key: APIKey{Name: "checkout", Scopes: []string{"messages:delete"}}, wantErr: ErrInvalidAPIKeyScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.key.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHashAPIKey(t *testing.T) {
+	if HashAPIKey("msk_a") == HashAPIKey("msk_b") {
+		t.Error("Expected different keys to have different hashes")
+	}
+	if len(HashAPIKey("msk_a")) != 64 {
+		t.Error("Expected hex encoded SHA-256 hash")
+	}
+}
@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditActionSchedulerStart = "scheduler.start"
+	AuditActionSchedulerStop  = "scheduler.stop"
+	AuditActionMessageCancel  = "message.cancel"
+	AuditActionMessageEdit    = "message.edit"
+	AuditActionMessageRequeue = "message.requeue"
+	AuditActionAPIKeyCreate   = "api_key.create"
+	AuditActionAPIKeyRevoke   = "api_key.revoke"
+	AuditActionRouteCreate    = "routing_rule.create"
+	AuditActionRouteUpdate    = "routing_rule.update"
+	AuditActionRouteDelete    = "routing_rule.delete"
+)
+
+const (
+	AuditTargetScheduler = "scheduler"
+	AuditTargetMessage   = "message"
+	AuditTargetAPIKey    = "api_key"
+	AuditTargetRouteRule = "routing_rule"
+)
+
+// context'te actor yoksa islem servis tarafindan baslatilmistir (ornek: acilista scheduler auto-start)
+const SystemActorID = "system"
+
+// auth kapaliyken API uzerinden yapilan islemler bu actor ile yazilir
+const AnonymousActorID = "anonymous"
+
+// AuditLog sadece eklenir, guncellenmez ve silinmez. Before/After islemden onceki ve sonraki durumun JSON halidir.
+type AuditLog struct {
+	ID         uuid.UUID       `json:"id" db:"id"`
+	TenantID   *uuid.UUID      `json:"tenant_id,omitempty" db:"tenant_id"`
+	ActorID    string          `json:"actor_id" db:"actor_id"`
+	ActorName  string          `json:"actor_name,omitempty" db:"actor_name"`
+	AuthMethod string          `json:"auth_method,omitempty" db:"auth_method"`
+	IPAddress  string          `json:"ip_address,omitempty" db:"ip_address"`
+	Action     string          `json:"action" db:"action"`
+	TargetType string          `json:"target_type" db:"target_type"`
+	TargetID   string          `json:"target_id,omitempty" db:"target_id"`
+	Before     json.RawMessage `json:"before,omitempty" db:"before_state"`
+	After      json.RawMessage `json:"after,omitempty" db:"after_state"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AuditLogFilter bos alanlar filtrelenmez
+type AuditLogFilter struct {
+	TenantID   *uuid.UUID
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+}
+
+// Actor istegi yapan kimlik ve IP adresi, audit kayitlari icin context'te tasinir
+type Actor struct {
+	ID         string
+	Name       string
+	AuthMethod string
+	IPAddress  string
+}
+
+type actorContextKey struct{}
+
+func ContextWithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorContextKey{}).(Actor); ok && actor.ID != "" {
+		return actor
+	}
+	return Actor{ID: SystemActorID}
+}
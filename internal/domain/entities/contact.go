@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Contact struct {
+	ID          uuid.UUID         `json:"id" db:"id"`
+	TenantID    uuid.UUID         `json:"tenant_id" db:"tenant_id"`
+	PhoneNumber string            `json:"phone_number" db:"phone_number"`
+	Name        string            `json:"name" db:"name"`
+	Attributes  map[string]string `json:"attributes" db:"attributes"`
+	Suppressed  bool              `json:"suppressed" db:"suppressed"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+type ContactList struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	TenantID     uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	Name         string    `json:"name" db:"name"`
+	Description  string    `json:"description" db:"description"`
+	ContactCount int64     `json:"contact_count" db:"contact_count"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (c *Contact) Validate() error {
+	if c.PhoneNumber == "" {
+		return ErrInvalidPhoneNumber
+	}
+
+	return nil
+}
+
+// TemplateData broadcast sablonunda kullanilan alanlar, attribute'lar name/phone_number'i ezemez
+func (c *Contact) TemplateData() map[string]string {
+	data := make(map[string]string, len(c.Attributes)+2)
+	for key, value := range c.Attributes {
+		data[key] = value
+	}
+	data["name"] = c.Name
+	data["phone_number"] = c.PhoneNumber
+	return data
+}
+
+func (l *ContactList) Validate() error {
+	if l.Name == "" {
+		return ErrInvalidContactListName
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusDelivered   DeliveryStatus = "delivered"
+	DeliveryStatusUndelivered DeliveryStatus = "undelivered"
+	DeliveryStatusExpired     DeliveryStatus = "expired"
+	DeliveryStatusRejected    DeliveryStatus = "rejected"
+	DeliveryStatusUnknown     DeliveryStatus = "unknown"
+)
+
+// DeliveryReceipt provider'in gonderimden sonra bildirdigi teslim durumu. Mesaj Provider ve
+// ExternalMessageID (gonderimde donen id) ile bulunur.
+type DeliveryReceipt struct {
+	Provider          string
+	ExternalMessageID string
+	Status            DeliveryStatus
+	ErrorCode         string
+	ReportedAt        time.Time
+}
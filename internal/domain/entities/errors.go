@@ -4,9 +4,52 @@ import "errors"
 
 var (
 	ErrInvalidMessageContent   = errors.New("message content cannot be empty")
-	ErrMessageTooLong          = errors.New("message content exceeds 160 characters")
+	ErrMessageTooLong          = errors.New("message content exceeds 160 characters (email, webhook: 100000)")
 	ErrInvalidPhoneNumber      = errors.New("phone number cannot be empty")
+	ErrInvalidCategory         = errors.New("message category must be transactional or marketing")
+	ErrInvalidSenderID         = errors.New("sender id must be numeric E.164 or up to 11 alphanumeric characters")
+	ErrInvalidChannel          = errors.New("message channel must be sms, email or webhook")
+	ErrInvalidEmailAddress     = errors.New("email address is missing or invalid")
+	ErrInvalidEmailSubject     = errors.New("email subject must be 1-255 characters on a single line")
+	ErrInvalidWebhookURL       = errors.New("webhook url is missing or not an absolute http(s) url")
+	ErrWebhookHostNotAllowed   = errors.New("webhook host is not in the allowlist")
+	ErrChannelFieldMismatch    = errors.New("phone number and sender id are only for sms, email and subject only for email, webhook url only for webhook messages")
+	ErrChannelNotConfigured    = errors.New("no provider is configured for this message channel")
+	ErrSenderIDNotAllowed      = errors.New("sender id is not in the allowed sender list")
+	ErrContentPolicyViolation  = errors.New("message content violates content policy")
 	ErrMessageNotFound         = errors.New("message not found")
+	ErrMessageNotPending       = errors.New("only pending messages can be cancelled or edited")
+	ErrMessageNotRequeueable   = errors.New("only failed or cancelled messages can be requeued")
+	ErrShortLinkNotFound       = errors.New("short link not found")
+	ErrShortCodeTaken          = errors.New("short link code already exists")
+	ErrContactNotFound         = errors.New("contact not found")
+	ErrContactAlreadyExists    = errors.New("contact with this phone number already exists")
+	ErrContactListNotFound     = errors.New("contact list not found")
+	ErrInvalidContactListName  = errors.New("contact list name cannot be empty")
+	ErrInvalidMessageTemplate  = errors.New("message template is invalid")
+	ErrDuplicateMessage        = errors.New("identical message already accepted for this phone number")
+	ErrTenantRequired          = errors.New("tenant is required")
+	ErrTenantNotFound          = errors.New("tenant not found")
+	ErrInvalidTenantName       = errors.New("tenant name cannot be empty")
+	ErrInvalidAPIKey           = errors.New("api key is missing, invalid or revoked")
+	ErrInvalidToken            = errors.New("bearer token is invalid or expired")
+	ErrAPIKeyNotFound          = errors.New("api key not found")
+	ErrInvalidAPIKeyName       = errors.New("api key name cannot be empty")
+	ErrInvalidAPIKeyScope      = errors.New("api key scopes must be a non-empty list of known scopes")
+	ErrQuotaExceeded           = errors.New("tenant quota exceeded")
+	ErrQuotaNotFound           = errors.New("tenant has no quota override")
+	ErrInvalidQuota            = errors.New("quota limits must be zero or positive and soft limit percent between 0 and 100")
+	ErrInvalidPriceEntry       = errors.New("price entry needs a provider, a numeric prefix and a non-negative price")
+	ErrInvalidCostGroup        = errors.New("cost report group must be campaign, country or day")
+	ErrProviderRejected        = errors.New("message rejected by provider")
+	ErrUnknownProviderAdapter  = errors.New("unknown message provider adapter")
+	ErrInvalidProviderConfig   = errors.New("invalid message provider configuration")
+	ErrNoProviderAvailable     = errors.New("no message provider available")
+	ErrCircuitOpen             = errors.New("provider circuit breaker is open")
+	ErrProviderThrottled       = errors.New("provider is throttling requests")
+	ErrRoutingRuleNotFound     = errors.New("routing rule not found")
+	ErrInvalidRoutingRule      = errors.New("routing rule needs a name, a numeric prefix and unique providers with non-negative weights")
+	ErrUnknownRouteProvider    = errors.New("routing rule references a provider that is not configured")
 	ErrSchedulerNotRunning     = errors.New("scheduler is not running")
 	ErrSchedulerAlreadyRunning = errors.New("scheduler is already running")
 )
@@ -1,6 +1,12 @@
 package entities
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,30 +15,110 @@ import (
 type MessageStatus string
 
 const (
-	MessageStatusPending MessageStatus = "pending"
-	MessageStatusSent    MessageStatus = "sent"
-	MessageStatusFailed  MessageStatus = "failed"
+	MessageStatusPending   MessageStatus = "pending"
+	MessageStatusSent      MessageStatus = "sent"
+	MessageStatusFailed    MessageStatus = "failed"
+	MessageStatusCancelled MessageStatus = "cancelled"
+)
+
+type MessageCategory string
+
+const (
+	MessageCategoryTransactional MessageCategory = "transactional"
+	MessageCategoryMarketing     MessageCategory = "marketing"
+)
+
+// MessageChannel mesajin hangi kanaldan gidecegi; bos deger eski kayitlar icin sms sayilir
+type MessageChannel string
+
+const (
+	MessageChannelSMS     MessageChannel = "sms"
+	MessageChannelEmail   MessageChannel = "email"
+	MessageChannelWebhook MessageChannel = "webhook"
+)
+
+const (
+	maxSMSContentLength     = 160
+	maxEmailContentLength   = 100000
+	maxEmailSubjectLength   = 255
+	maxWebhookContentLength = 100000
+	maxWebhookURLLength     = 2048
+)
+
+var (
+	// alfanumerik originator: en fazla 11 karakter, en az bir harf (sadece rakamsa numeric sayilir)
+	alphanumericSenderIDPattern = regexp.MustCompile(`^[A-Za-z0-9 ]{1,11}$`)
+	numericSenderIDPattern      = regexp.MustCompile(`^\+?[1-9][0-9]{1,14}$`)
 )
 
 type Message struct {
-	ID          uuid.UUID     `json:"id" db:"id"`
-	Content     string        `json:"content" db:"content"`
-	PhoneNumber string        `json:"phone_number" db:"phone_number"`
-	Status      MessageStatus `json:"status" db:"status"`
-	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at" db:"updated_at"`
-	SentAt      *time.Time    `json:"sent_at,omitempty" db:"sent_at"`
+	ID          uuid.UUID       `json:"id" db:"id"`
+	TenantID    uuid.UUID       `json:"tenant_id" db:"tenant_id"`
+	Content     string          `json:"content" db:"content"`
+	PhoneNumber string          `json:"phone_number" db:"phone_number"`
+	Channel     MessageChannel  `json:"channel" db:"channel"`
+	Email       *string         `json:"email,omitempty" db:"email"`
+	Subject     *string         `json:"subject,omitempty" db:"subject"`
+	WebhookURL  *string         `json:"webhook_url,omitempty" db:"webhook_url"`
+	Status      MessageStatus   `json:"status" db:"status"`
+	Category    MessageCategory `json:"category" db:"category"`
+	Campaign    *string         `json:"campaign,omitempty" db:"campaign"`
+	SenderID    *string         `json:"sender_id,omitempty" db:"sender_id"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+	SentAt      *time.Time      `json:"sent_at,omitempty" db:"sent_at"`
 
 	ExternalMessageID *string `json:"external_message_id,omitempty" db:"external_message_id"`
 	ErrorMessage      *string `json:"error_message,omitempty" db:"error_message"`
+	// ErrorCode ErrorMessage'in sinifi, istatistikler bu alana gore gruplanir
+	ErrorCode *ErrorCode `json:"error_code,omitempty" db:"error_code"`
+	// Provider son gonderim denemesini yapan provider, basarisiz denemelerde de yazilir
+	Provider *string `json:"provider,omitempty" db:"provider"`
+	// Attempts basarisiz gonderim denemesi sayisi; gecici hatada mesaj NextAttemptAt'e kadar beklemede kalir
+	Attempts      int        `json:"attempts" db:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	// QuotaReservedOn mesaj kabul edilirken tenant kullanimina eklendigi gun; gonderilmeyen mesajin rezervasyonu bu gunden geri alinir
+	QuotaReservedOn       *time.Time `json:"-" db:"quota_reserved_on"`
+	QuotaReservedSegments int64      `json:"-" db:"quota_reserved_segments"`
+
+	// teslim raporu destekleyen provider'larda gonderimden sonra doldurulur
+	DeliveryStatus     *DeliveryStatus `json:"delivery_status,omitempty" db:"delivery_status"`
+	DeliveryError      *string         `json:"delivery_error,omitempty" db:"delivery_error"`
+	DeliveryReportedAt *time.Time      `json:"delivery_reported_at,omitempty" db:"delivery_reported_at"`
+
+	// fiyat tablosunda eslesen prefix yoksa maliyet alanlari bos kalir
+	CostPrefix    *string  `json:"cost_prefix,omitempty" db:"cost_prefix"`
+	EstimatedCost *float64 `json:"estimated_cost,omitempty" db:"estimated_cost"`
+	ActualCost    *float64 `json:"actual_cost,omitempty" db:"actual_cost"`
 }
 
+// Validate kanala gore alanlari kontrol eder: sms icin 160 karakter, numara ve originator kurallari;
+// email icin gecerli adres ve konu; webhook icin http(s) URL. Diger kanalin alanlari dolu gelirse reddedilir.
 func (m *Message) Validate() error {
 	if m.Content == "" {
 		return ErrInvalidMessageContent
 	}
 
-	if len(m.Content) > 160 { // SMS character limit
+	switch m.Category {
+	case "", MessageCategoryTransactional, MessageCategoryMarketing:
+	default:
+		return ErrInvalidCategory
+	}
+
+	switch m.Channel {
+	case "", MessageChannelSMS:
+		return m.validateSMS()
+	case MessageChannelEmail:
+		return m.validateEmail()
+	case MessageChannelWebhook:
+		return m.validateWebhook()
+	default:
+		return ErrInvalidChannel
+	}
+}
+
+func (m *Message) validateSMS() error {
+	if len(m.Content) > maxSMSContentLength {
 		return ErrMessageTooLong
 	}
 
@@ -40,15 +126,95 @@ func (m *Message) Validate() error {
 		return ErrInvalidPhoneNumber
 	}
 
+	if m.Email != nil || m.Subject != nil || m.WebhookURL != nil {
+		return ErrChannelFieldMismatch
+	}
+
+	if m.SenderID != nil && !IsValidSenderID(*m.SenderID) {
+		return ErrInvalidSenderID
+	}
+
 	return nil
 }
 
+func (m *Message) validateEmail() error {
+	if len(m.Content) > maxEmailContentLength {
+		return ErrMessageTooLong
+	}
+
+	if m.Email == nil || !IsValidEmailAddress(*m.Email) {
+		return ErrInvalidEmailAddress
+	}
+
+	if m.Subject == nil || strings.TrimSpace(*m.Subject) == "" || len(*m.Subject) > maxEmailSubjectLength ||
+		strings.ContainsAny(*m.Subject, "\r\n") {
+		return ErrInvalidEmailSubject
+	}
+
+	// gonderen adresi SMTP_FROM'dan gelir
+	if m.PhoneNumber != "" || m.SenderID != nil || m.WebhookURL != nil {
+		return ErrChannelFieldMismatch
+	}
+
+	return nil
+}
+
+func (m *Message) validateWebhook() error {
+	if len(m.Content) > maxWebhookContentLength {
+		return ErrMessageTooLong
+	}
+
+	if m.WebhookURL == nil || !IsValidWebhookURL(*m.WebhookURL) {
+		return ErrInvalidWebhookURL
+	}
+
+	if m.PhoneNumber != "" || m.SenderID != nil || m.Email != nil || m.Subject != nil {
+		return ErrChannelFieldMismatch
+	}
+
+	return nil
+}
+
+// IsValidWebhookURL host'u olan mutlak bir http(s) URL'i bekler; kullanici bilgisi ve fragment kabul edilmez
+func IsValidWebhookURL(rawURL string) bool {
+	if len(rawURL) > maxWebhookURLLength {
+		return false
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" || parsed.User != nil || parsed.Fragment != "" {
+		return false
+	}
+	return parsed.Scheme == "http" || parsed.Scheme == "https"
+}
+
+// IsValidEmailAddress sadece "kullanici@alan" bicimini kabul eder, "Ad <adres>" ya da liste kabul etmez
+func IsValidEmailAddress(address string) bool {
+	parsed, err := mail.ParseAddress(address)
+	return err == nil && parsed.Address == address && parsed.Name == ""
+}
+
+// IsValidSenderID numeric (E.164) ya da 11 karakterlik alfanumerik originator kuralini kontrol eder.
+func IsValidSenderID(senderID string) bool {
+	if numericSenderIDPattern.MatchString(senderID) {
+		return true
+	}
+
+	if !alphanumericSenderIDPattern.MatchString(senderID) || strings.TrimSpace(senderID) != senderID {
+		return false
+	}
+
+	return strings.IndexFunc(senderID, func(r rune) bool {
+		return (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z')
+	}) >= 0
+}
+
 func (m *Message) MarkAsSent(externalMessageID string) {
 	now := time.Now()
 	m.Status = MessageStatusSent
 	m.SentAt = &now
 	m.UpdatedAt = now
 	m.ExternalMessageID = &externalMessageID
+	m.NextAttemptAt = nil
 }
 
 func (m *Message) MarkAsFailed(errorMsg string) {
@@ -57,6 +223,95 @@ func (m *Message) MarkAsFailed(errorMsg string) {
 	m.ErrorMessage = &errorMsg
 }
 
+// MarkSendFailed provider hatasini mesaja sinifiyla birlikte yazar
+func (m *Message) MarkSendFailed(err error) {
+	m.MarkAsFailed(err.Error())
+	m.recordAttempt(err)
+	m.NextAttemptAt = nil
+}
+
+// ScheduleRetry gecici hatada mesaj pending kalir; hata yazilir ve scheduler delay dolmadan mesaji almaz
+func (m *Message) ScheduleRetry(err error, delay time.Duration) {
+	m.recordAttempt(err)
+	m.UpdatedAt = time.Now()
+	nextAttemptAt := m.UpdatedAt.Add(delay)
+	m.NextAttemptAt = &nextAttemptAt
+}
+
+// HoldUntil kota dolunca mesaj pending kalir ve scheduler until'e kadar almaz; deneme sayilmaz
+func (m *Message) HoldUntil(until time.Time) {
+	m.UpdatedAt = time.Now()
+	m.NextAttemptAt = &until
+}
+
+func (m *Message) recordAttempt(err error) {
+	m.Attempts++
+	errorMsg := err.Error()
+	m.ErrorMessage = &errorMsg
+	code := ErrorCodeOf(err)
+	m.ErrorCode = &code
+}
+
+// ApplyDeliveryReceipt sadece teslim bilgisini yazar, mesajin status'u sent kalir
+func (m *Message) ApplyDeliveryReceipt(receipt DeliveryReceipt) {
+	status := receipt.Status
+	m.DeliveryStatus = &status
+	m.DeliveryError = nil
+	if receipt.ErrorCode != "" {
+		errorCode := receipt.ErrorCode
+		m.DeliveryError = &errorCode
+	}
+	reportedAt := receipt.ReportedAt
+	m.DeliveryReportedAt = &reportedAt
+	m.UpdatedAt = time.Now()
+}
+
+// QuotaReservation mesaj icin kotadan ayrilan kullanim, rezervasyon yoksa nil
+func (m *Message) QuotaReservation() *QuotaReservation {
+	if m.QuotaReservedOn == nil {
+		return nil
+	}
+	return &QuotaReservation{Day: *m.QuotaReservedOn, Messages: 1, Segments: m.QuotaReservedSegments}
+}
+
+// SetQuotaReservation nil verilirse rezervasyon silinir
+func (m *Message) SetQuotaReservation(reservation *QuotaReservation) {
+	if reservation == nil {
+		m.QuotaReservedOn = nil
+		m.QuotaReservedSegments = 0
+		return
+	}
+	day := reservation.Day
+	m.QuotaReservedOn = &day
+	m.QuotaReservedSegments = reservation.Segments
+}
+
+// Cancel sadece henuz gonderilmemis (pending) mesajlar icin gecerli
+func (m *Message) Cancel() error {
+	if !m.IsPending() {
+		return ErrMessageNotPending
+	}
+
+	m.Status = MessageStatusCancelled
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// Requeue failed ya da cancelled mesaji scheduler'in tekrar almasi icin pending'e ceker
+func (m *Message) Requeue() error {
+	if m.Status != MessageStatusFailed && m.Status != MessageStatusCancelled {
+		return ErrMessageNotRequeueable
+	}
+
+	m.Status = MessageStatusPending
+	m.ErrorMessage = nil
+	m.ErrorCode = nil
+	m.Attempts = 0
+	m.NextAttemptAt = nil
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
 func (m *Message) IsPending() bool {
 	return m.Status == MessageStatusPending
 }
@@ -64,3 +319,79 @@ func (m *Message) IsPending() bool {
 func (m *Message) IsSent() bool {
 	return m.Status == MessageStatusSent
 }
+
+// IsSMS kanal bos olan eski kayitlar da SMS sayilir
+func (m *Message) IsSMS() bool {
+	return m.Channel == "" || m.Channel == MessageChannelSMS
+}
+
+func (m *Message) IsEmail() bool {
+	return m.Channel == MessageChannelEmail
+}
+
+func (m *Message) IsWebhook() bool {
+	return m.Channel == MessageChannelWebhook
+}
+
+// Recipient kanala gore alicinin numarasi, e-posta adresi ya da webhook URL'i
+func (m *Message) Recipient() string {
+	switch {
+	case m.IsEmail() && m.Email != nil:
+		return *m.Email
+	case m.IsWebhook() && m.WebhookURL != nil:
+		return *m.WebhookURL
+	}
+	return m.PhoneNumber
+}
+
+// Segments kota icin SMS parca sayisi; e-posta ve webhook her zaman tek mesaj sayilir
+func (m *Message) Segments() int {
+	if !m.IsSMS() {
+		return 1
+	}
+	return SegmentCount(m.Content)
+}
+
+// SetEstimatedCost olusturma ya da duzenleme aninda hesaplanan tahmini maliyeti yazar
+func (m *Message) SetEstimatedCost(cost MessageCost) {
+	m.CostPrefix = &cost.Prefix
+	m.EstimatedCost = &cost.Amount
+}
+
+// SetActualCost gonderimi yapan provider'in fiyatiyla hesaplanan gercek maliyeti yazar
+func (m *Message) SetActualCost(cost MessageCost) {
+	m.CostPrefix = &cost.Prefix
+	m.ActualCost = &cost.Amount
+}
+
+func (m *Message) IsMarketing() bool {
+	return m.Category == MessageCategoryMarketing
+}
+
+// Fingerprint ayni tenant'tan ayni aliciya giden ayni icerigi tespit etmek icin kullanilir.
+// Icerik kucuk harfe cevrilip bosluklar sadelestirilir, numaradan da ayrac karakterleri atilir;
+// e-posta adresi kucuk harfe cevrilir, konu da icerige dahil edilir. Webhook URL'i oldugu gibi kullanilir.
+func (m *Message) Fingerprint() string {
+	content := strings.Join(strings.Fields(strings.ToLower(m.Content)), " ")
+	if !m.IsSMS() {
+		recipient, subject := m.Recipient(), ""
+		if m.IsEmail() {
+			recipient = strings.ToLower(recipient)
+		}
+		if m.Subject != nil {
+			subject = strings.Join(strings.Fields(strings.ToLower(*m.Subject)), " ")
+		}
+		sum := sha256.Sum256([]byte(m.TenantID.String() + "\x00" + string(m.Channel) + "\x00" + recipient + "\x00" + subject + "\x00" + content))
+		return hex.EncodeToString(sum[:])
+	}
+
+	phone := strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '+' {
+			return r
+		}
+		return -1
+	}, m.PhoneNumber)
+
+	sum := sha256.Sum256([]byte(m.TenantID.String() + "\x00" + phone + "\x00" + content))
+	return hex.EncodeToString(sum[:])
+}
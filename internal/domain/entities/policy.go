@@ -0,0 +1,26 @@
+package entities
+
+import "strings"
+
+type PolicyViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PolicyViolationError icerik kurallarina takilan tum ihlalleri tasir,
+// errors.Is ile ErrContentPolicyViolation olarak yakalanabilir.
+type PolicyViolationError struct {
+	Violations []PolicyViolation
+}
+
+func (e *PolicyViolationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Rule + ": " + v.Message
+	}
+	return ErrContentPolicyViolation.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *PolicyViolationError) Unwrap() error {
+	return ErrContentPolicyViolation
+}
@@ -0,0 +1,144 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AnyProvider fiyat tablosunda her provider icin gecerli satiri belirtir
+const AnyProvider = "*"
+
+const (
+	CostGroupCampaign = "campaign"
+	CostGroupCountry  = "country"
+	CostGroupDay      = "day"
+)
+
+// PriceEntry bir provider'in bir ulke (ya da daha dar) prefix'ine segment basi fiyati
+type PriceEntry struct {
+	Provider        string  `json:"provider"`
+	Prefix          string  `json:"prefix"`
+	PricePerSegment float64 `json:"price_per_segment"`
+}
+
+// PriceTable en uzun prefix eslesmesiyle fiyat bulur; provider'a ozel satir "*" satirindan once gelir
+type PriceTable struct {
+	entries []PriceEntry
+}
+
+func NewPriceTable(entries []PriceEntry) (*PriceTable, error) {
+	seen := make(map[string]bool, len(entries))
+	table := &PriceTable{entries: make([]PriceEntry, 0, len(entries))}
+
+	for _, entry := range entries {
+		if entry.Provider == "" || entry.Prefix == "" || strings.Trim(entry.Prefix, "0123456789") != "" {
+			return nil, fmt.Errorf("%w: provider %q prefix %q", ErrInvalidPriceEntry, entry.Provider, entry.Prefix)
+		}
+		if entry.PricePerSegment < 0 || math.IsNaN(entry.PricePerSegment) || math.IsInf(entry.PricePerSegment, 0) {
+			return nil, fmt.Errorf("%w: price for %s/%s", ErrInvalidPriceEntry, entry.Provider, entry.Prefix)
+		}
+
+		key := entry.Provider + "/" + entry.Prefix
+		if seen[key] {
+			return nil, fmt.Errorf("%w: duplicate %s", ErrInvalidPriceEntry, key)
+		}
+		seen[key] = true
+		table.entries = append(table.entries, entry)
+	}
+
+	sort.SliceStable(table.entries, func(i, j int) bool {
+		return len(table.entries[i].Prefix) > len(table.entries[j].Prefix)
+	})
+
+	return table, nil
+}
+
+func (t *PriceTable) Entries() []PriceEntry {
+	if t == nil {
+		return nil
+	}
+	entries := make([]PriceEntry, len(t.entries))
+	copy(entries, t.entries)
+	return entries
+}
+
+func (t *PriceTable) Lookup(provider, phoneNumber string) (PriceEntry, bool) {
+	if t == nil {
+		return PriceEntry{}, false
+	}
+
+	digits := phoneDigits(phoneNumber)
+	for _, candidate := range []string{provider, AnyProvider} {
+		for _, entry := range t.entries {
+			if entry.Provider == candidate && strings.HasPrefix(digits, entry.Prefix) {
+				return entry, true
+			}
+		}
+	}
+	return PriceEntry{}, false
+}
+
+// MessageCost bir mesajin fiyatlandirmasi; Amount = PricePerSegment * Segments
+type MessageCost struct {
+	Provider        string
+	Prefix          string
+	PricePerSegment float64
+	Segments        int
+	Amount          float64
+}
+
+// Cost mesajin mevcut icerigi ve numarasina gore maliyetini hesaplar, fiyat yoksa false doner
+func (t *PriceTable) Cost(provider string, message *Message) (MessageCost, bool) {
+	// fiyat tablosu SMS parca fiyati, e-posta ve webhook ucretlendirilmez
+	if !message.IsSMS() {
+		return MessageCost{}, false
+	}
+
+	entry, ok := t.Lookup(provider, message.PhoneNumber)
+	if !ok {
+		return MessageCost{}, false
+	}
+
+	segments := message.Segments()
+	return MessageCost{
+		Provider:        provider,
+		Prefix:          entry.Prefix,
+		PricePerSegment: entry.PricePerSegment,
+		Segments:        segments,
+		Amount:          RoundCost(entry.PricePerSegment * float64(segments)),
+	}, true
+}
+
+// RoundCost tutarlari veritabanindaki NUMERIC(14,6) hassasiyetine yuvarlar
+func RoundCost(amount float64) float64 {
+	return math.Round(amount*1e6) / 1e6
+}
+
+// CostReportFilter'da bos alanlar uygulanmaz
+type CostReportFilter struct {
+	GroupBy  string
+	Campaign *string
+	From     *time.Time
+	To       *time.Time
+}
+
+// CostReportRow GroupBy'a gore (kampanya, ulke prefix'i ya da gun) toplam maliyet
+type CostReportRow struct {
+	Key           string
+	Messages      int64
+	SentMessages  int64
+	EstimatedCost float64
+	ActualCost    float64
+}
+
+func phoneDigits(phoneNumber string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phoneNumber)
+}
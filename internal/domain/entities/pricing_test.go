@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewPriceTable_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry PriceEntry
+	}{
+		{"missing provider", PriceEntry{Prefix: "90", PricePerSegment: 0.01}},
+		{"non numeric prefix", PriceEntry{Provider: "default", Prefix: "+90", PricePerSegment: 0.01}},
+		{"negative price", PriceEntry{Provider: "default", Prefix: "90", PricePerSegment: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPriceTable([]PriceEntry{tt.entry}); !errors.Is(err, ErrInvalidPriceEntry) {
+				t.Errorf("Expected ErrInvalidPriceEntry, got %v", err)
+			}
+		})
+	}
+
+	duplicate := PriceEntry{Provider: "default", Prefix: "90", PricePerSegment: 0.01}
+	if _, err := NewPriceTable([]PriceEntry{duplicate, duplicate}); !errors.Is(err, ErrInvalidPriceEntry) {
+		t.Errorf("Expected ErrInvalidPriceEntry for duplicate entry, got %v", err)
+	}
+}
+
+func TestPriceTable_Lookup(t *testing.T) {
+	table, err := NewPriceTable([]PriceEntry{
+		{Provider: AnyProvider, Prefix: "1", PricePerSegment: 0.008},
+		{Provider: AnyProvider, Prefix: "90", PricePerSegment: 0.02},
+		{Provider: "acme", Prefix: "90", PricePerSegment: 0.015},
+		{Provider: "acme", Prefix: "90532", PricePerSegment: 0.012},
+	})
+	if err != nil {
+		t.Fatalf("Expected valid table, got %v", err)
+	}
+
+	tests := []struct {
+		provider   string
+		phone      string
+		wantPrefix string
+		wantPrice  float64
+		wantOK     bool
+	}{
+		{"acme", "+90 532 111 2233", "90532", 0.012, true},
+		{"acme", "+905551112233", "90", 0.015, true},
+		{"other", "+905321112233", "90", 0.02, true},
+		{"acme", "+12025550123", "1", 0.008, true},
+		{"acme", "+442071234567", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider+tt.phone, func(t *testing.T) {
+			entry, ok := table.Lookup(tt.provider, tt.phone)
+			if ok != tt.wantOK || entry.Prefix != tt.wantPrefix || entry.PricePerSegment != tt.wantPrice {
+				t.Errorf("Lookup() = %+v, %v; want prefix %q price %v ok %v", entry, ok, tt.wantPrefix, tt.wantPrice, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPriceTable_Cost(t *testing.T) {
+	table, _ := NewPriceTable([]PriceEntry{{Provider: AnyProvider, Prefix: "90", PricePerSegment: 0.0125}})
+
+	message := &Message{PhoneNumber: "+905551112233", Content: strings.Repeat("a", 200)}
+	cost, ok := table.Cost("default", message)
+	if !ok {
+		t.Fatal("Expected a price for +90")
+	}
+	if cost.Segments != 2 || cost.Amount != 0.025 || cost.Prefix != "90" {
+		t.Errorf("Unexpected cost: %+v", cost)
+	}
+
+	var empty *PriceTable
+	if _, ok := empty.Cost("default", message); ok {
+		t.Error("Expected no price from an empty table")
+	}
+}
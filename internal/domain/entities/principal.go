@@ -0,0 +1,26 @@
+package entities
+
+import "github.com/google/uuid"
+
+const (
+	AuthMethodAPIKey = "api_key"
+	AuthMethodJWT    = "jwt"
+)
+
+// Principal dogrulanmis istegin sahibi; api key ya da JWT fark etmeksizin route'lar bunu kullanir
+type Principal struct {
+	Subject    string    `json:"subject"`
+	Name       string    `json:"name,omitempty"`
+	TenantID   uuid.UUID `json:"tenant_id"`
+	Scopes     []string  `json:"scopes"`
+	AuthMethod string    `json:"auth_method"`
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SendRequest provider'a iletilen mesaj; SenderID bos ise provider kendi varsayilanini kullanir.
+// Email ve Subject sadece email, WebhookURL sadece webhook kanalinda dolu.
+type SendRequest struct {
+	MessageID   uuid.UUID
+	TenantID    uuid.UUID
+	Channel     MessageChannel
+	PhoneNumber string
+	Email       string
+	Subject     string
+	WebhookURL  string
+	Content     string
+	SenderID    string
+	Category    MessageCategory
+}
+
+// SendResult'ta Provider mesaji gercekten kabul eden provider, failover'da ilk siradakinden farkli olabilir
+type SendResult struct {
+	Provider          string
+	ExternalMessageID string
+}
+
+func NewSendRequest(message *Message) SendRequest {
+	request := SendRequest{
+		MessageID:   message.ID,
+		TenantID:    message.TenantID,
+		Channel:     message.Channel,
+		PhoneNumber: message.PhoneNumber,
+		Content:     message.Content,
+		Category:    message.Category,
+	}
+	if request.Channel == "" {
+		request.Channel = MessageChannelSMS
+	}
+	if message.SenderID != nil {
+		request.SenderID = *message.SenderID
+	}
+	if message.Email != nil {
+		request.Email = *message.Email
+	}
+	if message.Subject != nil {
+		request.Subject = *message.Subject
+	}
+	if message.WebhookURL != nil {
+		request.WebhookURL = *message.WebhookURL
+	}
+	return request
+}
+
+// ProviderSendError basarisiz gonderimde son denenen provider'i tasir
+type ProviderSendError struct {
+	Provider string
+	Err      error
+}
+
+func (e *ProviderSendError) Error() string {
+	return fmt.Sprintf("provider %s: %v", e.Provider, e.Err)
+}
+
+func (e *ProviderSendError) Unwrap() error {
+	return e.Err
+}
+
+// ProviderThrottledError provider 429/503 ile yavaslamamizi istediginde ya da yerel rate limit
+// token vermediginde doner. RetryAfter 0 ise provider bir sure belirtmemistir.
+type ProviderThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ProviderThrottledError) Error() string {
+	if e.RetryAfter <= 0 {
+		return ErrProviderThrottled.Error()
+	}
+	return fmt.Sprintf("%v, retry after %s", ErrProviderThrottled, e.RetryAfter)
+}
+
+func (e *ProviderThrottledError) Unwrap() error {
+	return ErrProviderThrottled
+}
+
+// IsSendDeferred gonderim provider'in hatasi yuzunden degil devre acik oldugu ya da throttle edildigi icin
+// yapilamadiysa true; bu durumda mesaj failed yapilmaz, pending kalip sonraki batch'te tekrar denenir
+func IsSendDeferred(err error) bool {
+	return errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrProviderThrottled)
+}
+
+// ErrorCode basarisiz gonderimin sinifi; mesajda error_code olarak saklanir. Kalici siniflarda mesaj
+// baska provider'a denenmez, digerlerinde failover siradaki provider'a gecer.
+type ErrorCode string
+
+const (
+	ErrorCodeNetwork          ErrorCode = "network"
+	ErrorCodeTimeout          ErrorCode = "timeout"
+	ErrorCodeThrottled        ErrorCode = "throttled"
+	ErrorCodeInvalidRecipient ErrorCode = "invalid_recipient"
+	ErrorCodeBlockedContent   ErrorCode = "blocked_content"
+	ErrorCodeAuth             ErrorCode = "auth"
+	ErrorCodeProviderError    ErrorCode = "provider_error"
+	ErrorCodeRejected         ErrorCode = "rejected"
+	// ErrorCodePartialDelivery uzun mesajin bazi parcalari kabul edildikten sonra gonderim koptu; tekrar denemek parcalari ikiler
+	ErrorCodePartialDelivery ErrorCode = "partial_delivery"
+	ErrorCodeUnknown         ErrorCode = "unknown"
+)
+
+// IsPermanent alici, icerik ya da mesajin kendisi yuzunden olan hatalar; tekrar denemek sonucu degistirmez
+func (c ErrorCode) IsPermanent() bool {
+	switch c {
+	case ErrorCodeInvalidRecipient, ErrorCodeBlockedContent, ErrorCodeRejected, ErrorCodePartialDelivery:
+		return true
+	default:
+		return false
+	}
+}
+
+// ProviderError adapter'larin siniflandirdigi hata. Kalici siniflar errors.Is(err, ErrProviderRejected)'a
+// da uyar, boylece ret kontrolu yapan eski kod ayni calisir.
+type ProviderError struct {
+	Code ErrorCode
+	Err  error
+}
+
+func NewProviderError(code ErrorCode, err error) *ProviderError {
+	return &ProviderError{Code: code, Err: err}
+}
+
+func (e *ProviderError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ProviderError) Is(target error) bool {
+	return target == ErrProviderRejected && e.Code.IsPermanent()
+}
+
+// ErrorCodeOf hata zincirindeki ilk ProviderError'in sinifi; siniflandirilmamis hatalar icin
+// bilinen sentinel'lere bakilir, hicbiri yoksa ErrorCodeUnknown
+func ErrorCodeOf(err error) ErrorCode {
+	var providerErr *ProviderError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &providerErr):
+		return providerErr.Code
+	case errors.Is(err, ErrProviderThrottled):
+		return ErrorCodeThrottled
+	case errors.Is(err, ErrProviderRejected):
+		return ErrorCodeRejected
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeTimeout
+	default:
+		return ErrorCodeUnknown
+	}
+}
+
+// ProviderHealth bir provider'in son denemelerine gore hesaplanan saglik durumu.
+// Score (1 - ErrorRate) ile gecikme cezasinin carpimi, 0 ile 1 arasi.
+type ProviderHealth struct {
+	Name          string
+	Priority      int
+	Healthy       bool
+	Score         float64
+	ErrorRate     float64
+	AvgLatency    time.Duration
+	Attempts      int
+	Failures      int
+	LastError     *string
+	LastFailureAt *time.Time
+	LastSuccessAt *time.Time
+	Circuit       CircuitState
+	CircuitOpenAt *time.Time
+}
+
+// CircuitState provider'in circuit breaker durumu; open iken provider hic cagrilmaz
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half_open"
+)
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		want      ErrorCode
+		permanent bool
+	}{
+		{name: "no error", err: nil, want: ""},
+		{name: "classified", err: NewProviderError(ErrorCodeInvalidRecipient, errors.New("unknown subscriber")), want: ErrorCodeInvalidRecipient, permanent: true},
+		{name: "classified behind send error", err: &ProviderSendError{Provider: "smsc", Err: NewProviderError(ErrorCodeAuth, errors.New("bind failed"))}, want: ErrorCodeAuth},
+		{name: "partial delivery", err: NewProviderError(ErrorCodePartialDelivery, errors.New("second segment failed")), want: ErrorCodePartialDelivery, permanent: true},
+		{name: "throttled", err: fmt.Errorf("HTTP 429: %w", &ProviderThrottledError{}), want: ErrorCodeThrottled},
+		{name: "unclassified rejection", err: fmt.Errorf("%w: blocked", ErrProviderRejected), want: ErrorCodeRejected, permanent: true},
+		{name: "deadline", err: fmt.Errorf("send: %w", context.DeadlineExceeded), want: ErrorCodeTimeout},
+		{name: "anything else", err: errors.New("boom"), want: ErrorCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := ErrorCodeOf(tt.err)
+			if code != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, code)
+			}
+			if code.IsPermanent() != tt.permanent {
+				t.Errorf("Expected permanent=%v for %s", tt.permanent, code)
+			}
+		})
+	}
+}
+
+func TestProviderError_IsRejected(t *testing.T) {
+	if !errors.Is(NewProviderError(ErrorCodeBlockedContent, errors.New("spam")), ErrProviderRejected) {
+		t.Error("Expected permanent provider error to match ErrProviderRejected")
+	}
+	if errors.Is(NewProviderError(ErrorCodeNetwork, errors.New("reset")), ErrProviderRejected) {
+		t.Error("Expected transient provider error not to match ErrProviderRejected")
+	}
+}
@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RouteTarget kuralin yonlendirdigi provider. Weight > 0 olanlar arasinda siralama agirliga gore
+// rastgele yapilir; Weight 0 olanlar sadece yedek olarak, listedeki sirayla denenir.
+type RouteTarget struct {
+	Provider string `json:"provider"`
+	Weight   int    `json:"weight"`
+}
+
+// RoutingRule telefon prefix'ine (ve istege bagli tenant ya da kategoriye) gore provider sirasini belirler.
+// Bos prefix tum numaralarla eslesir.
+type RoutingRule struct {
+	ID        uuid.UUID        `json:"id" db:"id"`
+	Name      string           `json:"name" db:"name"`
+	Prefix    string           `json:"prefix" db:"prefix"`
+	TenantID  *uuid.UUID       `json:"tenant_id,omitempty" db:"tenant_id"`
+	Category  *MessageCategory `json:"category,omitempty" db:"category"`
+	Targets   []RouteTarget    `json:"targets" db:"targets"`
+	Enabled   bool             `json:"enabled" db:"enabled"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+func (r *RoutingRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" || strings.Trim(r.Prefix, "0123456789") != "" || len(r.Targets) == 0 {
+		return ErrInvalidRoutingRule
+	}
+
+	if r.Category != nil && *r.Category != MessageCategoryTransactional && *r.Category != MessageCategoryMarketing {
+		return ErrInvalidCategory
+	}
+
+	seen := make(map[string]bool, len(r.Targets))
+	for _, target := range r.Targets {
+		if target.Provider == "" || target.Weight < 0 || seen[target.Provider] {
+			return ErrInvalidRoutingRule
+		}
+		seen[target.Provider] = true
+	}
+
+	return nil
+}
+
+func (r *RoutingRule) Matches(request SendRequest) bool {
+	if r.TenantID != nil && *r.TenantID != request.TenantID {
+		return false
+	}
+	if r.Category != nil && *r.Category != request.Category {
+		return false
+	}
+	return strings.HasPrefix(phoneDigits(request.PhoneNumber), r.Prefix)
+}
+
+// moreSpecificThan uzun prefix once gelir; esitlikte tenant'a, sonra kategoriye ozel kural kazanir
+func (r *RoutingRule) moreSpecificThan(other *RoutingRule) bool {
+	if len(r.Prefix) != len(other.Prefix) {
+		return len(r.Prefix) > len(other.Prefix)
+	}
+	if (r.TenantID != nil) != (other.TenantID != nil) {
+		return r.TenantID != nil
+	}
+	if (r.Category != nil) != (other.Category != nil) {
+		return r.Category != nil
+	}
+	return r.CreatedAt.Before(other.CreatedAt)
+}
+
+// RoutingTable aktif kurallari en ozelden en genele sirali tutar, ilk eslesen kural kullanilir
+type RoutingTable struct {
+	rules []*RoutingRule
+}
+
+func NewRoutingTable(rules []*RoutingRule) *RoutingTable {
+	table := &RoutingTable{}
+	for _, rule := range rules {
+		if rule.Enabled {
+			table.rules = append(table.rules, rule)
+		}
+	}
+
+	sort.SliceStable(table.rules, func(i, j int) bool {
+		return table.rules[i].moreSpecificThan(table.rules[j])
+	})
+
+	return table
+}
+
+// Match eslesen kural yoksa nil doner
+func (t *RoutingTable) Match(request SendRequest) *RoutingRule {
+	if t == nil {
+		return nil
+	}
+
+	for _, rule := range t.rules {
+		if rule.Matches(request) {
+			return rule
+		}
+	}
+	return nil
+}
+
+func (t *RoutingTable) Len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.rules)
+}
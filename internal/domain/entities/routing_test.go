@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRoutingRule_Validate(t *testing.T) {
+	marketing := MessageCategoryMarketing
+	invalidCategory := MessageCategory("promo")
+
+	tests := []struct {
+		name    string
+		rule    RoutingRule
+		wantErr error
+	}{
+		{"valid", RoutingRule{Name: "tr", Prefix: "90", Category: &marketing, Targets: []RouteTarget{{Provider: "a", Weight: 1}}}, nil},
+		{"empty prefix matches all", RoutingRule{Name: "all", Targets: []RouteTarget{{Provider: "a"}}}, nil},
+		{"missing name", RoutingRule{Prefix: "90", Targets: []RouteTarget{{Provider: "a"}}}, ErrInvalidRoutingRule},
+		{"non numeric prefix", RoutingRule{Name: "tr", Prefix: "+90", Targets: []RouteTarget{{Provider: "a"}}}, ErrInvalidRoutingRule},
+		{"no targets", RoutingRule{Name: "tr", Prefix: "90"}, ErrInvalidRoutingRule},
+		{"negative weight", RoutingRule{Name: "tr", Targets: []RouteTarget{{Provider: "a", Weight: -1}}}, ErrInvalidRoutingRule},
+		{"duplicate provider", RoutingRule{Name: "tr", Targets: []RouteTarget{{Provider: "a"}, {Provider: "a"}}}, ErrInvalidRoutingRule},
+		{"invalid category", RoutingRule{Name: "tr", Category: &invalidCategory, Targets: []RouteTarget{{Provider: "a"}}}, ErrInvalidCategory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRoutingTable_Match(t *testing.T) {
+	tenantID := uuid.New()
+	marketing := MessageCategoryMarketing
+	now := time.Now()
+
+	global := &RoutingRule{Name: "global", Enabled: true, CreatedAt: now}
+	turkey := &RoutingRule{Name: "turkey", Prefix: "90", Enabled: true, CreatedAt: now}
+	istanbul := &RoutingRule{Name: "istanbul-mobile", Prefix: "90532", Enabled: true, CreatedAt: now}
+	turkeyTenant := &RoutingRule{Name: "turkey-tenant", Prefix: "90", TenantID: &tenantID, Enabled: true, CreatedAt: now}
+	turkeyMarketing := &RoutingRule{Name: "turkey-marketing", Prefix: "90", Category: &marketing, Enabled: true, CreatedAt: now}
+	disabled := &RoutingRule{Name: "disabled", Prefix: "9053212", Enabled: false, CreatedAt: now}
+
+	table := NewRoutingTable([]*RoutingRule{global, turkey, istanbul, turkeyTenant, turkeyMarketing, disabled})
+
+	tests := []struct {
+		name    string
+		request SendRequest
+		want    *RoutingRule
+	}{
+		{"longest prefix wins", SendRequest{PhoneNumber: "+90 532 123 45 67"}, istanbul},
+		{"tenant rule beats category rule on same prefix", SendRequest{PhoneNumber: "+905051234567", TenantID: tenantID, Category: MessageCategoryMarketing}, turkeyTenant},
+		{"category rule", SendRequest{PhoneNumber: "+905051234567", Category: MessageCategoryMarketing}, turkeyMarketing},
+		{"country rule", SendRequest{PhoneNumber: "+905051234567", Category: MessageCategoryTransactional}, turkey},
+		{"global fallback", SendRequest{PhoneNumber: "+441234567890"}, global},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := table.Match(tt.request); got != tt.want {
+				t.Errorf("Expected rule %s, got %v", tt.want.Name, got)
+			}
+		})
+	}
+
+	if table.Len() != 5 {
+		t.Errorf("Expected disabled rule to be dropped, got %d rules", table.Len())
+	}
+	if NewRoutingTable(nil).Match(SendRequest{PhoneNumber: "+90"}) != nil {
+		t.Error("Expected no match on empty table")
+	}
+}
@@ -10,12 +10,13 @@ const (
 )
 
 type SchedulerInfo struct {
-	Status        SchedulerStatus `json:"status"`
-	LastRun       *time.Time      `json:"last_run,omitempty"`
-	NextRun       *time.Time      `json:"next_run,omitempty"`
-	MessagesCount int             `json:"messages_sent_count"`
-	Interval      time.Duration   `json:"interval"`
-	BatchSize     int             `json:"batch_size"`
+	Status        SchedulerStatus  `json:"status"`
+	LastRun       *time.Time       `json:"last_run,omitempty"`
+	NextRun       *time.Time       `json:"next_run,omitempty"`
+	MessagesCount int              `json:"messages_sent_count"`
+	Interval      time.Duration    `json:"interval"`
+	BatchSize     int              `json:"batch_size"`
+	Providers     []ProviderHealth `json:"providers,omitempty"`
 }
 
 func (s *SchedulerInfo) IsRunning() bool {
@@ -0,0 +1,55 @@
+package entities
+
+import "strings"
+
+const (
+	gsm7SingleSegmentLength = 160
+	gsm7MultiSegmentLength  = 153
+	ucs2SingleSegmentLength = 70
+	ucs2MultiSegmentLength  = 67
+)
+
+// GSM 03.38 temel karakter seti; extension tablosundakiler (escape ile) iki karakter sayilir
+const (
+	gsm7BasicCharset     = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsm7ExtensionCharset = "^{}\\[~]|€\f"
+)
+
+// SegmentCount icerigin operatorde kac SMS parcasi olarak faturalanacagini hesaplar.
+// GSM-7 disinda tek bir karakter bile varsa tum mesaj UCS-2 olarak kodlanir.
+func SegmentCount(content string) int {
+	if content == "" {
+		return 0
+	}
+
+	septets := 0
+	unicode := false
+	units := 0
+	for _, r := range content {
+		// UCS-2'de BMP disindaki karakterler (emoji) iki birim tutar
+		if r > 0xFFFF {
+			units += 2
+		} else {
+			units++
+		}
+
+		switch {
+		case strings.ContainsRune(gsm7BasicCharset, r):
+			septets++
+		case strings.ContainsRune(gsm7ExtensionCharset, r):
+			septets += 2
+		default:
+			unicode = true
+		}
+	}
+
+	length, single, multi := septets, gsm7SingleSegmentLength, gsm7MultiSegmentLength
+	if unicode {
+		length, single, multi = units, ucs2SingleSegmentLength, ucs2MultiSegmentLength
+	}
+
+	if length <= single {
+		return 1
+	}
+	return (length + multi - 1) / multi
+}
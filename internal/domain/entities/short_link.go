@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShortLink struct {
+	Code      string    `json:"code" db:"code"`
+	TenantID  uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	MessageID uuid.UUID `json:"message_id" db:"message_id"`
+	Campaign  *string   `json:"campaign,omitempty" db:"campaign"`
+	TargetURL string    `json:"target_url" db:"target_url"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type LinkClick struct {
+	ID        int64     `json:"id" db:"id"`
+	Code      string    `json:"code" db:"code"`
+	TenantID  uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	MessageID uuid.UUID `json:"message_id" db:"message_id"`
+	Campaign  *string   `json:"campaign,omitempty" db:"campaign"`
+	ClickedAt time.Time `json:"clicked_at" db:"clicked_at"`
+	IPAddress string    `json:"ip_address" db:"ip_address"`
+	UserAgent string    `json:"user_agent" db:"user_agent"`
+}
+
+type LinkClickCount struct {
+	Code      string    `json:"code"`
+	MessageID uuid.UUID `json:"message_id"`
+	TargetURL string    `json:"target_url"`
+	Clicks    int64     `json:"clicks"`
+}
+
+func (l *ShortLink) NewClick(ipAddress, userAgent string) *LinkClick {
+	return &LinkClick{
+		Code:      l.Code,
+		TenantID:  l.TenantID,
+		MessageID: l.MessageID,
+		Campaign:  l.Campaign,
+		ClickedAt: time.Now(),
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	}
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTenantID tenant'i olmayan eski kayitlar ve header gondermeyen istemciler icin
+var DefaultTenantID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+type Tenant struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (t *Tenant) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrInvalidTenantName
+	}
+
+	return nil
+}
+
+type tenantContextKey struct{}
+
+// ContextWithTenant repository sorgulari tenant'i context'ten okur
+func ContextWithTenant(ctx context.Context, tenantID uuid.UUID) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+func TenantFromContext(ctx context.Context) (uuid.UUID, bool) {
+	tenantID, ok := ctx.Value(tenantContextKey{}).(uuid.UUID)
+	return tenantID, ok && tenantID != uuid.Nil
+}
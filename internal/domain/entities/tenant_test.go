@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTenantFromContext(t *testing.T) {
+	if _, ok := TenantFromContext(context.Background()); ok {
+		t.Error("Expected no tenant in empty context")
+	}
+
+	if _, ok := TenantFromContext(ContextWithTenant(context.Background(), uuid.Nil)); ok {
+		t.Error("Expected nil tenant ID to be treated as missing")
+	}
+
+	tenantID := uuid.New()
+	got, ok := TenantFromContext(ContextWithTenant(context.Background(), tenantID))
+	if !ok || got != tenantID {
+		t.Errorf("Expected tenant %v, got %v (ok=%v)", tenantID, got, ok)
+	}
+}
+
+func TestTenant_Validate(t *testing.T) {
+	if err := (&Tenant{Name: "  "}).Validate(); err != ErrInvalidTenantName {
+		t.Errorf("Expected ErrInvalidTenantName, got %v", err)
+	}
+
+	if err := (&Tenant{Name: "Growth"}).Validate(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UsagePeriodDay   = "day"
+	UsagePeriodMonth = "month"
+)
+
+const (
+	QuotaMetricMessages = "messages"
+	QuotaMetricSegments = "segments"
+)
+
+// UsageRecord bir tenant'in bir gun ya da aydaki gonderim sayaclari; PeriodStart UTC gun/ay basidir
+type UsageRecord struct {
+	TenantID    uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	Period      string    `json:"period"`
+	PeriodStart time.Time `json:"period_start" db:"day"`
+	Messages    int64     `json:"messages" db:"messages"`
+	Segments    int64     `json:"segments" db:"segments"`
+}
+
+// QuotaReservation mesaj kabul edilirken gunluk sayaca eklenen miktar; mesaj gonderilmezse ayni gunden geri alinir
+type QuotaReservation struct {
+	Day      time.Time
+	Messages int64
+	Segments int64
+}
+
+// TenantQuota hard limitleri tutar, 0 sinirsiz demektir. Soft limit hard limitin
+// SoftLimitPercent yuzdesidir; asildiginda gonderim durmaz, sadece uyari loglanir.
+type TenantQuota struct {
+	TenantID         uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	DailyMessages    int64     `json:"daily_messages" db:"daily_messages"`
+	DailySegments    int64     `json:"daily_segments" db:"daily_segments"`
+	MonthlyMessages  int64     `json:"monthly_messages" db:"monthly_messages"`
+	MonthlySegments  int64     `json:"monthly_segments" db:"monthly_segments"`
+	SoftLimitPercent int       `json:"soft_limit_percent" db:"soft_limit_percent"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (q *TenantQuota) Validate() error {
+	if q.DailyMessages < 0 || q.DailySegments < 0 || q.MonthlyMessages < 0 || q.MonthlySegments < 0 {
+		return ErrInvalidQuota
+	}
+	if q.SoftLimitPercent < 0 || q.SoftLimitPercent > 100 {
+		return ErrInvalidQuota
+	}
+	return nil
+}
+
+// QuotaLimit tek bir period/metric icin limit ve mevcut kullanim
+type QuotaLimit struct {
+	Period   string
+	Metric   string
+	Hard     int64
+	Soft     int64
+	Used     int64
+	ResetsAt time.Time
+}
+
+// Limits gunluk ve aylik kullanima gore sinirli olan tum metrikleri doner
+func (q *TenantQuota) Limits(day, month UsageRecord) []QuotaLimit {
+	dayReset := day.PeriodStart.AddDate(0, 0, 1)
+	monthReset := month.PeriodStart.AddDate(0, 1, 0)
+
+	candidates := []QuotaLimit{
+		{Period: UsagePeriodDay, Metric: QuotaMetricMessages, Hard: q.DailyMessages, Used: day.Messages, ResetsAt: dayReset},
+		{Period: UsagePeriodDay, Metric: QuotaMetricSegments, Hard: q.DailySegments, Used: day.Segments, ResetsAt: dayReset},
+		{Period: UsagePeriodMonth, Metric: QuotaMetricMessages, Hard: q.MonthlyMessages, Used: month.Messages, ResetsAt: monthReset},
+		{Period: UsagePeriodMonth, Metric: QuotaMetricSegments, Hard: q.MonthlySegments, Used: month.Segments, ResetsAt: monthReset},
+	}
+
+	limits := make([]QuotaLimit, 0, len(candidates))
+	for _, limit := range candidates {
+		if limit.Hard <= 0 {
+			continue
+		}
+		limit.Soft = limit.Hard * int64(q.SoftLimitPercent) / 100
+		limits = append(limits, limit)
+	}
+	return limits
+}
+
+// Requested mesaj ya da segment sayisindan bu limitin metrigine dusen miktari secer
+func (l QuotaLimit) Requested(messages, segments int64) int64 {
+	if l.Metric == QuotaMetricSegments {
+		return segments
+	}
+	return messages
+}
+
+// QuotaExceededError hard quota asildiginda doner, errors.Is ile ErrQuotaExceeded olarak yakalanabilir
+type QuotaExceededError struct {
+	Period    string
+	Metric    string
+	Limit     int64
+	Used      int64
+	Requested int64
+	ResetsAt  time.Time
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: %s %s quota is %d, %d used, %d requested",
+		ErrQuotaExceeded.Error(), e.Period, e.Metric, e.Limit, e.Used, e.Requested)
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// UsagePeriodStart t'nin icinde bulundugu UTC gun ya da ay basini doner
+func UsagePeriodStart(period string, t time.Time) time.Time {
+	t = t.UTC()
+	if period == UsagePeriodMonth {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSegmentCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"empty", "", 0},
+		{"gsm single", strings.Repeat("a", 160), 1},
+		{"gsm multipart", strings.Repeat("a", 161), 2},
+		{"gsm three parts", strings.Repeat("a", 307), 3},
+		{"extension chars count twice", strings.Repeat("€", 81), 2},
+		{"ucs2 single", strings.Repeat("ş", 70), 1},
+		{"ucs2 multipart", strings.Repeat("ş", 71), 2},
+		{"emoji takes two units", strings.Repeat("😀", 35), 1},
+		{"emoji multipart", strings.Repeat("😀", 36), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SegmentCount(tt.content); got != tt.want {
+				t.Errorf("SegmentCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenantQuota_Limits(t *testing.T) {
+	quota := &TenantQuota{DailyMessages: 100, MonthlySegments: 1000, SoftLimitPercent: 80}
+	now := time.Date(2024, 2, 10, 15, 0, 0, 0, time.UTC)
+	day := UsageRecord{Period: UsagePeriodDay, PeriodStart: UsagePeriodStart(UsagePeriodDay, now), Messages: 40, Segments: 50}
+	month := UsageRecord{Period: UsagePeriodMonth, PeriodStart: UsagePeriodStart(UsagePeriodMonth, now), Messages: 400, Segments: 500}
+
+	limits := quota.Limits(day, month)
+	if len(limits) != 2 {
+		t.Fatalf("Expected only non-zero limits, got %d", len(limits))
+	}
+
+	if limits[0].Metric != QuotaMetricMessages || limits[0].Soft != 80 || limits[0].Used != 40 {
+		t.Errorf("Unexpected daily limit: %+v", limits[0])
+	}
+	if !limits[0].ResetsAt.Equal(time.Date(2024, 2, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected daily reset at next midnight, got %v", limits[0].ResetsAt)
+	}
+
+	if limits[1].Period != UsagePeriodMonth || limits[1].Used != 500 || limits[1].Requested(1, 3) != 3 {
+		t.Errorf("Unexpected monthly limit: %+v", limits[1])
+	}
+	if !limits[1].ResetsAt.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected monthly reset at start of next month, got %v", limits[1].ResetsAt)
+	}
+}
+
+func TestTenantQuota_Validate(t *testing.T) {
+	if err := (&TenantQuota{DailyMessages: 10, SoftLimitPercent: 80}).Validate(); err != nil {
+		t.Errorf("Expected valid quota, got %v", err)
+	}
+	if err := (&TenantQuota{MonthlyMessages: -1}).Validate(); err != ErrInvalidQuota {
+		t.Errorf("Expected ErrInvalidQuota for negative limit, got %v", err)
+	}
+	if err := (&TenantQuota{SoftLimitPercent: 120}).Validate(); err != ErrInvalidQuota {
+		t.Errorf("Expected ErrInvalidQuota for soft limit above 100, got %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error)
+
+	GetByHash(ctx context.Context, keyHash string) (*entities.APIKey, error)
+
+	GetAll(ctx context.Context, offset, limit int) ([]*entities.APIKey, error)
+
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+
+	TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
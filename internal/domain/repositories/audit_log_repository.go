@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+// AuditLogRepository bilerek Update/Delete icermez, audit kayitlari append-only
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *entities.AuditLog) error
+
+	GetAll(ctx context.Context, filter entities.AuditLogFilter, offset, limit int) ([]*entities.AuditLog, error)
+
+	Count(ctx context.Context, filter entities.AuditLogFilter) (int64, error)
+}
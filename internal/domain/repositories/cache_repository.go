@@ -8,6 +8,9 @@ import (
 type CacheRepository interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 
+	// SetNX key yoksa yazar ve true doner; key varsa dokunmaz
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+
 	Get(ctx context.Context, key string) (string, error)
 
 	Delete(ctx context.Context, key string) error
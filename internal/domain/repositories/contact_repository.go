@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type ContactRepository interface {
+	Create(ctx context.Context, contact *entities.Contact) error
+
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Contact, error)
+
+	Update(ctx context.Context, contact *entities.Contact) error
+
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	GetAll(ctx context.Context, offset, limit int) ([]*entities.Contact, error)
+
+	Count(ctx context.Context) (int64, error)
+}
+
+type ContactListRepository interface {
+	Create(ctx context.Context, list *entities.ContactList) error
+
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.ContactList, error)
+
+	Update(ctx context.Context, list *entities.ContactList) error
+
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	GetAll(ctx context.Context, offset, limit int) ([]*entities.ContactList, error)
+
+	Count(ctx context.Context) (int64, error)
+
+	AddContacts(ctx context.Context, listID uuid.UUID, contactIDs []uuid.UUID) error
+
+	RemoveContact(ctx context.Context, listID, contactID uuid.UUID) error
+
+	GetContacts(ctx context.Context, listID uuid.UUID, offset, limit int) ([]*entities.Contact, error)
+}
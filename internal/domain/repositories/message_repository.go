@@ -18,9 +18,23 @@ type MessageRepository interface {
 
 	Update(ctx context.Context, message *entities.Message) error
 
+	// UpdateDelivery sadece teslim alanlarini yazar; ayni anda gonderim ya da iptalin yazdigi status, deneme ve
+	// kota alanlarini ezmez
+	UpdateDelivery(ctx context.Context, message *entities.Message) error
+
+	// GetByExternalID context'te tenant yoksa (SMPP ve Twilio raporlari hangi tenant'a ait oldugunu bilmez) tum
+	// tenant'larda arar; HTTP ile gelen raporda arama cagiranin tenant'iyla sinirlanir
+	GetByExternalID(ctx context.Context, provider, externalMessageID string) (*entities.Message, error)
+
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	CountByStatus(ctx context.Context, status entities.MessageStatus) (int64, error)
 
+	// CountFailuresByCode failed mesajlari error_code'a gore sayar, kodu olmayanlar unknown altinda
+	CountFailuresByCode(ctx context.Context) (map[entities.ErrorCode]int64, error)
+
 	GetAll(ctx context.Context, offset, limit int) ([]*entities.Message, error)
+
+	// GetCostReport filtredeki gruplamaya gore maliyetleri toplar, GroupBy bossa tek toplam satiri doner
+	GetCostReport(ctx context.Context, filter entities.CostReportFilter) ([]*entities.CostReportRow, error)
 }
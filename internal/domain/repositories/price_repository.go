@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+// PriceRepository fiyat tablosunun kaynagi; her Load guncel tablonun tamamini doner
+type PriceRepository interface {
+	Load(ctx context.Context) ([]entities.PriceEntry, error)
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type QuotaRepository interface {
+	// GetByTenantID tenant'a ozel quota yoksa ErrQuotaNotFound doner, o zaman config varsayilani kullanilir
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) (*entities.TenantQuota, error)
+
+	Upsert(ctx context.Context, quota *entities.TenantQuota) error
+}
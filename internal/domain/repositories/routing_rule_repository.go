@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type RoutingRuleRepository interface {
+	Create(ctx context.Context, rule *entities.RoutingRule) error
+
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.RoutingRule, error)
+
+	// GetAll kapali kurallar dahil tum kurallari olusturulma sirasiyla doner
+	GetAll(ctx context.Context) ([]*entities.RoutingRule, error)
+
+	Update(ctx context.Context, rule *entities.RoutingRule) error
+
+	Delete(ctx context.Context, id uuid.UUID) error
+}
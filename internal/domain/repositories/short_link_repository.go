@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type ShortLinkRepository interface {
+	Create(ctx context.Context, link *entities.ShortLink) error
+
+	DeleteByCodes(ctx context.Context, codes []string) error
+
+	GetByCode(ctx context.Context, code string) (*entities.ShortLink, error)
+
+	RecordClick(ctx context.Context, click *entities.LinkClick) error
+
+	GetClickCountsByMessage(ctx context.Context, messageID uuid.UUID) ([]*entities.LinkClickCount, error)
+
+	GetClickCountsByCampaign(ctx context.Context, campaign string) ([]*entities.LinkClickCount, error)
+}
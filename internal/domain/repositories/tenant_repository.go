@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type TenantRepository interface {
+	Create(ctx context.Context, tenant *entities.Tenant) error
+
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Tenant, error)
+
+	GetAll(ctx context.Context, offset, limit int) ([]*entities.Tenant, error)
+
+	// GetIDsWithPendingMessages scheduler'in tenant'lar arasinda adil dagitim yapmasi icin
+	GetIDsWithPendingMessages(ctx context.Context) ([]uuid.UUID, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type UsageRepository interface {
+	// Reserve tenant'in o gunku ve o ayki kullanimini check'e verir, check hata donmezse gunluk sayaca ekler.
+	// Ayni tenant'in rezervasyonlari tek transaction'da ve sirayla calisir, es zamanli istekler limiti asamaz.
+	Reserve(ctx context.Context, tenantID uuid.UUID, day time.Time, messages, segments int64, check func(day, month entities.UsageRecord) error) error
+
+	// Release Reserve ile eklenen miktari geri alir, sayaclar sifirin altina dusmez
+	Release(ctx context.Context, tenantID uuid.UUID, day time.Time, messages, segments int64) error
+
+	// GetUsage gunluk sayaclari period'a (day ya da month) gore toplayip [from, to] araliginda doner
+	GetUsage(ctx context.Context, tenantID uuid.UUID, period string, from, to time.Time) ([]*entities.UsageRecord, error)
+}
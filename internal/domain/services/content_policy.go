@@ -0,0 +1,17 @@
+package services
+
+import (
+	"message-sending-service/internal/domain/entities"
+)
+
+// ContentRule mesaj kabul edilmeden once calisan tek bir icerik kurali.
+// Kural icerigi temizleyebilir (message.Content'i degistirerek) ya da ihlal donebilir.
+type ContentRule interface {
+	Name() string
+
+	Apply(message *entities.Message) []entities.PolicyViolation
+}
+
+type ContentPolicy interface {
+	Evaluate(message *entities.Message) error
+}
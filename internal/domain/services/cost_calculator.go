@@ -0,0 +1,8 @@
+package services
+
+import "message-sending-service/internal/domain/entities"
+
+// CostCalculator mesaji verilen provider'in fiyat tablosundaki satiriyla fiyatlandirir, eslesme yoksa false doner
+type CostCalculator interface {
+	Cost(provider string, message *entities.Message) (entities.MessageCost, bool)
+}
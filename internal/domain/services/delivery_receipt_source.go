@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+// DeliveryReceiptHandler provider'dan gelen teslim raporunu mesaja isler
+type DeliveryReceiptHandler func(ctx context.Context, receipt entities.DeliveryReceipt) error
+
+// DeliveryReceiptSource teslim raporlarini kendi baglantisi uzerinden iten provider'lar (SMPP gibi) bunu uygular.
+// Handler provider olusturulduktan sonra baglanir; baglanmadan once gelen raporlar kaybolur.
+type DeliveryReceiptSource interface {
+	OnDeliveryReceipt(handler DeliveryReceiptHandler)
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+// LinkShortener mesaj icindeki linkleri servis uzerinden gecen kisa linklerle degistirir. ShortenLinks
+// olusturdugu kodlari doner; mesaj kaydedilemezse cagiran taraf bunlari DiscardLinks ile siler.
+type LinkShortener interface {
+	ShortenLinks(ctx context.Context, message *entities.Message) ([]string, error)
+
+	DiscardLinks(ctx context.Context, codes []string) error
+}
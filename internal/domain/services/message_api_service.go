@@ -1,11 +0,0 @@
-package services
-
-import (
-	"context"
-
-	"message-sending-service/internal/infrastructure/external"
-)
-
-type MessageAPIService interface {
-	SendMessage(ctx context.Context, phoneNumber, message string) (*external.SendMessageResponse, error)
-}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+// MessageProvider mesaji disaridaki bir SMS saglayicisina iletir.
+// Provider mesaji kabul etmezse entities.ErrProviderRejected ile sarilmis hata doner; hatalar
+// entities.ProviderError ile siniflandirilir, siniflandirilmamis hatalar unknown sayilir.
+type MessageProvider interface {
+	// Name mesaja ve fiyat tablosuna yazilan provider adi
+	Name() string
+
+	Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error)
+}
@@ -0,0 +1,8 @@
+package services
+
+import "message-sending-service/internal/domain/entities"
+
+// ProviderHealthReporter provider'larin oncelik sirasiyla guncel saglik durumunu verir
+type ProviderHealthReporter interface {
+	ProviderHealth() []entities.ProviderHealth
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// ProviderRateLimiter provider basina token bucket; birden fazla replika varsa durum paylasilmalidir
+type ProviderRateLimiter interface {
+	// Acquire token alinabildiyse 0, alinamadiysa ya da provider durdurulmussa tekrar denemeden once beklenecek sure doner
+	Acquire(ctx context.Context, provider string) (time.Duration, error)
+
+	// Pause provider'a d boyunca hic istek gonderilmemesini saglar (429/503 Retry-After)
+	Pause(ctx context.Context, provider string, d time.Duration) error
+}
@@ -0,0 +1,8 @@
+package services
+
+import "message-sending-service/internal/domain/entities"
+
+// ProviderRouter mesaj icin denenecek provider isimlerini sirayla doner, eslesen kural yoksa nil
+type ProviderRouter interface {
+	Route(request entities.SendRequest) []string
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+// QuotaEnforcer mesaj kabul edilirken kullanimi tenant quota'sindan ayirir, gonderilmeyen mesajin payini geri verir
+type QuotaEnforcer interface {
+	// ReserveQuota kontrol ve sayaca ekleme tek adimda yapilir; hard quota asilacaksa *entities.QuotaExceededError doner
+	// ve hicbir sey eklenmez. Kullanim kaydedilemezse nil rezervasyon doner, mesaj engellenmez.
+	ReserveQuota(ctx context.Context, tenantID uuid.UUID, messages, segments int64) (*entities.QuotaReservation, error)
+
+	// ReleaseQuota iptal edilen ya da kalici olarak gonderilemeyen mesajin rezervasyonunu geri alir
+	ReleaseQuota(ctx context.Context, tenantID uuid.UUID, reservation entities.QuotaReservation) error
+}
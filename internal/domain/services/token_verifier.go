@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+// TokenVerifier bearer token'i dogrular ve claim'lerden tenant ile scope'lari cikarir
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*entities.Principal, error)
+}
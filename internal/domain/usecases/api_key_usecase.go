@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type APIKeyUseCase interface {
+	// CreateAPIKey olusan anahtarin duz halini sadece bir kez doner, sonra geri alinamaz
+	CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (*entities.APIKey, string, error)
+
+	Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error)
+
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+
+	GetAPIKeys(ctx context.Context, page, limit int) ([]*entities.APIKey, error)
+}
+
+type CreateAPIKeyInput struct {
+	TenantID uuid.UUID
+	Name     string
+	Scopes   []string
+}
@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+type AuditUseCase interface {
+	// Record actor'u ve IP'yi context'ten alir. Yazma hatasi asil islemi geri almaz, sadece loglanir.
+	Record(ctx context.Context, input RecordAuditInput)
+
+	GetAuditLogs(ctx context.Context, filter entities.AuditLogFilter, page, limit int) ([]*entities.AuditLog, int64, error)
+}
+
+// RecordAuditInput'taki Before ve After JSON'a cevrilerek saklanir, nil ise bos kalir
+type RecordAuditInput struct {
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     interface{}
+	After      interface{}
+}
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type ContactUseCase interface {
+	CreateContact(ctx context.Context, contact *entities.Contact) error
+
+	GetContact(ctx context.Context, id uuid.UUID) (*entities.Contact, error)
+
+	UpdateContact(ctx context.Context, contact *entities.Contact) error
+
+	DeleteContact(ctx context.Context, id uuid.UUID) error
+
+	GetContacts(ctx context.Context, page, limit int) ([]*entities.Contact, int64, error)
+
+	CreateList(ctx context.Context, list *entities.ContactList) error
+
+	GetList(ctx context.Context, id uuid.UUID) (*entities.ContactList, error)
+
+	UpdateList(ctx context.Context, list *entities.ContactList) error
+
+	DeleteList(ctx context.Context, id uuid.UUID) error
+
+	GetLists(ctx context.Context, page, limit int) ([]*entities.ContactList, int64, error)
+
+	AddContactsToList(ctx context.Context, listID uuid.UUID, contactIDs []uuid.UUID) error
+
+	RemoveContactFromList(ctx context.Context, listID, contactID uuid.UUID) error
+
+	GetListContacts(ctx context.Context, listID uuid.UUID, page, limit int) ([]*entities.Contact, error)
+
+	Broadcast(ctx context.Context, listID uuid.UUID, input BroadcastInput) (*BroadcastResult, error)
+}
+
+// BroadcastInput'taki Content bir text/template, her kisi icin {{.name}}, {{.phone_number}} ve attribute'lar ile render edilir
+type BroadcastInput struct {
+	Content  string
+	Category entities.MessageCategory
+	Campaign *string
+	SenderID *string
+}
+
+type BroadcastResult struct {
+	ListID     uuid.UUID          `json:"list_id"`
+	Queued     int                `json:"queued"`
+	Suppressed int                `json:"suppressed"`
+	Failed     int                `json:"failed"`
+	MessageIDs []uuid.UUID        `json:"message_ids"`
+	Failures   []BroadcastFailure `json:"failures,omitempty"`
+}
+
+type BroadcastFailure struct {
+	ContactID uuid.UUID `json:"contact_id"`
+	Reason    string    `json:"reason"`
+}
+
+func (r *BroadcastResult) AddFailure(contactID uuid.UUID, err error) {
+	r.Failed++
+	r.Failures = append(r.Failures, BroadcastFailure{
+		ContactID: contactID,
+		Reason:    err.Error(),
+	})
+}
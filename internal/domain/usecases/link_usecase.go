@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+)
+
+type LinkUseCase interface {
+	services.LinkShortener
+
+	ResolveClick(ctx context.Context, code, ipAddress, userAgent string) (string, error)
+
+	GetMessageClickStats(ctx context.Context, messageID uuid.UUID) (*ClickStats, error)
+
+	GetCampaignClickStats(ctx context.Context, campaign string) (*ClickStats, error)
+}
+
+type ClickStats struct {
+	TotalClicks int64                      `json:"total_clicks"`
+	Links       []*entities.LinkClickCount `json:"links"`
+}
@@ -8,7 +8,7 @@ import (
 )
 
 type MessageUseCase interface {
-	CreateMessage(ctx context.Context, content, phoneNumber string) (*entities.Message, error)
+	CreateMessage(ctx context.Context, input CreateMessageInput) (*entities.Message, error)
 
 	GetMessageByID(ctx context.Context, id uuid.UUID) (*entities.Message, error)
 
@@ -21,11 +21,49 @@ type MessageUseCase interface {
 	ProcessPendingMessages(ctx context.Context, batchSize int) (int, error)
 
 	GetMessageStats(ctx context.Context) (*MessageStats, error)
+
+	CancelMessage(ctx context.Context, id uuid.UUID) (*entities.Message, error)
+
+	UpdateMessage(ctx context.Context, id uuid.UUID, input UpdateMessageInput) (*entities.Message, error)
+
+	RequeueMessage(ctx context.Context, id uuid.UUID) (*entities.Message, error)
+
+	// RecordDeliveryReceipt eslesen mesaj yoksa entities.ErrMessageNotFound doner
+	RecordDeliveryReceipt(ctx context.Context, receipt entities.DeliveryReceipt) error
+}
+
+// CreateMessageInput'ta Channel bossa sms; email kanalinda PhoneNumber yerine Email ve Subject,
+// webhook kanalinda WebhookURL kullanilir
+type CreateMessageInput struct {
+	Channel     entities.MessageChannel
+	Content     string
+	PhoneNumber string
+	Email       *string
+	Subject     *string
+	WebhookURL  *string
+	Category    entities.MessageCategory
+	Campaign    *string
+	SenderID    *string
+}
+
+// UpdateMessageInput'ta nil alanlar degistirilmez; kanal degistirilemez
+type UpdateMessageInput struct {
+	Content     *string
+	PhoneNumber *string
+	Email       *string
+	Subject     *string
+	WebhookURL  *string
 }
 
+// EstimatedCost pending ve sent mesajlarin tahmini, ActualCost sent mesajlarin gercek maliyeti.
+// FailuresByCode failed mesajlari error_code'a gore boler, toplami FailedMessages'a esittir.
 type MessageStats struct {
-	TotalMessages   int64 `json:"total_messages"`
-	PendingMessages int64 `json:"pending_messages"`
-	SentMessages    int64 `json:"sent_messages"`
-	FailedMessages  int64 `json:"failed_messages"`
+	TotalMessages   int64            `json:"total_messages"`
+	PendingMessages int64            `json:"pending_messages"`
+	SentMessages    int64            `json:"sent_messages"`
+	FailedMessages  int64            `json:"failed_messages"`
+	FailuresByCode  map[string]int64 `json:"failures_by_code"`
+	EstimatedCost   float64          `json:"estimated_cost"`
+	ActualCost      float64          `json:"actual_cost"`
+	Currency        string           `json:"currency"`
 }
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+)
+
+type PricingUseCase interface {
+	services.CostCalculator
+
+	// ReloadPrices tabloyu kaynaktan tekrar okur, hatali tabloda eskisi kullanilmaya devam eder
+	ReloadPrices(ctx context.Context) error
+
+	GetPrices(ctx context.Context) *PriceList
+
+	GetCostReport(ctx context.Context, filter entities.CostReportFilter) (*CostReport, error)
+}
+
+type PriceList struct {
+	Currency string
+	Entries  []entities.PriceEntry
+}
+
+type CostReport struct {
+	Currency string
+	GroupBy  string
+	Rows     []*entities.CostReportRow
+	Total    entities.CostReportRow
+}
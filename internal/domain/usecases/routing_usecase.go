@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+)
+
+type RoutingUseCase interface {
+	services.ProviderRouter
+
+	// ReloadRules kurallari veritabanindan tekrar okur; API'den yapilan her degisiklikten sonra da cagrilir
+	ReloadRules(ctx context.Context) error
+
+	// StartReload ctx iptal edilene kadar kurallari periyodik olarak yeniler, diger replikalardaki degisiklikler de boylece gelir
+	StartReload(ctx context.Context)
+
+	CreateRule(ctx context.Context, rule *entities.RoutingRule) error
+
+	GetRule(ctx context.Context, id uuid.UUID) (*entities.RoutingRule, error)
+
+	GetRules(ctx context.Context) ([]*entities.RoutingRule, error)
+
+	UpdateRule(ctx context.Context, rule *entities.RoutingRule) error
+
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+}
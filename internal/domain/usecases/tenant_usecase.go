@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+)
+
+type TenantUseCase interface {
+	CreateTenant(ctx context.Context, tenant *entities.Tenant) error
+
+	GetTenant(ctx context.Context, id uuid.UUID) (*entities.Tenant, error)
+
+	GetTenants(ctx context.Context, page, limit int) ([]*entities.Tenant, error)
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+)
+
+type UsageUseCase interface {
+	services.QuotaEnforcer
+
+	GetUsageSummary(ctx context.Context, tenantID uuid.UUID) (*UsageSummary, error)
+
+	GetUsageHistory(ctx context.Context, tenantID uuid.UUID, period string, from, to time.Time) ([]*entities.UsageRecord, error)
+
+	// GetQuota tenant'a ozel quota yoksa config varsayilanini doner
+	GetQuota(ctx context.Context, tenantID uuid.UUID) (*entities.TenantQuota, error)
+
+	SetQuota(ctx context.Context, quota *entities.TenantQuota) error
+}
+
+// UsageSummary mevcut gun ve ayin kullanimini ve her sinirli metrik icin durumu tasir
+type UsageSummary struct {
+	TenantID uuid.UUID
+	Quota    *entities.TenantQuota
+	Today    entities.UsageRecord
+	Month    entities.UsageRecord
+	Limits   []entities.QuotaLimit
+}
@@ -0,0 +1,278 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+// saat farklari icin exp/nbf kontrollerinde tolerans
+const clockSkewLeeway = 30 * time.Second
+
+// KeySource kid ile imza anahtarini saglar, JWKS bunu implemente eder
+type KeySource interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+type jwtVerifier struct {
+	keys KeySource
+	cfg  config.JWTConfig
+	now  func() time.Time
+}
+
+func NewJWTVerifier(keys KeySource, cfg config.JWTConfig) services.TokenVerifier {
+	return &jwtVerifier{
+		keys: keys,
+		cfg:  cfg,
+		now:  time.Now,
+	}
+}
+
+// Verify sadece RS256 ve ES256 kabul eder; "none" ve HMAC algoritmalari bilerek desteklenmez
+func (v *jwtVerifier) Verify(ctx context.Context, token string) (*entities.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalidToken("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, invalidToken("malformed header")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalidToken("malformed signature")
+	}
+
+	key, err := v.keys.Key(ctx, header.Kid)
+	if err != nil {
+		return nil, invalidToken("unknown signing key")
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := verifySignature(header.Alg, key, digest[:], signature); err != nil {
+		return nil, err
+	}
+
+	claims := make(map[string]interface{})
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, invalidToken("malformed claims")
+	}
+
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+
+	return v.principal(claims)
+}
+
+func verifySignature(alg string, key crypto.PublicKey, digest, signature []byte) error {
+	switch alg {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return invalidToken("algorithm does not match key type")
+		}
+		if err := rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest, signature); err != nil {
+			return invalidToken("invalid signature")
+		}
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return invalidToken("algorithm does not match key type")
+		}
+		// JWS'te ES256 imzasi 32 byte r ve 32 byte s'in birlesimi
+		if len(signature) != 64 {
+			return invalidToken("invalid signature")
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return invalidToken("invalid signature")
+		}
+	default:
+		return invalidToken(fmt.Sprintf("unsupported algorithm %q", alg))
+	}
+	return nil
+}
+
+func (v *jwtVerifier) validateClaims(claims map[string]interface{}) error {
+	now := v.now()
+
+	exp, ok := numericClaim(claims, "exp")
+	if !ok {
+		return invalidToken("missing exp claim")
+	}
+	if now.After(exp.Add(clockSkewLeeway)) {
+		return invalidToken("token expired")
+	}
+
+	if nbf, ok := numericClaim(claims, "nbf"); ok && now.Add(clockSkewLeeway).Before(nbf) {
+		return invalidToken("token not yet valid")
+	}
+
+	if v.cfg.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.cfg.Issuer {
+			return invalidToken("unexpected issuer")
+		}
+	}
+
+	if v.cfg.Audience != "" && !containsString(stringsClaim(claims["aud"]), v.cfg.Audience) {
+		return invalidToken("unexpected audience")
+	}
+
+	return nil
+}
+
+func (v *jwtVerifier) principal(claims map[string]interface{}) (*entities.Principal, error) {
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, invalidToken("missing sub claim")
+	}
+
+	rawTenant, _ := claimAtPath(claims, v.cfg.TenantClaim).(string)
+	tenantID, err := uuid.Parse(rawTenant)
+	if err != nil || tenantID == uuid.Nil {
+		return nil, invalidToken("missing or invalid tenant claim")
+	}
+
+	principal := &entities.Principal{
+		Subject:    subject,
+		Name:       firstStringClaim(claims, "name", "preferred_username", "email"),
+		TenantID:   tenantID,
+		Scopes:     []string{},
+		AuthMethod: entities.AuthMethodJWT,
+	}
+
+	// rol config'te map'lenmisse scope'larina cevrilir, zaten bilinen bir scope ise aynen alinir
+	seen := make(map[string]bool)
+	addScope := func(scope string) {
+		if entities.IsKnownScope(scope) && !seen[scope] {
+			seen[scope] = true
+			principal.Scopes = append(principal.Scopes, scope)
+		}
+	}
+
+	for _, role := range stringsClaim(claimAtPath(claims, v.cfg.RolesClaim)) {
+		if scopes, ok := v.cfg.RoleScopes[role]; ok {
+			for _, scope := range scopes {
+				addScope(scope)
+			}
+			continue
+		}
+		addScope(role)
+	}
+
+	// standart OAuth2 "scope" claim'i bosluk ile ayrilmis string
+	if scope, ok := claims["scope"].(string); ok {
+		for _, s := range strings.Fields(scope) {
+			addScope(s)
+		}
+	}
+
+	return principal, nil
+}
+
+func invalidToken(reason string) error {
+	return fmt.Errorf("%w: %s", entities.ErrInvalidToken, reason)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func numericClaim(claims map[string]interface{}, name string) (time.Time, bool) {
+	number, ok := claims[name].(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+// claimAtPath "realm_access.roles" gibi noktali yollari nested claim'lerde arar
+func claimAtPath(claims map[string]interface{}, path string) interface{} {
+	if path == "" {
+		return nil
+	}
+
+	var current interface{} = claims
+	for _, part := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+	return current
+}
+
+// stringsClaim tek string'i ya da string dizisini ayni sekilde okur (aud ve roller icin).
+// tek string tek deger sayilir, bosluklara bolunmez; bosluklu liste sadece scope claim'inde var
+func stringsClaim(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func firstStringClaim(claims map[string]interface{}, names ...string) string {
+	for _, name := range names {
+		if value, ok := claims[name].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
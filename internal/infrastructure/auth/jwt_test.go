@@ -0,0 +1,262 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+var testTenantID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+type testKeys struct {
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	return testKeys{rsa: rsaKey, ec: ecKey}
+}
+
+func (k testKeys) jwks() []byte {
+	b64 := base64.RawURLEncoding.EncodeToString
+	pad := func(b []byte) []byte {
+		out := make([]byte, 32)
+		copy(out[32-len(b):], b)
+		return out
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA", "kid": "rsa-1", "use": "sig",
+				"n": b64(k.rsa.N.Bytes()),
+				"e": b64(big.NewInt(int64(k.rsa.E)).Bytes()),
+			},
+			{
+				"kty": "EC", "kid": "ec-1", "crv": "P-256",
+				"x": b64(pad(k.ec.X.Bytes())),
+				"y": b64(pad(k.ec.Y.Bytes())),
+			},
+		},
+	})
+	return data
+}
+
+func (k testKeys) sign(t *testing.T, alg, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch alg {
+	case "RS256":
+		sig, err := rsa.SignPKCS1v15(rand.Reader, k.rsa, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+		signature = sig
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, k.ec, digest[:])
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub":       "user-42",
+		"name":      "Jane Operator",
+		"iss":       "https://sso.example.com",
+		"aud":       []string{"message-service"},
+		"exp":       time.Now().Add(time.Hour).Unix(),
+		"tenant_id": testTenantID.String(),
+		"roles":     []string{"sso-support", "unknown-role"},
+	}
+}
+
+func newTestVerifier(t *testing.T, keys testKeys) *jwtVerifier {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, keys.jwks(), 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+
+	cfg := config.JWTConfig{
+		JWKSFile:    path,
+		Issuer:      "https://sso.example.com",
+		Audience:    "message-service",
+		TenantClaim: "tenant_id",
+		RolesClaim:  "roles",
+		RoleScopes: map[string][]string{
+			"sso-support": {entities.ScopeMessagesRead, entities.ScopeContactsRead},
+		},
+	}
+
+	jwks := NewJWKS(cfg, zap.NewNop())
+	if err := jwks.Refresh(context.Background()); err != nil {
+		t.Fatalf("failed to load JWKS: %v", err)
+	}
+
+	return NewJWTVerifier(jwks, cfg).(*jwtVerifier)
+}
+
+func TestJWTVerifier_ValidTokens(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier := newTestVerifier(t, keys)
+
+	for _, tc := range []struct{ alg, kid string }{{"RS256", "rsa-1"}, {"ES256", "ec-1"}} {
+		t.Run(tc.alg, func(t *testing.T) {
+			principal, err := verifier.Verify(context.Background(), keys.sign(t, tc.alg, tc.kid, validClaims()))
+			if err != nil {
+				t.Fatalf("expected valid token, got %v", err)
+			}
+
+			if principal.Subject != "user-42" || principal.TenantID != testTenantID || principal.AuthMethod != entities.AuthMethodJWT {
+				t.Errorf("unexpected principal: %+v", principal)
+			}
+			if !principal.HasScope(entities.ScopeMessagesRead) || !principal.HasScope(entities.ScopeContactsRead) {
+				t.Errorf("expected mapped scopes, got %v", principal.Scopes)
+			}
+			if principal.HasScope(entities.ScopeMessagesWrite) {
+				t.Errorf("did not expect messages:write, got %v", principal.Scopes)
+			}
+		})
+	}
+}
+
+func TestJWTVerifier_NestedRolesAndScopeClaim(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier := newTestVerifier(t, keys)
+	verifier.cfg.RolesClaim = "realm_access.roles"
+
+	claims := validClaims()
+	delete(claims, "roles")
+	claims["realm_access"] = map[string]interface{}{"roles": []string{"sso-support"}}
+	claims["scope"] = "openid messages:write"
+
+	principal, err := verifier.Verify(context.Background(), keys.sign(t, "RS256", "rsa-1", claims))
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+
+	if !principal.HasScope(entities.ScopeMessagesRead) || !principal.HasScope(entities.ScopeMessagesWrite) {
+		t.Errorf("expected scopes from nested roles and scope claim, got %v", principal.Scopes)
+	}
+}
+
+func TestJWTVerifier_RejectsInvalidTokens(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier := newTestVerifier(t, keys)
+	otherKeys := newTestKeys(t)
+
+	tests := []struct {
+		name  string
+		token func() string
+	}{
+		{"expired", func() string {
+			claims := validClaims()
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return keys.sign(t, "RS256", "rsa-1", claims)
+		}},
+		{"missing exp", func() string {
+			claims := validClaims()
+			delete(claims, "exp")
+			return keys.sign(t, "RS256", "rsa-1", claims)
+		}},
+		{"not yet valid", func() string {
+			claims := validClaims()
+			claims["nbf"] = time.Now().Add(time.Hour).Unix()
+			return keys.sign(t, "RS256", "rsa-1", claims)
+		}},
+		{"wrong issuer", func() string {
+			claims := validClaims()
+			claims["iss"] = "https://evil.example.com"
+			return keys.sign(t, "RS256", "rsa-1", claims)
+		}},
+		{"wrong audience", func() string {
+			claims := validClaims()
+			claims["aud"] = "another-service"
+			return keys.sign(t, "RS256", "rsa-1", claims)
+		}},
+		{"space separated audience", func() string {
+			claims := validClaims()
+			claims["aud"] = "message-service another-service"
+			return keys.sign(t, "RS256", "rsa-1", claims)
+		}},
+		{"missing tenant", func() string {
+			claims := validClaims()
+			delete(claims, "tenant_id")
+			return keys.sign(t, "RS256", "rsa-1", claims)
+		}},
+		{"signed by unknown key", func() string {
+			return otherKeys.sign(t, "RS256", "rsa-1", validClaims())
+		}},
+		{"algorithm does not match key", func() string {
+			return keys.sign(t, "ES256", "rsa-1", validClaims())
+		}},
+		{"unknown kid", func() string {
+			return keys.sign(t, "RS256", "missing", validClaims())
+		}},
+		{"alg none", func() string {
+			token := keys.sign(t, "RS256", "rsa-1", validClaims())
+			parts := strings.Split(token, ".")
+			header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"rsa-1"}`))
+			return header + "." + parts[1] + "."
+		}},
+		{"malformed", func() string { return "not.a-jwt" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(context.Background(), tt.token())
+			if !errors.Is(err, entities.ErrInvalidToken) {
+				t.Errorf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseJWKS_RejectsEmptySet(t *testing.T) {
+	if _, err := ParseJWKS([]byte(`{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`)); err == nil {
+		t.Error("expected error for JWKS without usable keys")
+	}
+}
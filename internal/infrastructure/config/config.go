@@ -1,8 +1,10 @@
 package config
 
 import (
+	"math"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -15,6 +17,16 @@ type Config struct {
 	External  ExternalConfig
 	Scheduler SchedulerConfig
 	Logger    LoggerConfig
+	Dedup     DedupConfig
+	Policy    ContentPolicyConfig
+	ShortLink ShortLinkConfig
+	Sender    SenderConfig
+	Auth      AuthConfig
+	Quota     QuotaConfig
+	Pricing   PricingConfig
+	Routing   RoutingConfig
+	Email     EmailConfig
+	Webhook   WebhookConfig
 }
 
 type DatabaseConfig struct {
@@ -38,20 +50,292 @@ type ServerConfig struct {
 	Port int
 }
 
+// Provider fiyat tablosunda bu API'nin satirlarini secmek icin kullanilan isim,
+// Adapter ise provider registry'de hangi entegrasyonun kullanilacagini secer.
+// Providers bos degilse MESSAGE_API_* yerine oncelik sirasiyla bu provider'lar kullanilir.
 type ExternalConfig struct {
 	MessageAPIURL string
 	Timeout       time.Duration
+	Provider      string
+	Adapter       string
+	Providers     []ProviderConfig
+	RateLimit     float64
+	RateBurst     int
+	Username      string
+	Password      string
+	From          string
+	CallbackURL   string
+	KeepAlive     time.Duration
+	HTTP          HTTPAdapterConfig
+	Sandbox       SandboxConfig
+	Health        ProviderHealthConfig
+	Breaker       CircuitBreakerConfig
+	Throttle      ThrottleConfig
 }
 
+// ProviderHealthConfig son Window denemeye gore provider skorunu hesaplar.
+// Skoru MinScore altina dusen provider atlanir, ProbeInterval gectikten sonra tekrar denenir.
+type ProviderHealthConfig struct {
+	Window        int
+	MinScore      float64
+	SlowLatency   time.Duration
+	ProbeInterval time.Duration
+}
+
+// CircuitBreakerConfig art arda FailureThreshold gecici hatada provider'in devresini OpenTimeout boyunca acar.
+// Sure dolunca HalfOpenRequests deneme istegine izin verilir, hepsi basariliysa devre kapanir.
+// FailureThreshold 0 ise circuit breaker kapali.
+type CircuitBreakerConfig struct {
+	FailureThreshold int
+	OpenTimeout      time.Duration
+	HalfOpenRequests int
+}
+
+// ThrottleConfig token bekleme suresi MaxWait'i asarsa provider atlanir ve mesaj pending kalir.
+// 429/503 yanitinda Retry-After yoksa provider DefaultPause boyunca durdurulur.
+type ThrottleConfig struct {
+	MaxWait      time.Duration
+	DefaultPause time.Duration
+}
+
+const (
+	HTTPAuthNone   = "none"
+	HTTPAuthBearer = "bearer"
+	HTTPAuthBasic  = "basic"
+	HTTPAuthAPIKey = "api_key"
+)
+
+// HTTPAdapterConfig http adapter'inin istek/yanit sozlesmesi. RequestFields phone_number, message ve sender_id
+// alanlarinin provider'daki adini verir ("to" ya da "recipient.msisdn" gibi noktali yol). Yanitta mesaj ID'si
+// MessageIDPath, durum StatusPath'ten okunur; durum SuccessStatuses'tan biri degilse mesaj reddedilmis sayilir.
+// StatusPath "-" ise durum kontrol edilmez. Auth: none, bearer (token PASSWORD), basic (USERNAME/PASSWORD)
+// ya da api_key (PASSWORD, APIKeyHeader basligiyla).
+type HTTPAdapterConfig struct {
+	Auth            string
+	APIKeyHeader    string
+	Headers         map[string]string
+	RequestFields   map[string]string
+	MessageIDPath   string
+	StatusPath      string
+	ErrorPath       string
+	SuccessStatuses []string
+}
+
+const (
+	SandboxLatencyFixed       = "fixed"
+	SandboxLatencyUniform     = "uniform"
+	SandboxLatencyNormal      = "normal"
+	SandboxLatencyExponential = "exponential"
+)
+
+// SandboxConfig "sandbox" adapter'inin simulasyon ayarlari. Gecikme LatencyDistribution'a gore Latency
+// etrafinda cekilir: fixed her zaman Latency, uniform Latency +/- LatencyJitter, normal ortalama Latency ve
+// standart sapma LatencyJitter, exponential ortalama Latency. Oranlar 0 ile 1 arasi ve toplamlari en fazla 1.
+// ReceiptDelay 0 ise teslim raporu uretilmez. Seed 0 ise her calismada farkli bir seed secilir.
+type SandboxConfig struct {
+	Seed                int64
+	Latency             time.Duration
+	LatencyJitter       time.Duration
+	LatencyDistribution string
+	FailureRate         float64
+	TimeoutRate         float64
+	ThrottleRate        float64
+	RejectRate          float64
+	ReceiptDelay        time.Duration
+	UndeliveredRate     float64
+}
+
+// ProviderConfig tek bir provider adapter'ini kurmak icin gereken ayarlar
+// RateLimit saniyedeki istek sayisi (0 = sinirsiz), RateBurst bir anda harcanabilecek token sayisi.
+// Username/Password adapter'in kimlik bilgisi (twilio: account SID / auth token), From varsayilan gonderici,
+// CallbackURL provider'in teslim durumunu bildirecegi adres, KeepAlive kalici baglantilarda (smpp) ping araligi.
+// HTTP sadece http, Sandbox sadece sandbox adapter'inda kullanilir.
+type ProviderConfig struct {
+	Name        string
+	Adapter     string
+	URL         string
+	Timeout     time.Duration
+	RateLimit   float64
+	RateBurst   int
+	Username    string
+	Password    string
+	From        string
+	CallbackURL string
+	KeepAlive   time.Duration
+	HTTP        HTTPAdapterConfig
+	Sandbox     SandboxConfig
+}
+
+// PrimaryProvider MESSAGE_API_* ayarlarindan tanimlanan provider
+func (c ExternalConfig) PrimaryProvider() ProviderConfig {
+	return ProviderConfig{
+		Name:        c.Provider,
+		Adapter:     c.Adapter,
+		URL:         c.MessageAPIURL,
+		Timeout:     c.Timeout,
+		RateLimit:   c.RateLimit,
+		RateBurst:   c.RateBurst,
+		Username:    c.Username,
+		Password:    c.Password,
+		From:        c.From,
+		CallbackURL: c.CallbackURL,
+		KeepAlive:   c.KeepAlive,
+		HTTP:        c.HTTP,
+		Sandbox:     c.Sandbox,
+	}
+}
+
+// Burst RateBurst verilmemisse saniyelik limit kadar (en az 1) token
+func (p ProviderConfig) Burst() int {
+	if p.RateBurst > 0 {
+		return p.RateBurst
+	}
+	return int(math.Max(1, math.Ceil(p.RateLimit)))
+}
+
+// ProviderList failover sirasindaki provider'lar, liste tanimlanmamissa sadece primary provider
+func (c ExternalConfig) ProviderList() []ProviderConfig {
+	if len(c.Providers) == 0 {
+		return []ProviderConfig{c.PrimaryProvider()}
+	}
+	return c.Providers
+}
+
+// MaxAttempts gecici hatalarda (network, timeout, provider_error...) bir mesajin en fazla kac kez denenecegi;
+// RetryBackoff ilk bekleme suresi, her denemede iki katina cikar
 type SchedulerConfig struct {
 	Interval         time.Duration
 	MessagesPerBatch int
+	MaxAttempts      int
+	RetryBackoff     time.Duration
 }
 
 type LoggerConfig struct {
 	Level string
 }
 
+const (
+	DedupModeReject = "reject"
+	DedupModeLink   = "link"
+)
+
+// ayni numaraya ayni icerik pencere icinde tekrar gelirse reject (409) ya da link (orijinal mesaj doner)
+type DedupConfig struct {
+	Enabled bool
+	Window  time.Duration
+	Mode    string
+}
+
+type ContentPolicyConfig struct {
+	StripControlChars bool
+	BannedWords       []string
+	BannedPatterns    []string
+	AllowedURLDomains []string
+	OptOutFooter      string
+}
+
+type ShortLinkConfig struct {
+	Enabled       bool
+	BaseURL       string
+	CodeLength    int
+	MarketingOnly bool
+}
+
+// bos allowlist butun ozel sender id'leri reddeder; sender id'siz mesajlar provider'in FROM'u ile gider
+type SenderConfig struct {
+	AllowedIDs []string
+}
+
+const (
+	AuthModeAPIKey = "api_key"
+	AuthModeJWT    = "jwt"
+	AuthModeBoth   = "both"
+)
+
+// Enabled false sadece lokal gelistirme icin, tum /api/v1 route'lari korumasiz kalir
+type AuthConfig struct {
+	Enabled bool
+	Mode    string
+	JWT     JWTConfig
+}
+
+// JWKS dosyadan ya da URL'den yuklenir ve RefreshInterval'da bir yenilenir.
+// RoleScopes rol claim'indeki degerleri scope'lara cevirir, ornek "sso-admin=admin;support=messages:read,contacts:read"
+type JWTConfig struct {
+	JWKSFile        string
+	JWKSURL         string
+	RefreshInterval time.Duration
+	Issuer          string
+	Audience        string
+	TenantClaim     string
+	RolesClaim      string
+	RoleScopes      map[string][]string
+}
+
+// tenant'a ozel quota tanimlanmamissa bu limitler kullanilir, 0 sinirsiz.
+// Enabled false iken kullanim yine sayilir ama limitler uygulanmaz.
+type QuotaConfig struct {
+	Enabled          bool
+	DailyMessages    int64
+	DailySegments    int64
+	MonthlyMessages  int64
+	MonthlySegments  int64
+	SoftLimitPercent int
+}
+
+// File bos ise fiyat tablosu bos kalir ve mesajlara maliyet yazilmaz. Tum fiyatlar Currency cinsindendir.
+type PricingConfig struct {
+	File     string
+	Currency string
+}
+
+// ReloadInterval 0 ise kurallar sadece acilista ve API'den degistirildiginde yuklenir
+type RoutingConfig struct {
+	ReloadInterval time.Duration
+}
+
+const (
+	SMTPTLSStartTLS = "starttls"
+	SMTPTLSImplicit = "tls"
+	SMTPTLSNone     = "none"
+)
+
+// EmailConfig email kanalinin SMTP ayarlari; Enabled false iken email mesajlari kabul edilmez.
+// TLSMode starttls (varsayilan, sunucu desteklemiyorsa gonderim basarisiz olur), tls (465 gibi dogrudan TLS)
+// ya da none. Username bossa AUTH yapilmaz.
+type EmailConfig struct {
+	Enabled            bool
+	Provider           string
+	Host               string
+	Port               int
+	Username           string
+	Password           string
+	From               string
+	TLSMode            string
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+}
+
+// WebhookConfig webhook kanali; istekler SigningSecret ile HMAC-SHA256 imzalanir.
+// Sadece AllowedHosts'taki host'lara (port haric) webhook mesaji kabul edilir, liste bossa hic biri.
+// AllowPrivateNetworks kapaliyken DNS cozumlemesinden sonra loopback, ozel ve link-local adreslere baglanilmaz.
+type WebhookConfig struct {
+	Enabled              bool
+	Provider             string
+	SigningSecret        string
+	Timeout              time.Duration
+	AllowedHosts         []string
+	AllowPrivateNetworks bool
+}
+
+func (c AuthConfig) UsesAPIKeys() bool {
+	return c.Mode == AuthModeAPIKey || c.Mode == AuthModeBoth
+}
+
+func (c AuthConfig) UsesJWT() bool {
+	return c.Mode == AuthModeJWT || c.Mode == AuthModeBoth
+}
+
 func Load() (*Config, error) {
 	_ = godotenv.Load("config.env")
 
@@ -75,21 +359,141 @@ func Load() (*Config, error) {
 			Port: getEnvAsInt("SERVER_PORT", 8080),
 		},
 		External: ExternalConfig{
-			MessageAPIURL: getEnv("MESSAGE_API_URL", "https://webhook.site/your-webhook-id"),
+			MessageAPIURL: getEnv("MESSAGE_API_URL", defaultAPIURL(getEnv("MESSAGE_API_ADAPTER", "http"))),
 			Timeout:       getEnvAsDuration("MESSAGE_API_TIMEOUT", 30*time.Second),
+			Provider:      getEnv("MESSAGE_API_PROVIDER", "default"),
+			Adapter:       getEnv("MESSAGE_API_ADAPTER", "http"),
+			RateLimit:     getEnvAsFloat("MESSAGE_API_RATE_LIMIT", 0),
+			RateBurst:     getEnvAsInt("MESSAGE_API_RATE_BURST", 0),
+			Username:      getEnv("MESSAGE_API_USERNAME", ""),
+			Password:      getEnv("MESSAGE_API_PASSWORD", ""),
+			From:          getEnv("MESSAGE_API_FROM", ""),
+			CallbackURL:   getEnv("MESSAGE_API_CALLBACK_URL", ""),
+			HTTP:          getEnvAsHTTPAdapter("MESSAGE_API_", DefaultHTTPAdapterConfig()),
+			KeepAlive:     getEnvAsDuration("MESSAGE_API_KEEPALIVE", 30*time.Second),
+			Sandbox:       getEnvAsSandbox("MESSAGE_API_", DefaultSandboxConfig()),
+			Health: ProviderHealthConfig{
+				Window:        getEnvAsInt("PROVIDER_HEALTH_WINDOW", 20),
+				MinScore:      getEnvAsFloat("PROVIDER_HEALTH_MIN_SCORE", 0.5),
+				SlowLatency:   getEnvAsDuration("PROVIDER_HEALTH_SLOW_LATENCY", 5*time.Second),
+				ProbeInterval: getEnvAsDuration("PROVIDER_HEALTH_PROBE_INTERVAL", 30*time.Second),
+			},
+			Breaker: CircuitBreakerConfig{
+				FailureThreshold: getEnvAsInt("CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5),
+				OpenTimeout:      getEnvAsDuration("CIRCUIT_BREAKER_OPEN_TIMEOUT", time.Minute),
+				HalfOpenRequests: getEnvAsInt("CIRCUIT_BREAKER_HALF_OPEN_REQUESTS", 1),
+			},
+			Throttle: ThrottleConfig{
+				MaxWait:      getEnvAsDuration("PROVIDER_RATE_MAX_WAIT", time.Second),
+				DefaultPause: getEnvAsDuration("PROVIDER_THROTTLE_PAUSE", 30*time.Second),
+			},
 		},
 		Scheduler: SchedulerConfig{
 			Interval:         getEnvAsDuration("SCHEDULER_INTERVAL", 2*time.Minute),
 			MessagesPerBatch: getEnvAsInt("MESSAGES_PER_BATCH", 2),
+			MaxAttempts:      getEnvAsInt("SEND_MAX_ATTEMPTS", 5),
+			RetryBackoff:     getEnvAsDuration("SEND_RETRY_BACKOFF", time.Minute),
 		},
 		Logger: LoggerConfig{
 			Level: getEnv("LOG_LEVEL", "info"),
 		},
+		Dedup: DedupConfig{
+			Enabled: getEnvAsBool("DEDUP_ENABLED", true),
+			Window:  getEnvAsDuration("DEDUP_WINDOW", 10*time.Minute),
+			Mode:    getEnv("DEDUP_MODE", DedupModeReject),
+		},
+		Policy: ContentPolicyConfig{
+			StripControlChars: getEnvAsBool("CONTENT_STRIP_CONTROL_CHARS", true),
+			BannedWords:       getEnvAsSlice("CONTENT_BANNED_WORDS", ","),
+			BannedPatterns:    getEnvAsSlice("CONTENT_BANNED_PATTERNS", ";;"),
+			AllowedURLDomains: getEnvAsSlice("CONTENT_ALLOWED_URL_DOMAINS", ","),
+			OptOutFooter:      getEnv("CONTENT_OPT_OUT_FOOTER", ""),
+		},
+		ShortLink: ShortLinkConfig{
+			Enabled:       getEnvAsBool("SHORT_LINKS_ENABLED", false),
+			BaseURL:       getEnv("SHORT_LINK_BASE_URL", "http://localhost:8080"),
+			CodeLength:    getEnvAsInt("SHORT_LINK_CODE_LENGTH", 7),
+			MarketingOnly: getEnvAsBool("SHORT_LINKS_MARKETING_ONLY", true),
+		},
+		Sender: SenderConfig{
+			AllowedIDs: getEnvAsSlice("SENDER_ID_ALLOWLIST", ","),
+		},
+		Auth: AuthConfig{
+			Enabled: getEnvAsBool("AUTH_ENABLED", true),
+			Mode:    getEnv("AUTH_MODE", AuthModeAPIKey),
+			JWT: JWTConfig{
+				JWKSFile:        getEnv("JWT_JWKS_FILE", ""),
+				JWKSURL:         getEnv("JWT_JWKS_URL", ""),
+				RefreshInterval: getEnvAsDuration("JWT_JWKS_REFRESH_INTERVAL", 15*time.Minute),
+				Issuer:          getEnv("JWT_ISSUER", ""),
+				Audience:        getEnv("JWT_AUDIENCE", ""),
+				TenantClaim:     getEnv("JWT_TENANT_CLAIM", "tenant_id"),
+				RolesClaim:      getEnv("JWT_ROLES_CLAIM", "roles"),
+				RoleScopes:      getEnvAsRoleScopes("JWT_ROLE_SCOPES"),
+			},
+		},
+		Quota: QuotaConfig{
+			Enabled:          getEnvAsBool("QUOTA_ENABLED", false),
+			DailyMessages:    int64(getEnvAsInt("QUOTA_DAILY_MESSAGES", 0)),
+			DailySegments:    int64(getEnvAsInt("QUOTA_DAILY_SEGMENTS", 0)),
+			MonthlyMessages:  int64(getEnvAsInt("QUOTA_MONTHLY_MESSAGES", 0)),
+			MonthlySegments:  int64(getEnvAsInt("QUOTA_MONTHLY_SEGMENTS", 0)),
+			SoftLimitPercent: getEnvAsInt("QUOTA_SOFT_LIMIT_PERCENT", 80),
+		},
+		Pricing: PricingConfig{
+			File:     getEnv("PRICING_FILE", ""),
+			Currency: getEnv("PRICING_CURRENCY", "EUR"),
+		},
+		Routing: RoutingConfig{
+			ReloadInterval: getEnvAsDuration("ROUTING_RELOAD_INTERVAL", 30*time.Second),
+		},
+		Email: EmailConfig{
+			Enabled:            getEnvAsBool("EMAIL_ENABLED", false),
+			Provider:           getEnv("EMAIL_PROVIDER", "smtp"),
+			Host:               getEnv("SMTP_HOST", "localhost"),
+			Port:               getEnvAsInt("SMTP_PORT", 587),
+			Username:           getEnv("SMTP_USERNAME", ""),
+			Password:           getEnv("SMTP_PASSWORD", ""),
+			From:               getEnv("SMTP_FROM", ""),
+			TLSMode:            getEnv("SMTP_TLS", SMTPTLSStartTLS),
+			InsecureSkipVerify: getEnvAsBool("SMTP_TLS_SKIP_VERIFY", false),
+			Timeout:            getEnvAsDuration("SMTP_TIMEOUT", 30*time.Second),
+		},
+		Webhook: WebhookConfig{
+			Enabled:              getEnvAsBool("WEBHOOK_ENABLED", false),
+			Provider:             getEnv("WEBHOOK_PROVIDER", "webhook"),
+			SigningSecret:        getEnv("WEBHOOK_SIGNING_SECRET", ""),
+			Timeout:              getEnvAsDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			AllowedHosts:         getEnvAsSlice("WEBHOOK_ALLOWED_HOSTS", ","),
+			AllowPrivateNetworks: getEnvAsBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+		},
 	}
 
+	cfg.External.Providers = getEnvAsProviders("MESSAGE_PROVIDERS", cfg.External)
+
 	return cfg, nil
 }
 
+// IsAllowed bos liste hicbir ozel sender id'ye izin vermez; sender id'siz mesajlar provider'in FROM'u ile gider
+func (s SenderConfig) IsAllowed(senderID string) bool {
+	for _, allowed := range s.AllowedIDs {
+		if allowed == senderID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHostAllowed host karsilastirmasi buyuk/kucuk harf duyarsiz; bos liste hicbir host'a izin vermez
+func (c WebhookConfig) IsHostAllowed(host string) bool {
+	for _, allowed := range c.AllowedHosts {
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Config) GetDatabaseDSN() string {
 	return "host=" + c.Database.Host +
 		" port=" + strconv.Itoa(c.Database.Port) +
@@ -123,6 +527,176 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsSlice(key, separator string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, separator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvAsRoleScopes "rol=scope1,scope2;rol2=scope3" formatini okur
+func getEnvAsRoleScopes(key string) map[string][]string {
+	roleScopes := make(map[string][]string)
+	for _, entry := range getEnvAsSlice(key, ";") {
+		role, scopes, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+		for _, scope := range strings.Split(scopes, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				roleScopes[strings.TrimSpace(role)] = append(roleScopes[strings.TrimSpace(role)], scope)
+			}
+		}
+	}
+	return roleScopes
+}
+
+// getEnvAsProviders "primary,backup" gibi oncelik sirasindaki isimleri okur, her provider'in ayarlari
+// MESSAGE_PROVIDER_<ISIM>_<AYAR>'dan gelir (URL, ADAPTER, TIMEOUT, RATE_LIMIT, RATE_BURST, USERNAME, PASSWORD,
+// FROM, CALLBACK_URL, KEEPALIVE, http ve sandbox adapter ayarlari); verilmeyenler MESSAGE_API_* degerlerini alir
+func getEnvAsProviders(key string, defaults ExternalConfig) []ProviderConfig {
+	var providers []ProviderConfig
+	for _, name := range getEnvAsSlice(key, ",") {
+		prefix := "MESSAGE_PROVIDER_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		adapter := getEnv(prefix+"ADAPTER", defaults.Adapter)
+		// baska adapter'in adresi bu provider'a uymaz
+		apiURL := defaults.MessageAPIURL
+		if adapter != defaults.Adapter {
+			apiURL = defaultAPIURL(adapter)
+		}
+		providers = append(providers, ProviderConfig{
+			Name:        name,
+			Adapter:     adapter,
+			URL:         getEnv(prefix+"URL", apiURL),
+			Timeout:     getEnvAsDuration(prefix+"TIMEOUT", defaults.Timeout),
+			RateLimit:   getEnvAsFloat(prefix+"RATE_LIMIT", defaults.RateLimit),
+			RateBurst:   getEnvAsInt(prefix+"RATE_BURST", defaults.RateBurst),
+			Username:    getEnv(prefix+"USERNAME", defaults.Username),
+			Password:    getEnv(prefix+"PASSWORD", defaults.Password),
+			From:        getEnv(prefix+"FROM", defaults.From),
+			CallbackURL: getEnv(prefix+"CALLBACK_URL", defaults.CallbackURL),
+			KeepAlive:   getEnvAsDuration(prefix+"KEEPALIVE", defaults.KeepAlive),
+			HTTP:        getEnvAsHTTPAdapter(prefix, defaults.HTTP),
+			Sandbox:     getEnvAsSandbox(prefix, defaults.Sandbox),
+		})
+	}
+	return providers
+}
+
+// defaultAPIURL URL verilmediginde kullanilan adres. twilio adapter'i bos URL ile kendi API'sine
+// (api.twilio.com) gider; smpp adapter'inin SMSC adresi acikca verilmeli, bos URL provider kurulurken hata verir.
+// Ornek webhook.site adresi sadece http provider'larina gider.
+func defaultAPIURL(adapter string) string {
+	if adapter == "twilio" || adapter == "smpp" {
+		return ""
+	}
+	return "https://webhook.site/your-webhook-id"
+}
+
+// DefaultHTTPAdapterConfig onceki sabit sozlesme: {"phone_number","message","sender_id"} gonderilir,
+// yanitta {"message_id","status":"sent"} beklenir
+func DefaultHTTPAdapterConfig() HTTPAdapterConfig {
+	return HTTPAdapterConfig{
+		Auth:            HTTPAuthNone,
+		APIKeyHeader:    "X-API-Key",
+		MessageIDPath:   "message_id",
+		StatusPath:      "status",
+		ErrorPath:       "error",
+		SuccessStatuses: []string{"sent"},
+	}
+}
+
+// getEnvAsHTTPAdapter <prefix>AUTH, API_KEY_HEADER, HEADERS ("Ad=deger;Ad2=deger2"), REQUEST_FIELDS
+// ("phone_number=to,message=text"), MESSAGE_ID_PATH, STATUS_PATH, ERROR_PATH ve SUCCESS_STATUSES'i okur
+func getEnvAsHTTPAdapter(prefix string, defaults HTTPAdapterConfig) HTTPAdapterConfig {
+	adapter := HTTPAdapterConfig{
+		Auth:            getEnv(prefix+"AUTH", defaults.Auth),
+		APIKeyHeader:    getEnv(prefix+"API_KEY_HEADER", defaults.APIKeyHeader),
+		Headers:         getEnvAsPairs(prefix+"HEADERS", ";", defaults.Headers),
+		RequestFields:   getEnvAsPairs(prefix+"REQUEST_FIELDS", ",", defaults.RequestFields),
+		MessageIDPath:   getEnv(prefix+"MESSAGE_ID_PATH", defaults.MessageIDPath),
+		StatusPath:      getEnv(prefix+"STATUS_PATH", defaults.StatusPath),
+		ErrorPath:       getEnv(prefix+"ERROR_PATH", defaults.ErrorPath),
+		SuccessStatuses: getEnvAsSlice(prefix+"SUCCESS_STATUSES", ","),
+	}
+	if adapter.SuccessStatuses == nil {
+		adapter.SuccessStatuses = defaults.SuccessStatuses
+	}
+	return adapter
+}
+
+// DefaultSandboxConfig her mesaji 50-150ms icinde kabul eden, 2 saniye sonra teslim raporu ureten provider
+func DefaultSandboxConfig() SandboxConfig {
+	return SandboxConfig{
+		Latency:             100 * time.Millisecond,
+		LatencyJitter:       50 * time.Millisecond,
+		LatencyDistribution: SandboxLatencyUniform,
+		ReceiptDelay:        2 * time.Second,
+	}
+}
+
+// getEnvAsSandbox <prefix>SANDBOX_* ayarlarini okur (SEED, LATENCY, LATENCY_JITTER, LATENCY_DISTRIBUTION,
+// FAILURE_RATE, TIMEOUT_RATE, THROTTLE_RATE, REJECT_RATE, RECEIPT_DELAY, UNDELIVERED_RATE)
+func getEnvAsSandbox(prefix string, defaults SandboxConfig) SandboxConfig {
+	prefix += "SANDBOX_"
+	return SandboxConfig{
+		Seed:                int64(getEnvAsInt(prefix+"SEED", int(defaults.Seed))),
+		Latency:             getEnvAsDuration(prefix+"LATENCY", defaults.Latency),
+		LatencyJitter:       getEnvAsDuration(prefix+"LATENCY_JITTER", defaults.LatencyJitter),
+		LatencyDistribution: getEnv(prefix+"LATENCY_DISTRIBUTION", defaults.LatencyDistribution),
+		FailureRate:         getEnvAsFloat(prefix+"FAILURE_RATE", defaults.FailureRate),
+		TimeoutRate:         getEnvAsFloat(prefix+"TIMEOUT_RATE", defaults.TimeoutRate),
+		ThrottleRate:        getEnvAsFloat(prefix+"THROTTLE_RATE", defaults.ThrottleRate),
+		RejectRate:          getEnvAsFloat(prefix+"REJECT_RATE", defaults.RejectRate),
+		ReceiptDelay:        getEnvAsDuration(prefix+"RECEIPT_DELAY", defaults.ReceiptDelay),
+		UndeliveredRate:     getEnvAsFloat(prefix+"UNDELIVERED_RATE", defaults.UndeliveredRate),
+	}
+}
+
+// getEnvAsPairs "anahtar=deger" ciftlerini okur, degiskende yoksa defaults doner
+func getEnvAsPairs(key, separator string, defaults map[string]string) map[string]string {
+	entries := getEnvAsSlice(key, separator)
+	if entries == nil {
+		return defaults
+	}
+
+	pairs := make(map[string]string)
+	for _, entry := range entries {
+		name, value, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+		pairs[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return pairs
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+)
+
+const auditLogColumns = `id, tenant_id, actor_id, actor_name, auth_method, ip_address, action, target_type, target_id,
+		       before_state, after_state, created_at`
+
+func scanAuditLog(row rowScanner) (*entities.AuditLog, error) {
+	entry := &entities.AuditLog{}
+	var before, after []byte
+	err := row.Scan(
+		&entry.ID,
+		&entry.TenantID,
+		&entry.ActorID,
+		&entry.ActorName,
+		&entry.AuthMethod,
+		&entry.IPAddress,
+		&entry.Action,
+		&entry.TargetType,
+		&entry.TargetID,
+		&before,
+		&after,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Before = before
+	entry.After = after
+	return entry, nil
+}
+
+// auditLogWhere bos olmayan filtre alanlarindan WHERE kosulunu ve parametreleri uretir
+func auditLogWhere(filter entities.AuditLogFilter) (string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.TenantID != nil {
+		add("tenant_id = $%d", *filter.TenantID)
+	}
+	if filter.ActorID != "" {
+		add("actor_id = $%d", filter.ActorID)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.TargetType != "" {
+		add("target_type = $%d", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		add("target_id = $%d", filter.TargetID)
+	}
+	if filter.From != nil {
+		add("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("created_at < $%d", *filter.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+type auditLogRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewAuditLogRepository(db *sql.DB) repositories.AuditLogRepository {
+	return &auditLogRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *auditLogRepositoryImpl) Create(ctx context.Context, entry *entities.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs (id, tenant_id, actor_id, actor_name, auth_method, ip_address, action, target_type,
+		                        target_id, before_state, after_state, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		entry.ID,
+		entry.TenantID,
+		entry.ActorID,
+		entry.ActorName,
+		entry.AuthMethod,
+		entry.IPAddress,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		nullableJSON(entry.Before),
+		nullableJSON(entry.After),
+		entry.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	return nil
+}
+
+func (r *auditLogRepositoryImpl) GetAll(ctx context.Context, filter entities.AuditLogFilter, offset, limit int) ([]*entities.AuditLog, error) {
+	where, args := auditLogWhere(filter)
+	query := fmt.Sprintf(`
+		SELECT `+auditLogColumns+`
+		FROM audit_logs
+		%s
+		ORDER BY created_at DESC
+		OFFSET $%d LIMIT $%d
+	`, where, len(args)+1, len(args)+2)
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, offset, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entities.AuditLog
+	for rows.Next() {
+		entry, err := scanAuditLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit log: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *auditLogRepositoryImpl) Count(ctx context.Context, filter entities.AuditLogFilter) (int64, error) {
+	where, args := auditLogWhere(filter)
+	query := `SELECT COUNT(*) FROM audit_logs ` + where
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	return count, nil
+}
+
+// nullableJSON bos state'i JSONB'ye NULL olarak yazar
+func nullableJSON(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
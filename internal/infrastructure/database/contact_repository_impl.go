@@ -0,0 +1,513 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+)
+
+const uniqueViolationCode = "23505"
+
+const contactColumns = `c.id, c.tenant_id, c.phone_number, c.name, c.attributes, c.suppressed, c.created_at, c.updated_at`
+
+func scanContact(row rowScanner) (*entities.Contact, error) {
+	contact := &entities.Contact{}
+	var attributes []byte
+	err := row.Scan(
+		&contact.ID,
+		&contact.TenantID,
+		&contact.PhoneNumber,
+		&contact.Name,
+		&attributes,
+		&contact.Suppressed,
+		&contact.CreatedAt,
+		&contact.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	contact.Attributes = map[string]string{}
+	if len(attributes) > 0 {
+		if err := json.Unmarshal(attributes, &contact.Attributes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal contact attributes: %w", err)
+		}
+	}
+
+	return contact, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode
+}
+
+type contactRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewContactRepository(db *sql.DB) repositories.ContactRepository {
+	return &contactRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *contactRepositoryImpl) Create(ctx context.Context, contact *entities.Contact) error {
+	query := `
+		INSERT INTO contacts (id, tenant_id, phone_number, name, attributes, suppressed, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+	contact.TenantID = tenantID
+
+	attributes, err := json.Marshal(contact.Attributes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal contact attributes: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		contact.ID,
+		contact.TenantID,
+		contact.PhoneNumber,
+		contact.Name,
+		attributes,
+		contact.Suppressed,
+		contact.CreatedAt,
+		contact.UpdatedAt,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entities.ErrContactAlreadyExists
+		}
+		return fmt.Errorf("failed to create contact: %w", err)
+	}
+
+	return nil
+}
+
+func (r *contactRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.Contact, error) {
+	query := `
+		SELECT ` + contactColumns + `
+		FROM contacts c
+		WHERE c.id = $1 AND c.tenant_id = $2
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contact, err := scanContact(r.db.QueryRowContext(ctx, query, id, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrContactNotFound
+		}
+		return nil, fmt.Errorf("failed to get contact: %w", err)
+	}
+
+	return contact, nil
+}
+
+func (r *contactRepositoryImpl) Update(ctx context.Context, contact *entities.Contact) error {
+	query := `
+		UPDATE contacts
+		SET phone_number = $2, name = $3, attributes = $4, suppressed = $5, updated_at = $6
+		WHERE id = $1 AND tenant_id = $7
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	attributes, err := json.Marshal(contact.Attributes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal contact attributes: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		contact.ID,
+		contact.PhoneNumber,
+		contact.Name,
+		attributes,
+		contact.Suppressed,
+		contact.UpdatedAt,
+		tenantID,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entities.ErrContactAlreadyExists
+		}
+		return fmt.Errorf("failed to update contact: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrContactNotFound
+	}
+
+	return nil
+}
+
+func (r *contactRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM contacts WHERE id = $1 AND tenant_id = $2`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete contact: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrContactNotFound
+	}
+
+	return nil
+}
+
+func (r *contactRepositoryImpl) GetAll(ctx context.Context, offset, limit int) ([]*entities.Contact, error) {
+	query := `
+		SELECT ` + contactColumns + `
+		FROM contacts c
+		WHERE c.tenant_id = $3
+		ORDER BY c.created_at DESC
+		OFFSET $1 LIMIT $2
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, offset, limit, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contacts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanContacts(rows)
+}
+
+func (r *contactRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM contacts WHERE tenant_id = $1`, tenantID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count contacts: %w", err)
+	}
+
+	return count, nil
+}
+
+func scanContacts(rows *sql.Rows) ([]*entities.Contact, error) {
+	var contacts []*entities.Contact
+	for rows.Next() {
+		contact, err := scanContact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contact: %w", err)
+		}
+		contacts = append(contacts, contact)
+	}
+
+	return contacts, rows.Err()
+}
+
+type contactListRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewContactListRepository(db *sql.DB) repositories.ContactListRepository {
+	return &contactListRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *contactListRepositoryImpl) Create(ctx context.Context, list *entities.ContactList) error {
+	query := `
+		INSERT INTO contact_lists (id, tenant_id, name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+	list.TenantID = tenantID
+
+	_, err = r.db.ExecContext(ctx, query,
+		list.ID,
+		list.TenantID,
+		list.Name,
+		list.Description,
+		list.CreatedAt,
+		list.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create contact list: %w", err)
+	}
+
+	return nil
+}
+
+func (r *contactListRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.ContactList, error) {
+	query := `
+		SELECT l.id, l.tenant_id, l.name, l.description,
+		       (SELECT COUNT(*) FROM contact_list_members m WHERE m.list_id = l.id),
+		       l.created_at, l.updated_at
+		FROM contact_lists l
+		WHERE l.id = $1 AND l.tenant_id = $2
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &entities.ContactList{}
+	err = r.db.QueryRowContext(ctx, query, id, tenantID).Scan(
+		&list.ID,
+		&list.TenantID,
+		&list.Name,
+		&list.Description,
+		&list.ContactCount,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrContactListNotFound
+		}
+		return nil, fmt.Errorf("failed to get contact list: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *contactListRepositoryImpl) Update(ctx context.Context, list *entities.ContactList) error {
+	query := `
+		UPDATE contact_lists
+		SET name = $2, description = $3, updated_at = $4
+		WHERE id = $1 AND tenant_id = $5
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, list.ID, list.Name, list.Description, list.UpdatedAt, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to update contact list: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrContactListNotFound
+	}
+
+	return nil
+}
+
+func (r *contactListRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM contact_lists WHERE id = $1 AND tenant_id = $2`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete contact list: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrContactListNotFound
+	}
+
+	return nil
+}
+
+func (r *contactListRepositoryImpl) GetAll(ctx context.Context, offset, limit int) ([]*entities.ContactList, error) {
+	query := `
+		SELECT l.id, l.tenant_id, l.name, l.description,
+		       (SELECT COUNT(*) FROM contact_list_members m WHERE m.list_id = l.id),
+		       l.created_at, l.updated_at
+		FROM contact_lists l
+		WHERE l.tenant_id = $3
+		ORDER BY l.created_at DESC
+		OFFSET $1 LIMIT $2
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, offset, limit, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact lists: %w", err)
+	}
+	defer rows.Close()
+
+	var lists []*entities.ContactList
+	for rows.Next() {
+		list := &entities.ContactList{}
+		err := rows.Scan(
+			&list.ID,
+			&list.TenantID,
+			&list.Name,
+			&list.Description,
+			&list.ContactCount,
+			&list.CreatedAt,
+			&list.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contact list: %w", err)
+		}
+		lists = append(lists, list)
+	}
+
+	return lists, rows.Err()
+}
+
+func (r *contactListRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM contact_lists WHERE tenant_id = $1`, tenantID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count contact lists: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *contactListRepositoryImpl) AddContacts(ctx context.Context, listID uuid.UUID, contactIDs []uuid.UUID) error {
+	if len(contactIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(contactIDs))
+	for i, id := range contactIDs {
+		ids[i] = id.String()
+	}
+
+	// sadece listeyle ayni tenant'ta var olan kisileri ekliyoruz, tekrar eklenenler sessizce atlanir
+	query := `
+		INSERT INTO contact_list_members (list_id, contact_id)
+		SELECT l.id, c.id
+		FROM contact_lists l
+		JOIN contacts c ON c.tenant_id = l.tenant_id
+		WHERE l.id = $1 AND l.tenant_id = $3 AND c.id = ANY($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, listID, pq.Array(ids), tenantID); err != nil {
+		return fmt.Errorf("failed to add contacts to list: %w", err)
+	}
+
+	return nil
+}
+
+func (r *contactListRepositoryImpl) RemoveContact(ctx context.Context, listID, contactID uuid.UUID) error {
+	query := `
+		DELETE FROM contact_list_members m
+		USING contact_lists l
+		WHERE m.list_id = l.id AND m.list_id = $1 AND m.contact_id = $2 AND l.tenant_id = $3
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, listID, contactID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to remove contact from list: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrContactNotFound
+	}
+
+	return nil
+}
+
+func (r *contactListRepositoryImpl) GetContacts(ctx context.Context, listID uuid.UUID, offset, limit int) ([]*entities.Contact, error) {
+	query := `
+		SELECT ` + contactColumns + `
+		FROM contacts c
+		JOIN contact_list_members m ON m.contact_id = c.id
+		WHERE m.list_id = $1 AND c.tenant_id = $4
+		ORDER BY c.created_at ASC, c.id ASC
+		OFFSET $2 LIMIT $3
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, listID, offset, limit, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list contacts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanContacts(rows)
+}
@@ -4,12 +4,71 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"message-sending-service/internal/domain/entities"
 	"message-sending-service/internal/domain/repositories"
 )
 
+const messageColumns = `id, tenant_id, content, phone_number, status, category, campaign, sender_id, created_at, updated_at,
+		       sent_at, external_message_id, error_message, cost_prefix, estimated_cost, actual_cost, provider,
+		       delivery_status, delivery_error, delivery_reported_at, channel, email, subject, webhook_url, error_code,
+		       attempts, next_attempt_at, quota_reserved_on, quota_reserved_segments`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMessage(row rowScanner) (*entities.Message, error) {
+	message := &entities.Message{}
+	err := row.Scan(
+		&message.ID,
+		&message.TenantID,
+		&message.Content,
+		&message.PhoneNumber,
+		&message.Status,
+		&message.Category,
+		&message.Campaign,
+		&message.SenderID,
+		&message.CreatedAt,
+		&message.UpdatedAt,
+		&message.SentAt,
+		&message.ExternalMessageID,
+		&message.ErrorMessage,
+		&message.CostPrefix,
+		&message.EstimatedCost,
+		&message.ActualCost,
+		&message.Provider,
+		&message.DeliveryStatus,
+		&message.DeliveryError,
+		&message.DeliveryReportedAt,
+		&message.Channel,
+		&message.Email,
+		&message.Subject,
+		&message.WebhookURL,
+		&message.ErrorCode,
+		&message.Attempts,
+		&message.NextAttemptAt,
+		&message.QuotaReservedOn,
+		&message.QuotaReservedSegments,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// tenantScope butun mesaj sorgulari context'teki tenant ile sinirlanir, tenant yoksa sorgu yapilmaz
+func tenantScope(ctx context.Context) (uuid.UUID, error) {
+	tenantID, ok := entities.TenantFromContext(ctx)
+	if !ok {
+		return uuid.Nil, entities.ErrTenantRequired
+	}
+	return tenantID, nil
+}
+
 type messageRepositoryImpl struct {
 	db *sql.DB
 }
@@ -22,21 +81,43 @@ func NewMessageRepository(db *sql.DB) repositories.MessageRepository {
 
 func (r *messageRepositoryImpl) Create(ctx context.Context, message *entities.Message) error {
 	query := `
-		INSERT INTO messages (id, content, phone_number, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO messages (id, tenant_id, content, phone_number, status, category, campaign, sender_id, created_at, updated_at,
+		                      cost_prefix, estimated_cost, channel, email, subject, webhook_url, quota_reserved_on, quota_reserved_segments)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	`
 
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
 	if message.ID == uuid.Nil {
 		message.ID = uuid.New()
 	}
+	if message.Channel == "" {
+		message.Channel = entities.MessageChannelSMS
+	}
+	message.TenantID = tenantID
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err = r.db.ExecContext(ctx, query,
 		message.ID,
+		message.TenantID,
 		message.Content,
 		message.PhoneNumber,
 		message.Status,
+		message.Category,
+		message.Campaign,
+		message.SenderID,
 		message.CreatedAt,
 		message.UpdatedAt,
+		message.CostPrefix,
+		message.EstimatedCost,
+		message.Channel,
+		message.Email,
+		message.Subject,
+		message.WebhookURL,
+		message.QuotaReservedOn,
+		message.QuotaReservedSegments,
 	)
 
 	if err != nil {
@@ -48,27 +129,17 @@ func (r *messageRepositoryImpl) Create(ctx context.Context, message *entities.Me
 
 func (r *messageRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.Message, error) {
 	query := `
-		SELECT id, content, phone_number, status, created_at, updated_at, 
-		       sent_at, external_message_id, error_message
+		SELECT ` + messageColumns + `
 		FROM messages 
-		WHERE id = $1
+		WHERE id = $1 AND tenant_id = $2
 	`
 
-	row := r.db.QueryRowContext(ctx, query, id)
-
-	message := &entities.Message{}
-	err := row.Scan(
-		&message.ID,
-		&message.Content,
-		&message.PhoneNumber,
-		&message.Status,
-		&message.CreatedAt,
-		&message.UpdatedAt,
-		&message.SentAt,
-		&message.ExternalMessageID,
-		&message.ErrorMessage,
-	)
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
 
+	message, err := scanMessage(r.db.QueryRowContext(ctx, query, id, tenantID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, entities.ErrMessageNotFound
@@ -81,15 +152,19 @@ func (r *messageRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*ent
 
 func (r *messageRepositoryImpl) GetPendingMessages(ctx context.Context, limit int) ([]*entities.Message, error) {
 	query := `
-		SELECT id, content, phone_number, status, created_at, updated_at, 
-		       sent_at, external_message_id, error_message
+		SELECT ` + messageColumns + `
 		FROM messages 
-		WHERE status = 'pending'
+		WHERE status = 'pending' AND tenant_id = $2 AND (next_attempt_at IS NULL OR next_attempt_at <= NOW())
 		ORDER BY created_at ASC
 		LIMIT $1
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, limit)
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, limit, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pending messages: %w", err)
 	}
@@ -97,38 +172,31 @@ func (r *messageRepositoryImpl) GetPendingMessages(ctx context.Context, limit in
 
 	var messages []*entities.Message
 	for rows.Next() {
-		message := &entities.Message{}
-		err := rows.Scan(
-			&message.ID,
-			&message.Content,
-			&message.PhoneNumber,
-			&message.Status,
-			&message.CreatedAt,
-			&message.UpdatedAt,
-			&message.SentAt,
-			&message.ExternalMessageID,
-			&message.ErrorMessage,
-		)
+		message, err := scanMessage(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan message: %w", err)
 		}
 		messages = append(messages, message)
 	}
 
-	return messages, nil
+	return messages, rows.Err()
 }
 
 func (r *messageRepositoryImpl) GetSentMessages(ctx context.Context, offset, limit int) ([]*entities.Message, error) {
 	query := `
-		SELECT id, content, phone_number, status, created_at, updated_at, 
-		       sent_at, external_message_id, error_message
+		SELECT ` + messageColumns + `
 		FROM messages 
-		WHERE status = 'sent'
+		WHERE status = 'sent' AND tenant_id = $3
 		ORDER BY sent_at DESC
 		OFFSET $1 LIMIT $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, offset, limit)
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, offset, limit, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sent messages: %w", err)
 	}
@@ -136,35 +204,33 @@ func (r *messageRepositoryImpl) GetSentMessages(ctx context.Context, offset, lim
 
 	var messages []*entities.Message
 	for rows.Next() {
-		message := &entities.Message{}
-		err := rows.Scan(
-			&message.ID,
-			&message.Content,
-			&message.PhoneNumber,
-			&message.Status,
-			&message.CreatedAt,
-			&message.UpdatedAt,
-			&message.SentAt,
-			&message.ExternalMessageID,
-			&message.ErrorMessage,
-		)
+		message, err := scanMessage(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan message: %w", err)
 		}
 		messages = append(messages, message)
 	}
 
-	return messages, nil
+	return messages, rows.Err()
 }
 
 func (r *messageRepositoryImpl) Update(ctx context.Context, message *entities.Message) error {
 	query := `
 		UPDATE messages 
 		SET content = $2, phone_number = $3, status = $4, updated_at = $5,
-		    sent_at = $6, external_message_id = $7, error_message = $8
-		WHERE id = $1
+		    sent_at = $6, external_message_id = $7, error_message = $8, category = $9,
+		    cost_prefix = $11, estimated_cost = $12, actual_cost = $13, provider = $14,
+		    delivery_status = $15, delivery_error = $16, delivery_reported_at = $17, email = $18, subject = $19,
+		    webhook_url = $20, error_code = $21, attempts = $22, next_attempt_at = $23,
+		    quota_reserved_on = $24, quota_reserved_segments = $25
+		WHERE id = $1 AND tenant_id = $10
 	`
 
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
 	result, err := r.db.ExecContext(ctx, query,
 		message.ID,
 		message.Content,
@@ -174,6 +240,23 @@ func (r *messageRepositoryImpl) Update(ctx context.Context, message *entities.Me
 		message.SentAt,
 		message.ExternalMessageID,
 		message.ErrorMessage,
+		message.Category,
+		tenantID,
+		message.CostPrefix,
+		message.EstimatedCost,
+		message.ActualCost,
+		message.Provider,
+		message.DeliveryStatus,
+		message.DeliveryError,
+		message.DeliveryReportedAt,
+		message.Email,
+		message.Subject,
+		message.WebhookURL,
+		message.ErrorCode,
+		message.Attempts,
+		message.NextAttemptAt,
+		message.QuotaReservedOn,
+		message.QuotaReservedSegments,
 	)
 
 	if err != nil {
@@ -192,10 +275,73 @@ func (r *messageRepositoryImpl) Update(ctx context.Context, message *entities.Me
 	return nil
 }
 
+func (r *messageRepositoryImpl) UpdateDelivery(ctx context.Context, message *entities.Message) error {
+	query := `
+		UPDATE messages
+		SET delivery_status = $2, delivery_error = $3, delivery_reported_at = $4, updated_at = $5
+		WHERE id = $1 AND tenant_id = $6
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		message.ID,
+		message.DeliveryStatus,
+		message.DeliveryError,
+		message.DeliveryReportedAt,
+		message.UpdatedAt,
+		tenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update message delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return entities.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+func (r *messageRepositoryImpl) GetByExternalID(ctx context.Context, provider, externalMessageID string) (*entities.Message, error) {
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages
+		WHERE provider = $1 AND external_message_id = $2
+	`
+	args := []interface{}{provider, externalMessageID}
+	if tenantID, ok := entities.TenantFromContext(ctx); ok {
+		query += ` AND tenant_id = $3`
+		args = append(args, tenantID)
+	}
+
+	message, err := scanMessage(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to get message by external id: %w", err)
+	}
+
+	return message, nil
+}
+
 func (r *messageRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM messages WHERE id = $1`
+	query := `DELETE FROM messages WHERE id = $1 AND tenant_id = $2`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, id, tenantID)
 	if err != nil {
 		return fmt.Errorf("failed to delete message: %w", err)
 	}
@@ -213,10 +359,15 @@ func (r *messageRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error
 }
 
 func (r *messageRepositoryImpl) CountByStatus(ctx context.Context, status entities.MessageStatus) (int64, error) {
-	query := `SELECT COUNT(*) FROM messages WHERE status = $1`
+	query := `SELECT COUNT(*) FROM messages WHERE status = $1 AND tenant_id = $2`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return 0, err
+	}
 
 	var count int64
-	err := r.db.QueryRowContext(ctx, query, status).Scan(&count)
+	err = r.db.QueryRowContext(ctx, query, status, tenantID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count messages by status: %w", err)
 	}
@@ -224,16 +375,54 @@ func (r *messageRepositoryImpl) CountByStatus(ctx context.Context, status entiti
 	return count, nil
 }
 
+func (r *messageRepositoryImpl) CountFailuresByCode(ctx context.Context) (map[entities.ErrorCode]int64, error) {
+	// sinifsiz eski kayitlar unknown sayilir
+	query := `
+		SELECT COALESCE(error_code, 'unknown'), COUNT(*)
+		FROM messages
+		WHERE status = $1 AND tenant_id = $2
+		GROUP BY 1
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, entities.MessageStatusFailed, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count failures by code: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[entities.ErrorCode]int64)
+	for rows.Next() {
+		var code entities.ErrorCode
+		var count int64
+		if err := rows.Scan(&code, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan failure count: %w", err)
+		}
+		counts[code] = count
+	}
+
+	return counts, rows.Err()
+}
+
 func (r *messageRepositoryImpl) GetAll(ctx context.Context, offset, limit int) ([]*entities.Message, error) {
 	query := `
-		SELECT id, content, phone_number, status, created_at, updated_at, 
-		       sent_at, external_message_id, error_message
+		SELECT ` + messageColumns + `
 		FROM messages 
+		WHERE tenant_id = $3
 		ORDER BY created_at DESC
 		OFFSET $1 LIMIT $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, offset, limit)
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, offset, limit, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all messages: %w", err)
 	}
@@ -241,23 +430,79 @@ func (r *messageRepositoryImpl) GetAll(ctx context.Context, offset, limit int) (
 
 	var messages []*entities.Message
 	for rows.Next() {
-		message := &entities.Message{}
-		err := rows.Scan(
-			&message.ID,
-			&message.Content,
-			&message.PhoneNumber,
-			&message.Status,
-			&message.CreatedAt,
-			&message.UpdatedAt,
-			&message.SentAt,
-			&message.ExternalMessageID,
-			&message.ErrorMessage,
-		)
+		message, err := scanMessage(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan message: %w", err)
 		}
 		messages = append(messages, message)
 	}
 
-	return messages, nil
+	return messages, rows.Err()
+}
+
+// costGroupKeys rapor gruplarinin SQL karsiligi; bos grup tek bir toplam satiri doner
+var costGroupKeys = map[string]string{
+	"":                         "''",
+	entities.CostGroupCampaign: "COALESCE(campaign, '')",
+	entities.CostGroupCountry:  "COALESCE(cost_prefix, '')",
+	entities.CostGroupDay:      "to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD')",
+}
+
+// GetCostReport iptal edilen mesajlari saymaz; tahmini maliyet pending ve sent, gercek maliyet sadece sent mesajlardan toplanir
+func (r *messageRepositoryImpl) GetCostReport(ctx context.Context, filter entities.CostReportFilter) ([]*entities.CostReportRow, error) {
+	key, ok := costGroupKeys[filter.GroupBy]
+	if !ok {
+		return nil, entities.ErrInvalidCostGroup
+	}
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := []string{"tenant_id = $1", "status <> 'cancelled'"}
+	args := []interface{}{tenantID}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Campaign != nil {
+		add("campaign = $%d", *filter.Campaign)
+	}
+	if filter.From != nil {
+		add("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("created_at < $%d", *filter.To)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS cost_key,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE status = 'sent'),
+		       COALESCE(SUM(estimated_cost) FILTER (WHERE status IN ('pending', 'sent')), 0),
+		       COALESCE(SUM(actual_cost) FILTER (WHERE status = 'sent'), 0)
+		FROM messages
+		WHERE %s
+		GROUP BY cost_key
+		ORDER BY cost_key
+	`, key, strings.Join(conditions, " AND "))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost report: %w", err)
+	}
+	defer rows.Close()
+
+	var report []*entities.CostReportRow
+	for rows.Next() {
+		row := &entities.CostReportRow{}
+		if err := rows.Scan(&row.Key, &row.Messages, &row.SentMessages, &row.EstimatedCost, &row.ActualCost); err != nil {
+			return nil, fmt.Errorf("failed to scan cost report row: %w", err)
+		}
+		report = append(report, row)
+	}
+
+	return report, rows.Err()
 }
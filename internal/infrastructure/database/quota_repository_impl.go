@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+)
+
+type quotaRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewQuotaRepository(db *sql.DB) repositories.QuotaRepository {
+	return &quotaRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *quotaRepositoryImpl) GetByTenantID(ctx context.Context, tenantID uuid.UUID) (*entities.TenantQuota, error) {
+	query := `
+		SELECT tenant_id, daily_messages, daily_segments, monthly_messages, monthly_segments, soft_limit_percent, updated_at
+		FROM tenant_quotas
+		WHERE tenant_id = $1
+	`
+
+	quota := &entities.TenantQuota{}
+	err := r.db.QueryRowContext(ctx, query, tenantID).Scan(
+		&quota.TenantID,
+		&quota.DailyMessages,
+		&quota.DailySegments,
+		&quota.MonthlyMessages,
+		&quota.MonthlySegments,
+		&quota.SoftLimitPercent,
+		&quota.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrQuotaNotFound
+		}
+		return nil, fmt.Errorf("failed to get tenant quota: %w", err)
+	}
+
+	return quota, nil
+}
+
+func (r *quotaRepositoryImpl) Upsert(ctx context.Context, quota *entities.TenantQuota) error {
+	query := `
+		INSERT INTO tenant_quotas (tenant_id, daily_messages, daily_segments, monthly_messages, monthly_segments,
+		                           soft_limit_percent, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET daily_messages = EXCLUDED.daily_messages,
+		    daily_segments = EXCLUDED.daily_segments,
+		    monthly_messages = EXCLUDED.monthly_messages,
+		    monthly_segments = EXCLUDED.monthly_segments,
+		    soft_limit_percent = EXCLUDED.soft_limit_percent,
+		    updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		quota.TenantID,
+		quota.DailyMessages,
+		quota.DailySegments,
+		quota.MonthlyMessages,
+		quota.MonthlySegments,
+		quota.SoftLimitPercent,
+		quota.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save tenant quota: %w", err)
+	}
+
+	return nil
+}
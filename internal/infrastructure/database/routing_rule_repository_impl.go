@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+)
+
+const routingRuleColumns = `id, name, prefix, tenant_id, category, targets, enabled, created_at, updated_at`
+
+func scanRoutingRule(row rowScanner) (*entities.RoutingRule, error) {
+	rule := &entities.RoutingRule{}
+	var targets []byte
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Prefix,
+		&rule.TenantID,
+		&rule.Category,
+		&targets,
+		&rule.Enabled,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(targets, &rule.Targets); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal routing targets: %w", err)
+	}
+
+	return rule, nil
+}
+
+type routingRuleRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewRoutingRuleRepository(db *sql.DB) repositories.RoutingRuleRepository {
+	return &routingRuleRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *routingRuleRepositoryImpl) Create(ctx context.Context, rule *entities.RoutingRule) error {
+	query := `
+		INSERT INTO routing_rules (id, name, prefix, tenant_id, category, targets, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	targets, err := json.Marshal(rule.Targets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal routing targets: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Prefix,
+		rule.TenantID,
+		rule.Category,
+		targets,
+		rule.Enabled,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create routing rule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *routingRuleRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.RoutingRule, error) {
+	query := `SELECT ` + routingRuleColumns + ` FROM routing_rules WHERE id = $1`
+
+	rule, err := scanRoutingRule(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrRoutingRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get routing rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (r *routingRuleRepositoryImpl) GetAll(ctx context.Context) ([]*entities.RoutingRule, error) {
+	query := `SELECT ` + routingRuleColumns + ` FROM routing_rules ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routing rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entities.RoutingRule
+	for rows.Next() {
+		rule, err := scanRoutingRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan routing rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+func (r *routingRuleRepositoryImpl) Update(ctx context.Context, rule *entities.RoutingRule) error {
+	query := `
+		UPDATE routing_rules
+		SET name = $2, prefix = $3, tenant_id = $4, category = $5, targets = $6, enabled = $7, updated_at = $8
+		WHERE id = $1
+	`
+
+	targets, err := json.Marshal(rule.Targets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal routing targets: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Prefix,
+		rule.TenantID,
+		rule.Category,
+		targets,
+		rule.Enabled,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update routing rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrRoutingRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *routingRuleRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM routing_rules WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete routing rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrRoutingRuleNotFound
+	}
+
+	return nil
+}
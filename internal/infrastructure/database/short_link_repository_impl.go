@@ -0,0 +1,170 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+)
+
+type shortLinkRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewShortLinkRepository(db *sql.DB) repositories.ShortLinkRepository {
+	return &shortLinkRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *shortLinkRepositoryImpl) Create(ctx context.Context, link *entities.ShortLink) error {
+	query := `
+		INSERT INTO short_links (code, tenant_id, message_id, campaign, target_url, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+	link.TenantID = tenantID
+
+	_, err = r.db.ExecContext(ctx, query,
+		link.Code,
+		link.TenantID,
+		link.MessageID,
+		link.Campaign,
+		link.TargetURL,
+		link.CreatedAt,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entities.ErrShortCodeTaken
+		}
+		return fmt.Errorf("failed to create short link: %w", err)
+	}
+
+	return nil
+}
+
+func (r *shortLinkRepositoryImpl) DeleteByCodes(ctx context.Context, codes []string) error {
+	query := `DELETE FROM short_links WHERE code = ANY($1) AND tenant_id = $2`
+
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(codes), tenantID); err != nil {
+		return fmt.Errorf("failed to delete short links: %w", err)
+	}
+
+	return nil
+}
+
+// GetByCode herkese acik /r/:code yonlendirmesi icin tenant'siz calisir; kod butun tenant'larda tekil
+func (r *shortLinkRepositoryImpl) GetByCode(ctx context.Context, code string) (*entities.ShortLink, error) {
+	query := `
+		SELECT code, tenant_id, message_id, campaign, target_url, created_at
+		FROM short_links
+		WHERE code = $1
+	`
+
+	link := &entities.ShortLink{}
+	err := r.db.QueryRowContext(ctx, query, code).Scan(
+		&link.Code,
+		&link.TenantID,
+		&link.MessageID,
+		&link.Campaign,
+		&link.TargetURL,
+		&link.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrShortLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to get short link: %w", err)
+	}
+
+	return link, nil
+}
+
+func (r *shortLinkRepositoryImpl) RecordClick(ctx context.Context, click *entities.LinkClick) error {
+	query := `
+		INSERT INTO link_clicks (code, tenant_id, message_id, campaign, clicked_at, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		click.Code,
+		click.TenantID,
+		click.MessageID,
+		click.Campaign,
+		click.ClickedAt,
+		click.IPAddress,
+		click.UserAgent,
+	).Scan(&click.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to record link click: %w", err)
+	}
+
+	return nil
+}
+
+func (r *shortLinkRepositoryImpl) GetClickCountsByMessage(ctx context.Context, messageID uuid.UUID) ([]*entities.LinkClickCount, error) {
+	query := `
+		SELECT l.code, l.message_id, l.target_url, COUNT(c.id)
+		FROM short_links l
+		LEFT JOIN link_clicks c ON c.code = l.code
+		WHERE l.message_id = $1 AND l.tenant_id = $2
+		GROUP BY l.code, l.message_id, l.target_url, l.created_at
+		ORDER BY l.created_at ASC
+	`
+
+	return r.queryClickCounts(ctx, query, messageID)
+}
+
+func (r *shortLinkRepositoryImpl) GetClickCountsByCampaign(ctx context.Context, campaign string) ([]*entities.LinkClickCount, error) {
+	query := `
+		SELECT l.code, l.message_id, l.target_url, COUNT(c.id)
+		FROM short_links l
+		LEFT JOIN link_clicks c ON c.code = l.code
+		WHERE l.campaign = $1 AND l.tenant_id = $2
+		GROUP BY l.code, l.message_id, l.target_url, l.created_at
+		ORDER BY l.created_at ASC
+	`
+
+	return r.queryClickCounts(ctx, query, campaign)
+}
+
+func (r *shortLinkRepositoryImpl) queryClickCounts(ctx context.Context, query string, arg interface{}) ([]*entities.LinkClickCount, error) {
+	tenantID, err := tenantScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, arg, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link click counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*entities.LinkClickCount
+	for rows.Next() {
+		count := &entities.LinkClickCount{}
+		if err := rows.Scan(&count.Code, &count.MessageID, &count.TargetURL, &count.Clicks); err != nil {
+			return nil, fmt.Errorf("failed to scan link click count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
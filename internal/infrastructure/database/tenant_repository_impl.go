@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+)
+
+type tenantRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewTenantRepository(db *sql.DB) repositories.TenantRepository {
+	return &tenantRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *tenantRepositoryImpl) Create(ctx context.Context, tenant *entities.Tenant) error {
+	query := `
+		INSERT INTO tenants (id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	if tenant.ID == uuid.Nil {
+		tenant.ID = uuid.New()
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		tenant.ID,
+		tenant.Name,
+		tenant.CreatedAt,
+		tenant.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create tenant: %w", err)
+	}
+
+	return nil
+}
+
+func (r *tenantRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.Tenant, error) {
+	query := `SELECT id, name, created_at, updated_at FROM tenants WHERE id = $1`
+
+	tenant := &entities.Tenant{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&tenant.ID,
+		&tenant.Name,
+		&tenant.CreatedAt,
+		&tenant.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrTenantNotFound
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	return tenant, nil
+}
+
+func (r *tenantRepositoryImpl) GetAll(ctx context.Context, offset, limit int) ([]*entities.Tenant, error) {
+	query := `
+		SELECT id, name, created_at, updated_at
+		FROM tenants
+		ORDER BY created_at ASC
+		OFFSET $1 LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenants: %w", err)
+	}
+	defer rows.Close()
+
+	var tenants []*entities.Tenant
+	for rows.Next() {
+		tenant := &entities.Tenant{}
+		if err := rows.Scan(&tenant.ID, &tenant.Name, &tenant.CreatedAt, &tenant.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tenant: %w", err)
+		}
+		tenants = append(tenants, tenant)
+	}
+
+	return tenants, rows.Err()
+}
+
+func (r *tenantRepositoryImpl) GetIDsWithPendingMessages(ctx context.Context) ([]uuid.UUID, error) {
+	query := `
+		SELECT tenant_id
+		FROM messages
+		WHERE status = 'pending' AND (next_attempt_at IS NULL OR next_attempt_at <= NOW())
+		GROUP BY tenant_id
+		ORDER BY MIN(created_at) ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenants with pending messages: %w", err)
+	}
+	defer rows.Close()
+
+	var tenantIDs []uuid.UUID
+	for rows.Next() {
+		var tenantID uuid.UUID
+		if err := rows.Scan(&tenantID); err != nil {
+			return nil, fmt.Errorf("failed to scan tenant id: %w", err)
+		}
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+
+	return tenantIDs, rows.Err()
+}
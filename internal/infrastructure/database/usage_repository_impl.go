@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+)
+
+type usageRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewUsageRepository(db *sql.DB) repositories.UsageRepository {
+	return &usageRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *usageRepositoryImpl) Reserve(ctx context.Context, tenantID uuid.UUID, day time.Time, messages, segments int64, check func(day, month entities.UsageRecord) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin usage reservation: %w", err)
+	}
+	defer tx.Rollback()
+
+	// kilit commit ya da rollback'e kadar tutulur, ayni tenant'in rezervasyonlari birbirini bekler
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "tenant_usage:"+tenantID.String()); err != nil {
+		return fmt.Errorf("failed to lock tenant usage: %w", err)
+	}
+
+	dayUsage := entities.UsageRecord{TenantID: tenantID, Period: entities.UsagePeriodDay, PeriodStart: day}
+	monthUsage := entities.UsageRecord{TenantID: tenantID, Period: entities.UsagePeriodMonth, PeriodStart: entities.UsagePeriodStart(entities.UsagePeriodMonth, day)}
+	query := `
+		SELECT COALESCE(SUM(messages) FILTER (WHERE day = $2::date), 0),
+		       COALESCE(SUM(segments) FILTER (WHERE day = $2::date), 0),
+		       COALESCE(SUM(messages), 0),
+		       COALESCE(SUM(segments), 0)
+		FROM tenant_usage
+		WHERE tenant_id = $1 AND day >= $3::date AND day < ($3::date + INTERVAL '1 month')
+	`
+	err = tx.QueryRowContext(ctx, query, tenantID, day.UTC(), monthUsage.PeriodStart.UTC()).
+		Scan(&dayUsage.Messages, &dayUsage.Segments, &monthUsage.Messages, &monthUsage.Segments)
+	if err != nil {
+		return fmt.Errorf("failed to read usage for reservation: %w", err)
+	}
+
+	if err := check(dayUsage, monthUsage); err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO tenant_usage (tenant_id, day, messages, segments)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, day) DO UPDATE
+		SET messages = tenant_usage.messages + EXCLUDED.messages,
+		    segments = tenant_usage.segments + EXCLUDED.segments
+	`
+	if _, err := tx.ExecContext(ctx, query, tenantID, day.UTC(), messages, segments); err != nil {
+		return fmt.Errorf("failed to reserve usage: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit usage reservation: %w", err)
+	}
+	return nil
+}
+
+func (r *usageRepositoryImpl) Release(ctx context.Context, tenantID uuid.UUID, day time.Time, messages, segments int64) error {
+	query := `
+		UPDATE tenant_usage
+		SET messages = GREATEST(messages - $3, 0),
+		    segments = GREATEST(segments - $4, 0)
+		WHERE tenant_id = $1 AND day = $2::date
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, tenantID, day.UTC(), messages, segments); err != nil {
+		return fmt.Errorf("failed to release usage: %w", err)
+	}
+
+	return nil
+}
+
+func (r *usageRepositoryImpl) GetUsage(ctx context.Context, tenantID uuid.UUID, period string, from, to time.Time) ([]*entities.UsageRecord, error) {
+	// period sadece sabitlerden biri olabilir, SQL'e dogrudan girmesi bu yuzden guvenli
+	if period != entities.UsagePeriodDay && period != entities.UsagePeriodMonth {
+		return nil, fmt.Errorf("unknown usage period %q", period)
+	}
+
+	query := `
+		SELECT date_trunc('` + period + `', day)::date AS period_start, SUM(messages), SUM(segments)
+		FROM tenant_usage
+		WHERE tenant_id = $1 AND day >= $2::date AND day <= $3::date
+		GROUP BY period_start
+		ORDER BY period_start DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*entities.UsageRecord
+	for rows.Next() {
+		record := &entities.UsageRecord{TenantID: tenantID, Period: period}
+		if err := rows.Scan(&record.PeriodStart, &record.Messages, &record.Segments); err != nil {
+			return nil, fmt.Errorf("failed to scan usage: %w", err)
+		}
+		record.PeriodStart = record.PeriodStart.UTC()
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
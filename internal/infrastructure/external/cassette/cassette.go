@@ -0,0 +1,351 @@
+// Package cassette provider HTTP trafigini dosyaya kaydedip tekrar oynatan bir http.RoundTripper.
+// Record modunda istekler gercek transport'a gider, istek/cevap ciftleri telefon numaralari ve kimlik
+// bilgileri maskelenerek saklanir. Replay modunda ag hic kullanilmaz, istek kayitli bir ciftle eslesmezse
+// hata doner. Boylece provider sozlesme testleri gercek trafikten alinmis kayitlarla offline calisir.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type Mode string
+
+const (
+	ModeReplay Mode = "replay"
+	ModeRecord Mode = "record"
+)
+
+// EnvMode testlerde kayitlari yenilemek icin: CASSETTE_MODE=record go test ./...
+const EnvMode = "CASSETTE_MODE"
+
+// Redacted maskelenen header degerlerinin yerine yazilir
+const Redacted = "[REDACTED]"
+
+var ErrNoInteraction = errors.New("cassette: no recorded interaction matches the request")
+
+// defaultRedactedHeaders kimlik bilgisi tasiyan header'lar hic kaydedilmez
+var defaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "X-Api-Key", "Cookie", "Set-Cookie"}
+
+// phonePattern E.164 numaralar; form govdesinde + isareti %2B olarak gelir
+var phonePattern = regexp.MustCompile(`(\+|%2B)[1-9][0-9]{6,14}`)
+
+// defaultPhoneFields degeri numara olan JSON, form ve query alanlari; bu alanlarda + olmadan yazilan numaralar da maskelenir
+var defaultPhoneFields = []string{"phone_number", "phone", "msisdn", "to", "from", "recipient", "destination", "destination_addr", "source_addr"}
+
+// Options Transport record modunda isteklerin gonderildigi gercek transport (bos ise http.DefaultTransport).
+// RedactHeaders varsayilanlara ek olarak maskelenecek header'lar (ornegin provider'in API_KEY_HEADER'i).
+// PhoneFields varsayilanlara ek olarak numarasi maskelenecek alanlar (ornegin REQUEST_FIELDS'ta phone_number'in
+// yeni adi; noktali adlarda son parca). Alan adlari buyuk/kucuk harf ayirmadan eslesir.
+type Options struct {
+	Mode          Mode
+	Transport     http.RoundTripper
+	RedactHeaders []string
+	PhoneFields   []string
+}
+
+type Request struct {
+	Method  string              `json:"method"`
+	URL     string              `json:"url"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    string              `json:"body,omitempty"`
+}
+
+type Response struct {
+	Status  int                 `json:"status"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    string              `json:"body,omitempty"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type file struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Cassette tek bir kayit dosyasi. Replay'de her kayit bir kez kullanilir, ayni istek tekrar gelirse
+// siradaki kayitla eslesir.
+type Cassette struct {
+	path          string
+	mode          Mode
+	transport     http.RoundTripper
+	redactHeaders []string
+	phoneFields   *regexp.Regexp
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	phones       map[string]string
+	placeholders map[string]bool
+}
+
+// ModeFromEnv CASSETTE_MODE=record ise record, aksi halde replay
+func ModeFromEnv() Mode {
+	if Mode(os.Getenv(EnvMode)) == ModeRecord {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// Open replay modunda dosyayi okur, dosya yoksa hata doner. Record modunda bos bir kayitla baslar;
+// dosya Save ile yazilir.
+func Open(path string, options Options) (*Cassette, error) {
+	if options.Mode == "" {
+		options.Mode = ModeReplay
+	}
+	if options.Transport == nil {
+		options.Transport = http.DefaultTransport
+	}
+
+	c := &Cassette{
+		path:          path,
+		mode:          options.Mode,
+		transport:     options.Transport,
+		redactHeaders: append(append([]string(nil), defaultRedactedHeaders...), options.RedactHeaders...),
+		phoneFields:   phoneFieldPattern(append(append([]string(nil), defaultPhoneFields...), options.PhoneFields...)),
+		phones:        make(map[string]string),
+		placeholders:  make(map[string]bool),
+	}
+
+	switch options.Mode {
+	case ModeRecord:
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: %w (record it with %s=%s)", err, EnvMode, ModeRecord)
+		}
+		var stored file
+		if err := json.Unmarshal(data, &stored); err != nil {
+			return nil, fmt.Errorf("cassette: decode %s: %w", path, err)
+		}
+		c.interactions = stored.Interactions
+		c.used = make([]bool, len(stored.Interactions))
+	default:
+		return nil, fmt.Errorf("cassette: unknown mode %q (replay, record)", options.Mode)
+	}
+	return c, nil
+}
+
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: read request body: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	recorded := Request{
+		Method:  req.Method,
+		URL:     c.redact(req.URL.String()),
+		Headers: c.redactHeaderValues(req.Header),
+		Body:    c.redact(string(body)),
+	}
+
+	if c.mode == ModeReplay {
+		return c.replay(req, recorded)
+	}
+
+	// gercek istek de kilit altinda gider ki kayit sirasi istek sirasiyla ayni kalsin
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: read response body: %w", err)
+	}
+
+	c.interactions = append(c.interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			Status:  resp.StatusCode,
+			Headers: c.redactHeaderValues(resp.Header),
+			Body:    c.redact(string(respBody)),
+		},
+	})
+	return resp, nil
+}
+
+// replay host'u yok sayar; kayit gercek provider'dan alinsa da test istedigi adrese gonderebilir.
+// Kilit cagiran tarafta tutulur.
+func (c *Cassette) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	for i, interaction := range c.interactions {
+		if c.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		c.used[i] = true
+
+		header := http.Header{}
+		for name, values := range interaction.Response.Headers {
+			header[name] = append([]string(nil), values...)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s %s", ErrNoInteraction, recorded.Method, pathOf(recorded.URL), recorded.Body)
+}
+
+// Unused replay'de hic kullanilmayan kayit sayisi; test sonunda beklenen isteklerin hepsinin geldigini dogrular
+func (c *Cassette) Unused() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := 0
+	for _, used := range c.used {
+		if !used {
+			count++
+		}
+	}
+	return count
+}
+
+// Save record modunda kayitlari dosyaya yazar, replay modunda bir sey yapmaz
+func (c *Cassette) Save() error {
+	if c.mode != ModeRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	data, err := json.MarshalIndent(file{Interactions: c.interactions}, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("cassette: encode: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	return os.WriteFile(c.path, append(data, '\n'), 0o644)
+}
+
+// phoneFieldPattern JSON'da "to": "905551112233" ya da "to": 905551112233, form ve query'de to=905551112233
+// gibi alan adiyla gelen numaralari yakalar; gruplar alan kismi, varsa + ve numara
+func phoneFieldPattern(fields []string) *regexp.Regexp {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = regexp.QuoteMeta(field)
+	}
+	alternatives := strings.Join(names, "|")
+	return regexp.MustCompile(`(?i)("(?:` + alternatives + `)"\s*:\s*"?|(?:^|[?&])(?:` + alternatives + `)=)(\+|%2B)?([0-9]{7,15})\b`)
+}
+
+// redact her farkli numarayi goruldugu sirayla +10000000001, +10000000002... yapar. Once + ile yazilan numaralar,
+// sonra telefon alanlarindaki + olmayan numaralar maskelenir; + yoksa karsiligi da + olmadan yazilir. Replay'de
+// ayni sirayla gelen istekler ayni karsiliklari alir, yani testler kayittakinden farkli numaralar kullanabilir.
+// Kilit cagiran tarafta tutulur.
+func (c *Cassette) redact(value string) string {
+	value = phonePattern.ReplaceAllStringFunc(value, func(match string) string {
+		prefix := "+"
+		if strings.HasPrefix(match, "%2B") {
+			prefix = "%2B"
+		}
+		return prefix + c.placeholder(strings.TrimPrefix(strings.TrimPrefix(match, "%2B"), "+"))
+	})
+	return c.phoneFields.ReplaceAllStringFunc(value, func(match string) string {
+		groups := c.phoneFields.FindStringSubmatch(match)
+		return groups[1] + groups[2] + c.placeholder(groups[3])
+	})
+}
+
+// placeholder ayni numaraya hep ayni karsiligi verir; ilk geciste maskelenmis bir deger oldugu gibi kalir
+func (c *Cassette) placeholder(number string) string {
+	if c.placeholders[number] {
+		return number
+	}
+	placeholder, ok := c.phones[number]
+	if !ok {
+		placeholder = fmt.Sprintf("1%010d", len(c.phones)+1)
+		c.phones[number] = placeholder
+		c.placeholders[placeholder] = true
+	}
+	return placeholder
+}
+
+func (c *Cassette) redactHeaderValues(header http.Header) map[string][]string {
+	if len(header) == 0 {
+		return nil
+	}
+
+	values := make(map[string][]string, len(header))
+	for name, headerValues := range header {
+		redacted := make([]string, len(headerValues))
+		for i, value := range headerValues {
+			if c.isSecretHeader(name) {
+				redacted[i] = Redacted
+			} else {
+				redacted[i] = c.redact(value)
+			}
+		}
+		values[name] = redacted
+	}
+	return values
+}
+
+func (c *Cassette) isSecretHeader(name string) bool {
+	for _, secret := range c.redactHeaders {
+		if strings.EqualFold(secret, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches header'lar eslesmeye dahil degil; degisebilen User-Agent, Content-Length gibi degerler testi bozmasin
+func matches(recorded, incoming Request) bool {
+	return recorded.Method == incoming.Method && pathOf(recorded.URL) == pathOf(incoming.URL) && recorded.Body == incoming.Body
+}
+
+// pathOf URL'in host'tan sonraki kismi (path ve query)
+func pathOf(rawURL string) string {
+	if i := strings.Index(rawURL, "://"); i >= 0 {
+		rawURL = rawURL[i+3:]
+		if j := strings.IndexAny(rawURL, "/?"); j >= 0 {
+			return rawURL[j:]
+		}
+		return "/"
+	}
+	return rawURL
+}
+
+// readBody govdeyi okur ve tekrar okunabilsin diye yerine koyar
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, err
+}
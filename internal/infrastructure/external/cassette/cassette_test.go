@@ -0,0 +1,162 @@
+package cassette
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func post(t *testing.T, client *http.Client, url, body string) (*http.Response, string, error) {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-Key", "secret-key")
+	req.Header.Set("X-Account", "42")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp, string(data), nil
+}
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(string(body), "+905550000001") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid number +905550000001"}`))
+			return
+		}
+		w.Write([]byte(`{"message_id": "ext_1", "status": "sent"}`))
+	}))
+
+	path := filepath.Join(t.TempDir(), "cassettes", "provider.json")
+	recorder, err := Open(path, Options{Mode: ModeRecord, RedactHeaders: []string{"X-Account"}})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	client := &http.Client{Transport: recorder}
+
+	resp, body, err := post(t, client, server.URL+"/send", `{"phone_number": "+905551112233"}`)
+	if err != nil || resp.StatusCode != http.StatusOK || !strings.Contains(body, "ext_1") {
+		t.Fatalf("Expected the live response while recording, got %v %q %v", resp, body, err)
+	}
+	if _, body, _ := post(t, client, server.URL+"/send", `{"phone_number": "+905550000001"}`); !strings.Contains(body, "+905550000001") {
+		t.Errorf("Expected the caller to see the unredacted response, got %q", body)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	for _, secret := range []string{"+905551112233", "+905550000001", "secret-key", `"42"`} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected %s to be redacted from the cassette:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), "+10000000001") || !strings.Contains(string(data), "+10000000002") {
+		t.Errorf("Expected numbered placeholders in the cassette:\n%s", data)
+	}
+
+	// replay baska bir host ve baska numaralarla da calisir, ag kullanilmaz
+	player, err := Open(path, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	client = &http.Client{Transport: player}
+
+	resp, body, err = post(t, client, "https://provider.invalid/send", `{"phone_number": "+447700900001"}`)
+	if err != nil || resp.StatusCode != http.StatusOK || !strings.Contains(body, "ext_1") {
+		t.Fatalf("Expected the recorded success, got %v %q %v", resp, body, err)
+	}
+	resp, body, err = post(t, client, "https://provider.invalid/send", `{"phone_number": "+447700900002"}`)
+	if err != nil || resp.StatusCode != http.StatusBadRequest || !strings.Contains(body, "+10000000002") {
+		t.Fatalf("Expected the recorded rejection, got %v %q %v", resp, body, err)
+	}
+	if player.Unused() != 0 {
+		t.Errorf("Expected every interaction to be used, %d left", player.Unused())
+	}
+
+	if _, _, err := post(t, client, "https://provider.invalid/send", `{"phone_number": "+447700900001"}`); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Expected ErrNoInteraction once the recording is used up, got %v", err)
+	}
+}
+
+func TestCassette_RedactsFormEncodedNumbers(t *testing.T) {
+	c, err := Open("unused.json", Options{Mode: ModeRecord})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	got := c.redact("To=%2B905551112233&From=%2B15005550006&Body=call+905551112233")
+	want := "To=%2B10000000001&From=%2B10000000002&Body=call+10000000001"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestCassette_RedactsPhoneFieldsByKey(t *testing.T) {
+	c, err := Open("unused.json", Options{Mode: ModeRecord, PhoneFields: []string{"gsm"}})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "bare json string",
+			value: `{"msisdn": "905551112233", "message": "Order 12345678 shipped"}`,
+			want:  `{"msisdn": "10000000001", "message": "Order 12345678 shipped"}`,
+		},
+		{
+			name:  "json number and configured field",
+			value: `{"To":905551112233,"recipient":{"gsm":"447700900001"},"from":"ACME"}`,
+			want:  `{"To":10000000001,"recipient":{"gsm":"10000000002"},"from":"ACME"}`,
+		},
+		{
+			name:  "plus and bare forms share a placeholder",
+			value: `{"phone_number":"+905551112233","destination_addr":"905551112233"}`,
+			want:  `{"phone_number":"+10000000001","destination_addr":"10000000001"}`,
+		},
+		{
+			name:  "form body and query",
+			value: "https://sms.example.com/send?to=447700900001&autoto=905550000009&From=905550000003",
+			want:  "https://sms.example.com/send?to=10000000002&autoto=905550000009&From=10000000003",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.redact(tt.value); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOpen_Errors(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "missing.json"), Options{}); err == nil || !strings.Contains(err.Error(), EnvMode) {
+		t.Errorf("Expected a missing cassette to explain how to record it, got %v", err)
+	}
+	if _, err := Open("x.json", Options{Mode: "live"}); err == nil {
+		t.Error("Expected an unknown mode to fail")
+	}
+}
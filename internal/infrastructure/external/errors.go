@@ -0,0 +1,44 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+// transportError cevap alinamayan istekleri siniflandirir: sure asimi timeout, digerleri (DNS, baglanti
+// reddi, kopan baglanti) network
+func transportError(action string, err error) error {
+	code := entities.ErrorCodeNetwork
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		code = entities.ErrorCodeTimeout
+	}
+	return entities.NewProviderError(code, fmt.Errorf("%s: %w", action, err))
+}
+
+// httpStatusCode 429/503 disindaki basarisiz HTTP yanitlarinin sinifi. 401/403 bizim hesabimizla ilgili,
+// 404 genelde yanlis URL; ikisi de baska provider'da duzelebilir.
+func httpStatusCode(status int) entities.ErrorCode {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return entities.ErrorCodeAuth
+	case status == http.StatusRequestTimeout:
+		return entities.ErrorCodeTimeout
+	case status == http.StatusNotFound || status >= 500:
+		return entities.ErrorCodeProviderError
+	case status >= 400:
+		return entities.ErrorCodeRejected
+	default:
+		return entities.ErrorCodeProviderError
+	}
+}
+
+// classified err'i code ile sarar; mesaj metni degismez
+func classified(code entities.ErrorCode, format string, args ...interface{}) error {
+	return entities.NewProviderError(code, fmt.Errorf(format, args...))
+}
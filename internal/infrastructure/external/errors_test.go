@@ -0,0 +1,64 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+func TestTransportError(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	closed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closed.Close()
+
+	tests := []struct {
+		name string
+		url  string
+		want entities.ErrorCode
+	}{
+		{name: "client timeout", url: slow.URL, want: entities.ErrorCodeTimeout},
+		{name: "connection refused", url: closed.URL, want: entities.ErrorCodeNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestHTTPProvider(t, config.ProviderConfig{Name: "primary", URL: tt.url, Timeout: 50 * time.Millisecond})
+			_, err := client.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1234567890", Content: "x"})
+			if code := entities.ErrorCodeOf(err); code != tt.want {
+				t.Errorf("Expected %s, got %s (%v)", tt.want, code, err)
+			}
+			if errors.Is(err, entities.ErrProviderRejected) {
+				t.Errorf("Expected transport error to be transient, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPStatusCode(t *testing.T) {
+	tests := map[int]entities.ErrorCode{
+		400: entities.ErrorCodeRejected,
+		401: entities.ErrorCodeAuth,
+		403: entities.ErrorCodeAuth,
+		404: entities.ErrorCodeProviderError,
+		408: entities.ErrorCodeTimeout,
+		422: entities.ErrorCodeRejected,
+		500: entities.ErrorCodeProviderError,
+		502: entities.ErrorCodeProviderError,
+	}
+
+	for status, want := range tests {
+		if got := httpStatusCode(status); got != want {
+			t.Errorf("Status %d: expected %s, got %s", status, want, got)
+		}
+	}
+}
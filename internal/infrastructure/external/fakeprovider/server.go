@@ -0,0 +1,441 @@
+// Package fakeprovider http adapter'inin (external.MessageAPIClient) konustugu mesaj API'sini taklit eden
+// sunucu. Gelen her istegi kaydeder, /_fake altindaki inceleme API'siyle kayitlari ve senaryolari yonetir,
+// basarili gonderimlerden sonra servise teslim raporu POST eder. cmd/fakeprovider bunu ayri bir surec
+// olarak calistirir; testler de dogrudan httptest ile kullanabilir.
+package fakeprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// InspectPrefix inceleme API'sinin yolu; bunun disindaki her POST mesaj gonderimi sayilir
+const InspectPrefix = "/_fake/"
+
+// messageIDPlaceholder senaryo govdesinde uretilen mesaj ID'si ile degistirilir
+const messageIDPlaceholder = "{{message_id}}"
+
+// ReceiptNone senaryoda teslim raporu gonderilmeyecegini belirtir
+const ReceiptNone = "none"
+
+// Options ReceiptURL istekte callback_url yoksa raporlarin gonderilecegi adres, ikisi de yoksa rapor
+// gonderilmez. ReceiptAPIKey raporlarda X-API-Key olarak gonderilir (receipts:write scope'lu anahtar).
+type Options struct {
+	ReceiptURL    string
+	ReceiptAPIKey string
+	ReceiptDelay  time.Duration
+	Client        *http.Client
+	Logger        *zap.Logger
+}
+
+// Request kaydedilen bir gonderim istegi ve verilen cevap
+type Request struct {
+	ID             int               `json:"id"`
+	Method         string            `json:"method"`
+	Path           string            `json:"path"`
+	Headers        map[string]string `json:"headers"`
+	Body           json.RawMessage   `json:"body"`
+	PhoneNumber    string            `json:"phone_number,omitempty"`
+	ReceivedAt     time.Time         `json:"received_at"`
+	ResponseStatus int               `json:"response_status"`
+	MessageID      string            `json:"message_id,omitempty"`
+	Scripted       bool              `json:"scripted"`
+}
+
+// Rule senaryolu cevap. PhoneNumber bos ise her istege uyar; Times kac istege uygulanacagi (0 = bir kez,
+// -1 = silinene kadar). Body verilmezse varsayilan basari govdesi doner, govdedeki {{message_id}} uretilen
+// ID ile degistirilir. Receipt teslim raporunun durumu: bos ise delivered, "none" ise rapor gonderilmez.
+type Rule struct {
+	PhoneNumber      string            `json:"phone_number,omitempty"`
+	Times            int               `json:"times,omitempty"`
+	Status           int               `json:"status,omitempty"`
+	Headers          map[string]string `json:"headers,omitempty"`
+	Body             json.RawMessage   `json:"body,omitempty"`
+	Delay            Duration          `json:"delay,omitempty"`
+	Receipt          string            `json:"receipt,omitempty"`
+	ReceiptErrorCode string            `json:"receipt_error_code,omitempty"`
+}
+
+// Receipt servise gonderilen (ya da gonderilemeyen) teslim raporu
+type Receipt struct {
+	MessageID      string    `json:"message_id"`
+	Status         string    `json:"status"`
+	ErrorCode      string    `json:"error_code,omitempty"`
+	URL            string    `json:"url"`
+	SentAt         time.Time `json:"sent_at"`
+	ResponseStatus int       `json:"response_status,omitempty"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// Duration JSON'da "2s" gibi yazilan sure
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string like \"2s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+type Server struct {
+	options Options
+
+	mu       sync.Mutex
+	requests []Request
+	rules    []Rule
+	receipts []Receipt
+	pending  map[*time.Timer]struct{}
+	nextID   int
+	closed   bool
+}
+
+func New(options Options) *Server {
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if options.Logger == nil {
+		options.Logger = zap.NewNop()
+	}
+	return &Server{options: options, pending: make(map[*time.Timer]struct{})}
+}
+
+// Close bekleyen teslim raporlarini iptal eder
+func (s *Server) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for timer := range s.pending {
+		timer.Stop()
+	}
+	s.pending = make(map[*time.Timer]struct{})
+}
+
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) Receipts() []Receipt {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Receipt(nil), s.receipts...)
+}
+
+// AddRules senaryolari eklenme sirasiyla denenecek sekilde listenin sonuna koyar
+func (s *Server) AddRules(rules ...Rule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = append(s.rules, rules...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, InspectPrefix) {
+		s.inspect(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "only POST is accepted"})
+		return
+	}
+	s.handleMessage(w, r)
+}
+
+func (s *Server) handleMessage(w http.ResponseWriter, r *http.Request) {
+	var raw bytes.Buffer
+	raw.ReadFrom(r.Body)
+
+	// http adapter'inin varsayilan sozlesmesindeki alanlar; eslesmeyen govde de kaydedilir
+	var fields struct {
+		PhoneNumber string `json:"phone_number"`
+		CallbackURL string `json:"callback_url"`
+	}
+	json.Unmarshal(raw.Bytes(), &fields)
+
+	s.mu.Lock()
+	s.nextID++
+	request := Request{
+		ID:          s.nextID,
+		Method:      r.Method,
+		Path:        r.URL.Path,
+		Headers:     make(map[string]string),
+		Body:        rawJSON(raw.Bytes()),
+		PhoneNumber: fields.PhoneNumber,
+		ReceivedAt:  time.Now(),
+		MessageID:   fmt.Sprintf("fake-%d", s.nextID),
+	}
+	for name := range r.Header {
+		request.Headers[name] = r.Header.Get(name)
+	}
+	rule, scripted := s.takeRule(fields.PhoneNumber)
+	s.mu.Unlock()
+
+	if rule.Delay > 0 {
+		select {
+		case <-time.After(time.Duration(rule.Delay)):
+		case <-r.Context().Done():
+		}
+	}
+
+	status := rule.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	body := []byte(fmt.Sprintf(`{"message_id": %q, "status": "sent"}`, request.MessageID))
+	if len(rule.Body) > 0 {
+		body = bytes.ReplaceAll(rule.Body, []byte(messageIDPlaceholder), []byte(request.MessageID))
+	}
+
+	request.ResponseStatus = status
+	request.Scripted = scripted
+	if status < 200 || status >= 300 {
+		request.MessageID = ""
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	s.mu.Unlock()
+
+	for name, value := range rule.Headers {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+
+	if request.MessageID != "" && rule.Receipt != ReceiptNone {
+		receiptStatus := rule.Receipt
+		if receiptStatus == "" {
+			receiptStatus = "delivered"
+		}
+		url := fields.CallbackURL
+		if url == "" {
+			url = s.options.ReceiptURL
+		}
+		s.scheduleReceipt(Receipt{MessageID: request.MessageID, Status: receiptStatus, ErrorCode: rule.ReceiptErrorCode, URL: url})
+	}
+}
+
+// takeRule telefon numarasina uyan ilk senaryoyu doner ve hakkini dusurur; senaryo yoksa bos Rule ve false.
+// Kilit cagiran tarafta tutulur.
+func (s *Server) takeRule(phoneNumber string) (Rule, bool) {
+	for i, rule := range s.rules {
+		if rule.PhoneNumber != "" && rule.PhoneNumber != phoneNumber {
+			continue
+		}
+
+		switch {
+		case rule.Times < 0:
+		case rule.Times > 1:
+			s.rules[i].Times--
+		default:
+			s.rules = append(s.rules[:i], s.rules[i+1:]...)
+		}
+		return rule, true
+	}
+	return Rule{}, false
+}
+
+func (s *Server) scheduleReceipt(receipt Receipt) {
+	if receipt.URL == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(s.options.ReceiptDelay, func() {
+		s.mu.Lock()
+		delete(s.pending, timer)
+		s.mu.Unlock()
+		s.sendReceipt(receipt)
+	})
+	s.pending[timer] = struct{}{}
+}
+
+// sendReceipt raporu servisin /api/v1/delivery-receipts/{provider} endpoint'inin bekledigi govdeyle gonderir
+func (s *Server) sendReceipt(receipt Receipt) Receipt {
+	receipt.SentAt = time.Now()
+	body, _ := json.Marshal(map[string]string{
+		"message_id":  receipt.MessageID,
+		"status":      receipt.Status,
+		"error_code":  receipt.ErrorCode,
+		"reported_at": receipt.SentAt.UTC().Format(time.RFC3339),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.options.Client.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, receipt.URL, bytes.NewReader(body))
+	if err == nil {
+		req.Header.Set("Content-Type", "application/json")
+		if s.options.ReceiptAPIKey != "" {
+			req.Header.Set("X-API-Key", s.options.ReceiptAPIKey)
+		}
+
+		var resp *http.Response
+		if resp, err = s.options.Client.Do(req); err == nil {
+			resp.Body.Close()
+			receipt.ResponseStatus = resp.StatusCode
+		}
+	}
+	if err != nil {
+		receipt.Error = err.Error()
+		s.options.Logger.Warn("Failed to post delivery receipt", zap.String("message_id", receipt.MessageID), zap.Error(err))
+	} else {
+		s.options.Logger.Info("Delivery receipt posted",
+			zap.String("message_id", receipt.MessageID),
+			zap.String("status", receipt.Status),
+			zap.Int("response_status", receipt.ResponseStatus))
+	}
+
+	s.mu.Lock()
+	s.receipts = append(s.receipts, receipt)
+	s.mu.Unlock()
+	return receipt
+}
+
+// inspect GET/DELETE /_fake/requests, GET/POST/DELETE /_fake/rules, GET/POST/DELETE /_fake/receipts
+func (s *Server) inspect(w http.ResponseWriter, r *http.Request) {
+	resource := strings.Trim(strings.TrimPrefix(r.URL.Path, InspectPrefix), "/")
+
+	switch resource + " " + r.Method {
+	case "requests GET":
+		requests := s.Requests()
+		if phoneNumber := r.URL.Query().Get("phone_number"); phoneNumber != "" {
+			filtered := requests[:0]
+			for _, request := range requests {
+				if request.PhoneNumber == phoneNumber {
+					filtered = append(filtered, request)
+				}
+			}
+			requests = filtered
+		}
+		writeJSON(w, http.StatusOK, nonNil(requests))
+	case "requests DELETE":
+		s.mu.Lock()
+		s.requests = nil
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	case "rules GET":
+		s.mu.Lock()
+		rules := append([]Rule(nil), s.rules...)
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, nonNil(rules))
+	case "rules POST":
+		rules, err := decodeRules(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		s.AddRules(rules...)
+		writeJSON(w, http.StatusCreated, rules)
+	case "rules DELETE":
+		s.mu.Lock()
+		s.rules = nil
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	case "receipts GET":
+		writeJSON(w, http.StatusOK, nonNil(s.Receipts()))
+	case "receipts POST":
+		var receipt Receipt
+		if err := json.NewDecoder(r.Body).Decode(&receipt); err != nil || receipt.MessageID == "" || receipt.Status == "" {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "message_id and status are required"})
+			return
+		}
+		if receipt.URL == "" {
+			receipt.URL = s.options.ReceiptURL
+		}
+		if receipt.URL == "" {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "url is required when no receipt url is configured"})
+			return
+		}
+		writeJSON(w, http.StatusOK, s.sendReceipt(receipt))
+	case "receipts DELETE":
+		s.mu.Lock()
+		s.receipts = nil
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown inspection endpoint"})
+	}
+}
+
+// decodeRules tek bir senaryo nesnesi ya da senaryo dizisi kabul eder
+func decodeRules(r *http.Request) ([]Rule, error) {
+	var raw bytes.Buffer
+	raw.ReadFrom(r.Body)
+	data := bytes.TrimSpace(raw.Bytes())
+
+	var rules []Rule
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &rules); err != nil {
+			return nil, err
+		}
+	} else {
+		var rule Rule
+		if err := json.Unmarshal(data, &rule); err != nil {
+			return nil, err
+		}
+		rules = []Rule{rule}
+	}
+
+	for _, rule := range rules {
+		if rule.Status != 0 && (rule.Status < 100 || rule.Status > 599) {
+			return nil, fmt.Errorf("invalid status %d", rule.Status)
+		}
+		if len(rule.Body) > 0 && !json.Valid(rule.Body) {
+			return nil, fmt.Errorf("body must be JSON")
+		}
+	}
+	return rules, nil
+}
+
+// rawJSON JSON olmayan govdeyi metin olarak saklar ki kayit listesi her zaman gecerli JSON olsun
+func rawJSON(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return json.RawMessage("null")
+	}
+	if json.Valid(body) {
+		return json.RawMessage(append([]byte(nil), body...))
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
+
+// nonNil bos listeyi null yerine [] olarak yazdirir
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
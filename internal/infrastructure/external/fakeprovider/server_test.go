@@ -0,0 +1,204 @@
+package fakeprovider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+	"message-sending-service/internal/infrastructure/external"
+)
+
+func newTestServer(t *testing.T, options Options) (*Server, *httptest.Server) {
+	fake := New(options)
+	server := httptest.NewServer(fake)
+	t.Cleanup(func() {
+		server.Close()
+		fake.Close()
+	})
+	return fake, server
+}
+
+func newTestProvider(t *testing.T, url, callbackURL string) *external.MessageAPIClient {
+	provider, err := external.NewHTTPProvider(config.ProviderConfig{
+		Name:        "fake",
+		URL:         url + "/send",
+		Timeout:     5 * time.Second,
+		CallbackURL: callbackURL,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	return provider
+}
+
+func sendRequest(phoneNumber string) entities.SendRequest {
+	return entities.SendRequest{MessageID: uuid.New(), PhoneNumber: phoneNumber, Content: "Hello"}
+}
+
+func TestServer_RecordsRequestsAndPostsReceipts(t *testing.T) {
+	receipts := make(chan map[string]string, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		body["api_key"] = r.Header.Get("X-API-Key")
+		receipts <- body
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	fake, server := newTestServer(t, Options{ReceiptAPIKey: "receipt-key", ReceiptDelay: 10 * time.Millisecond})
+	provider := newTestProvider(t, server.URL, receiver.URL)
+
+	result, err := provider.Send(context.Background(), sendRequest("+905551112233"))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.ExternalMessageID != "fake-1" {
+		t.Errorf("Expected message id fake-1, got %s", result.ExternalMessageID)
+	}
+
+	requests := fake.Requests()
+	if len(requests) != 1 || requests[0].Path != "/send" || requests[0].PhoneNumber != "+905551112233" || requests[0].ResponseStatus != http.StatusOK {
+		t.Fatalf("Unexpected recorded requests %+v", requests)
+	}
+
+	select {
+	case receipt := <-receipts:
+		if receipt["message_id"] != "fake-1" || receipt["status"] != "delivered" || receipt["api_key"] != "receipt-key" {
+			t.Errorf("Unexpected receipt %v", receipt)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a delivery receipt")
+	}
+}
+
+func TestServer_ScriptedRules(t *testing.T) {
+	fake, server := newTestServer(t, Options{})
+	provider := newTestProvider(t, server.URL, "")
+
+	resp, err := http.Post(server.URL+"/_fake/rules", "application/json", strings.NewReader(`[
+		{"phone_number": "+905550000001", "status": 429, "headers": {"Retry-After": "3"}, "body": {"error": "slow down"}},
+		{"phone_number": "+905550000002", "times": -1, "body": {"message_id": "{{message_id}}", "status": "failed", "error": "invalid number"}}
+	]`))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d", resp.StatusCode)
+	}
+
+	// ilk senaryo bir kez uygulanir, sonra varsayilan cevaba doner
+	_, err = provider.Send(context.Background(), sendRequest("+905550000001"))
+	var throttled *entities.ProviderThrottledError
+	if !errors.As(err, &throttled) || throttled.RetryAfter != 3*time.Second {
+		t.Errorf("Expected throttled error with Retry-After 3s, got %v", err)
+	}
+	if _, err := provider.Send(context.Background(), sendRequest("+905550000001")); err != nil {
+		t.Errorf("Expected default response after the rule was used, got %v", err)
+	}
+
+	// ikinci senaryo silinene kadar gecerli
+	for i := 0; i < 2; i++ {
+		_, err := provider.Send(context.Background(), sendRequest("+905550000002"))
+		if entities.ErrorCodeOf(err) != entities.ErrorCodeRejected {
+			t.Errorf("Expected rejected error, got %v", err)
+		}
+	}
+
+	var rules []Rule
+	getJSON(t, server.URL+"/_fake/rules", &rules)
+	if len(rules) != 1 || rules[0].PhoneNumber != "+905550000002" {
+		t.Errorf("Expected only the forever rule to remain, got %+v", rules)
+	}
+
+	var requests []Request
+	getJSON(t, server.URL+"/_fake/requests?phone_number=%2B905550000002", &requests)
+	if len(requests) != 2 || !requests[0].Scripted {
+		t.Errorf("Unexpected filtered requests %+v", requests)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, server.URL+"/_fake/requests", nil)
+	if resp, err := http.DefaultClient.Do(req); err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("Expected 204 on delete, got %v %v", resp, err)
+	}
+	if len(fake.Requests()) != 0 {
+		t.Errorf("Expected requests to be cleared")
+	}
+}
+
+func TestServer_InvalidRule(t *testing.T) {
+	_, server := newTestServer(t, Options{})
+
+	for _, body := range []string{`{"status": 42}`, `{"body": "not json"`, `[{"times": "x"}]`} {
+		resp, err := http.Post(server.URL+"/_fake/rules", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, resp.StatusCode)
+		}
+	}
+}
+
+func TestServer_ManualReceiptAndNoReceipt(t *testing.T) {
+	posted := make(chan string, 2)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		posted <- body["status"]
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer receiver.Close()
+
+	fake, server := newTestServer(t, Options{ReceiptURL: receiver.URL})
+	fake.AddRules(Rule{Receipt: ReceiptNone})
+
+	if _, err := newTestProvider(t, server.URL, "").Send(context.Background(), sendRequest("+905551112233")); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	resp, err := http.Post(server.URL+"/_fake/receipts", "application/json", strings.NewReader(`{"message_id": "fake-1", "status": "expired"}`))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	var receipt Receipt
+	json.NewDecoder(resp.Body).Decode(&receipt)
+	resp.Body.Close()
+	if receipt.ResponseStatus != http.StatusNotFound || receipt.URL != receiver.URL {
+		t.Errorf("Unexpected manual receipt %+v", receipt)
+	}
+
+	select {
+	case status := <-posted:
+		if status != "expired" {
+			t.Errorf("Expected only the manual receipt, got %s", status)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the manual receipt to be posted")
+	}
+	if receipts := fake.Receipts(); len(receipts) != 1 {
+		t.Errorf("Expected one recorded receipt, got %+v", receipts)
+	}
+}
+
+func getJSON(t *testing.T, url string, value interface{}) {
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(value); err != nil {
+		t.Fatalf("Failed to decode %s: %v", url, err)
+	}
+}
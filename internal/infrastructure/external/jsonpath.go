@@ -0,0 +1,116 @@
+package external
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseJSONPath "data.messages[0].id" ya da "$.data.messages.0.id" bicimindeki yolu parcalara ayirir.
+// Sayi olan parcalar dizide index, digerleri nesnede alan adi olarak kullanilir.
+func parseJSONPath(path string) ([]string, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if trimmed == "" {
+		return nil, fmt.Errorf("empty json path %q", path)
+	}
+
+	var segments []string
+	for _, part := range strings.Split(trimmed, ".") {
+		name, rest, hasIndex := strings.Cut(part, "[")
+		if name == "" && !hasIndex {
+			return nil, fmt.Errorf("invalid json path %q", path)
+		}
+		if name != "" {
+			segments = append(segments, name)
+		}
+
+		for hasIndex {
+			var index string
+			index, rest, hasIndex = strings.Cut(rest, "]")
+			if !hasIndex {
+				return nil, fmt.Errorf("invalid json path %q: missing ]", path)
+			}
+			if _, err := strconv.Atoi(index); err != nil {
+				return nil, fmt.Errorf("invalid json path %q: index %q is not a number", path, index)
+			}
+			segments = append(segments, index)
+
+			if rest == "" {
+				break
+			}
+			if !strings.HasPrefix(rest, "[") {
+				return nil, fmt.Errorf("invalid json path %q", path)
+			}
+			rest, hasIndex = rest[1:], true
+		}
+	}
+	return segments, nil
+}
+
+// lookupJSONPath json.Decoder.UseNumber ile cozulmus dokumanda yolu izler
+func lookupJSONPath(doc interface{}, segments []string) (interface{}, bool) {
+	current := doc
+	for _, segment := range segments {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// jsonScalar string, sayi ya da bool degeri metne cevirir; nesne, dizi ve null icin false
+func jsonScalar(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+// setJSONPath istek govdesinde yolu gerekirse ara nesneleri olusturarak yazar; index desteklenmez,
+// ayni alana ikinci kez yazmak hatadir
+func setJSONPath(doc map[string]interface{}, segments []string, value interface{}) error {
+	current := doc
+	for i, segment := range segments {
+		if i == len(segments)-1 {
+			if _, exists := current[segment]; exists {
+				return fmt.Errorf("field %q is set twice", strings.Join(segments, "."))
+			}
+			current[segment] = value
+			return nil
+		}
+
+		next, ok := current[segment]
+		if !ok {
+			child := make(map[string]interface{})
+			current[segment] = child
+			current = child
+			continue
+		}
+		child, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("field %q is used both as a value and an object", strings.Join(segments[:i+1], "."))
+		}
+		current = child
+	}
+	return nil
+}
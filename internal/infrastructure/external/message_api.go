@@ -4,33 +4,139 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"message-sending-service/internal/domain/entities"
 	"message-sending-service/internal/infrastructure/config"
 )
 
+// MessageAPIClient "http" adapter'i: mesaji JSON olarak tek bir URL'e POST eder. Govdedeki alan adlari,
+// kimlik dogrulama ve yanittan ID/durum okuma provider ayarlarindan gelir (config.HTTPAdapterConfig).
 type MessageAPIClient struct {
-	baseURL    string
-	httpClient *http.Client
+	name        string
+	baseURL     string
+	username    string
+	password    string
+	callbackURL string
+	mapping     config.HTTPAdapterConfig
+	fields      map[string][]string
+	idPath      []string
+	statusPath  []string
+	errorPath   []string
+	httpClient  *http.Client
 }
 
-func NewMessageAPIClient(cfg *config.Config) *MessageAPIClient {
-	return &MessageAPIClient{
-		baseURL: cfg.External.MessageAPIURL,
+// httpRequestFields RequestFields'ta yeniden adlandirilabilen alanlar; sender_id ve callback_url bossa gonderilmez
+var httpRequestFields = []string{"phone_number", "message", "sender_id", "callback_url"}
+
+func NewMessageAPIClient(cfg *config.Config) (*MessageAPIClient, error) {
+	return NewHTTPProvider(cfg.External.PrimaryProvider())
+}
+
+// NewHTTPProvider bos birakilan mapping ayarlari icin config.DefaultHTTPAdapterConfig kullanilir
+func NewHTTPProvider(providerConfig config.ProviderConfig) (*MessageAPIClient, error) {
+	mapping := withHTTPDefaults(providerConfig.HTTP)
+
+	client := &MessageAPIClient{
+		name:        providerConfig.Name,
+		baseURL:     providerConfig.URL,
+		username:    providerConfig.Username,
+		password:    providerConfig.Password,
+		callbackURL: providerConfig.CallbackURL,
+		mapping:     mapping,
+		fields:      make(map[string][]string),
 		httpClient: &http.Client{
-			Timeout: cfg.External.Timeout,
+			Timeout: providerConfig.Timeout,
 		},
 	}
+
+	switch mapping.Auth {
+	case config.HTTPAuthNone:
+	case config.HTTPAuthBearer, config.HTTPAuthAPIKey:
+		if providerConfig.Password == "" {
+			return nil, fmt.Errorf("%w: http adapter with %s auth needs the token in PASSWORD", entities.ErrInvalidProviderConfig, mapping.Auth)
+		}
+	case config.HTTPAuthBasic:
+		if providerConfig.Username == "" || providerConfig.Password == "" {
+			return nil, fmt.Errorf("%w: http adapter with basic auth needs USERNAME and PASSWORD", entities.ErrInvalidProviderConfig)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown http auth %q (none, bearer, basic, api_key)", entities.ErrInvalidProviderConfig, mapping.Auth)
+	}
+
+	for field := range mapping.RequestFields {
+		if !slices.Contains(httpRequestFields, field) {
+			return nil, fmt.Errorf("%w: unknown request field %q (available: %v)", entities.ErrInvalidProviderConfig, field, httpRequestFields)
+		}
+	}
+	for _, field := range httpRequestFields {
+		name := field
+		if mapped := mapping.RequestFields[field]; mapped != "" {
+			name = mapped
+		}
+		segments, err := parseJSONPath(name)
+		if err != nil || strings.Contains(name, "[") {
+			return nil, fmt.Errorf("%w: request field %s: invalid name %q", entities.ErrInvalidProviderConfig, field, name)
+		}
+		client.fields[field] = segments
+	}
+	// ayni alana iki deger yazilmasin diye ornek govde bir kez kurulur
+	if _, err := client.buildBody(map[string]string{"phone_number": "+10000000000", "message": "x", "sender_id": "x", "callback_url": "x"}); err != nil {
+		return nil, fmt.Errorf("%w: %v", entities.ErrInvalidProviderConfig, err)
+	}
+
+	var err error
+	if client.idPath, err = parseJSONPath(mapping.MessageIDPath); err != nil {
+		return nil, fmt.Errorf("%w: message id path: %v", entities.ErrInvalidProviderConfig, err)
+	}
+	if mapping.StatusPath != "-" {
+		if client.statusPath, err = parseJSONPath(mapping.StatusPath); err != nil {
+			return nil, fmt.Errorf("%w: status path: %v", entities.ErrInvalidProviderConfig, err)
+		}
+	}
+	if client.errorPath, err = parseJSONPath(mapping.ErrorPath); err != nil {
+		return nil, fmt.Errorf("%w: error path: %v", entities.ErrInvalidProviderConfig, err)
+	}
+
+	return client, nil
 }
 
-type SendMessageRequest struct {
-	PhoneNumber string `json:"phone_number"`
-	Message     string `json:"message"`
+// SetTransport istekleri gonderen transport'u degistirir; testlerde cassette kaydi icin kullanilir
+func (c *MessageAPIClient) SetTransport(transport http.RoundTripper) {
+	c.httpClient.Transport = transport
+}
+
+func withHTTPDefaults(mapping config.HTTPAdapterConfig) config.HTTPAdapterConfig {
+	defaults := config.DefaultHTTPAdapterConfig()
+	if mapping.Auth == "" {
+		mapping.Auth = defaults.Auth
+	}
+	if mapping.APIKeyHeader == "" {
+		mapping.APIKeyHeader = defaults.APIKeyHeader
+	}
+	if mapping.MessageIDPath == "" {
+		mapping.MessageIDPath = defaults.MessageIDPath
+	}
+	if mapping.StatusPath == "" {
+		mapping.StatusPath = defaults.StatusPath
+	}
+	if mapping.ErrorPath == "" {
+		mapping.ErrorPath = defaults.ErrorPath
+	}
+	if len(mapping.SuccessStatuses) == 0 {
+		mapping.SuccessStatuses = defaults.SuccessStatuses
+	}
+	return mapping
 }
 
+// SendMessageResponse yanittan mapping yollariyla okunan degerler
 type SendMessageResponse struct {
 	MessageID string `json:"message_id"`
 	Status    string `json:"status"`
@@ -38,15 +144,33 @@ type SendMessageResponse struct {
 	Error     string `json:"error,omitempty"`
 }
 
-func (c *MessageAPIClient) SendMessage(ctx context.Context, phoneNumber, message string) (*SendMessageResponse, error) {
-	request := SendMessageRequest{
-		PhoneNumber: phoneNumber,
-		Message:     message,
+func (c *MessageAPIClient) Name() string {
+	return c.name
+}
+
+func (c *MessageAPIClient) Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+	response, err := c.SendMessage(ctx, request.PhoneNumber, request.Content, request.SenderID)
+	if err != nil {
+		return nil, err
 	}
 
-	jsonData, err := json.Marshal(request)
+	if c.statusPath != nil && !slices.Contains(c.mapping.SuccessStatuses, response.Status) {
+		errorMsg := response.Error
+		if errorMsg == "" {
+			errorMsg = fmt.Sprintf("provider returned status %q", response.Status)
+		}
+		return nil, classified(entities.ErrorCodeRejected, "%w: %s", entities.ErrProviderRejected, errorMsg)
+	}
+
+	return &entities.SendResult{Provider: c.name, ExternalMessageID: response.MessageID}, nil
+}
+
+// SendMessage senderID bos ise alan gonderilmez ve provider kendi varsayilan originator'unu kullanir.
+// 2xx yanit JSON degilse ya da mesaj ID'si yoksa hata doner; provider sozlesmesi bozulmus demektir.
+func (c *MessageAPIClient) SendMessage(ctx context.Context, phoneNumber, message, senderID string) (*SendMessageResponse, error) {
+	jsonData, err := c.requestBody(phoneNumber, message, senderID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, err
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL, bytes.NewBuffer(jsonData))
@@ -56,44 +180,138 @@ func (c *MessageAPIClient) SendMessage(ctx context.Context, phoneNumber, message
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "Insider-Sending-Service/1.0")
+	for name, value := range c.mapping.Headers {
+		req.Header.Set(name, value)
+	}
+	switch c.mapping.Auth {
+	case config.HTTPAuthBearer:
+		req.Header.Set("Authorization", "Bearer "+c.password)
+	case config.HTTPAuthBasic:
+		req.SetBasicAuth(c.username, c.password)
+	case config.HTTPAuthAPIKey:
+		req.Header.Set(c.mapping.APIKeyHeader, c.password)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, transportError("failed to send request", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, transportError("failed to read response body", err)
 	}
 
+	doc, parseErr := decodeJSON(body)
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		response := &SendMessageResponse{
-			MessageID: generateMockMessageID(),
-			Status:    "sent",
-			Message:   "Message sent successfully",
+		if parseErr != nil {
+			return nil, classified(entities.ErrorCodeProviderError, "unparseable response from provider (HTTP %d): %w", resp.StatusCode, parseErr)
 		}
+		return c.parseResponse(doc)
+	}
+
+	errorResponse := SendMessageResponse{Status: "failed", Error: fmt.Sprintf("HTTP %d: %s", resp.StatusCode, string(body))}
+	if parseErr == nil {
+		if errorMsg, ok := c.lookupString(doc, c.errorPath); ok && errorMsg != "" {
+			errorResponse.Error = errorMsg
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		throttled := &entities.ProviderThrottledError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+		return &errorResponse, fmt.Errorf("API request failed with status %d: %w", resp.StatusCode, throttled)
+	}
 
-		var apiResponse SendMessageResponse
-		if err := json.Unmarshal(body, &apiResponse); err == nil && apiResponse.MessageID != "" {
-			response = &apiResponse
+	// genel adapter'da 4xx mapping'in yanlis olmasindan da gelebilir; ret sadece yanittaki durumdan okunur
+	code := httpStatusCode(resp.StatusCode)
+	if code.IsPermanent() {
+		code = entities.ErrorCodeProviderError
+	}
+	return &errorResponse, classified(code, "API request failed with status %d: %s", resp.StatusCode, errorResponse.Error)
+}
+
+func (c *MessageAPIClient) requestBody(phoneNumber, message, senderID string) ([]byte, error) {
+	return c.buildBody(map[string]string{"phone_number": phoneNumber, "message": message, "sender_id": senderID, "callback_url": c.callbackURL})
+}
+
+func (c *MessageAPIClient) buildBody(values map[string]string) ([]byte, error) {
+	body := make(map[string]interface{})
+	for _, field := range httpRequestFields {
+		if values[field] == "" && (field == "sender_id" || field == "callback_url") {
+			continue
 		}
+		if err := setJSONPath(body, c.fields[field], values[field]); err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+	}
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	return jsonData, nil
+}
 
-		return response, nil
+func (c *MessageAPIClient) parseResponse(doc interface{}) (*SendMessageResponse, error) {
+	response := &SendMessageResponse{}
+
+	messageID, ok := c.lookupString(doc, c.idPath)
+	if !ok || messageID == "" {
+		return nil, classified(entities.ErrorCodeProviderError, "provider response has no message id at %q", c.mapping.MessageIDPath)
 	}
+	response.MessageID = messageID
 
-	var errorResponse SendMessageResponse
-	if err := json.Unmarshal(body, &errorResponse); err != nil {
-		errorResponse = SendMessageResponse{
-			Status: "failed",
-			Error:  fmt.Sprintf("HTTP %d: %s", resp.StatusCode, string(body)),
+	if c.statusPath != nil {
+		status, ok := c.lookupString(doc, c.statusPath)
+		if !ok {
+			return nil, classified(entities.ErrorCodeProviderError, "provider response has no status at %q", c.mapping.StatusPath)
 		}
+		response.Status = status
 	}
+	response.Error, _ = c.lookupString(doc, c.errorPath)
+
+	return response, nil
+}
+
+func (c *MessageAPIClient) lookupString(doc interface{}, path []string) (string, bool) {
+	value, ok := lookupJSONPath(doc, path)
+	if !ok {
+		return "", false
+	}
+	return jsonScalar(value)
+}
 
-	return &errorResponse, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+func decodeJSON(body []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after JSON document")
+	}
+	return doc, nil
 }
 
-func generateMockMessageID() string {
-	return fmt.Sprintf("msg_%d", time.Now().UnixNano())
+// parseRetryAfter saniye ya da HTTP tarihi kabul eder; bos ya da gecersizse 0
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
 }
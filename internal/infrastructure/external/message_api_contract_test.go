@@ -0,0 +1,83 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+	"message-sending-service/internal/infrastructure/external/cassette"
+)
+
+// kayit sirasinda isteklerin gidecegi provider adresi ve token'i; replay'de kullanilmaz, token kayda yazilmaz
+const (
+	envContractURL   = "CASSETTE_HTTP_PROVIDER_URL"
+	envContractToken = "CASSETTE_HTTP_PROVIDER_TOKEN"
+)
+
+// newContractProvider replay modunda kayittan, CASSETTE_MODE=record iken CASSETTE_HTTP_PROVIDER_URL'deki
+// provider'a konusan bir http adapter kurar; kayit test bitince yazilir
+func newContractProvider(t *testing.T, name string) (*MessageAPIClient, *cassette.Cassette) {
+	url, token := "https://provider.invalid/v1/messages", "replay-token"
+	mode := cassette.ModeFromEnv()
+	if mode == cassette.ModeRecord {
+		if url = os.Getenv(envContractURL); url == "" {
+			t.Skipf("%s is needed to record %s", envContractURL, name)
+		}
+		token = os.Getenv(envContractToken)
+	}
+
+	recorder, err := cassette.Open("testdata/cassettes/"+name+".json", cassette.Options{Mode: mode})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Save(); err != nil {
+			t.Errorf("Failed to save cassette: %v", err)
+		}
+	})
+
+	provider := newTestHTTPProvider(t, config.ProviderConfig{
+		Name:     "contract",
+		URL:      url,
+		Timeout:  10 * time.Second,
+		Password: token,
+		HTTP:     config.HTTPAdapterConfig{Auth: config.HTTPAuthBearer},
+	})
+	provider.SetTransport(recorder)
+	return provider, recorder
+}
+
+func TestMessageAPIClient_Contract(t *testing.T) {
+	provider, recorder := newContractProvider(t, "http_provider")
+	ctx := context.Background()
+
+	// numaralar kayitta maskelenir; gonderim sirasi kayittakiyle ayni kalmali
+	result, err := provider.Send(ctx, entities.SendRequest{MessageID: uuid.New(), PhoneNumber: "+905551112233", Content: "Your code is 1234"})
+	if err != nil {
+		t.Fatalf("Expected the send to succeed, got %v", err)
+	}
+	if result.ExternalMessageID == "" {
+		t.Error("Expected a provider message id")
+	}
+
+	_, err = provider.Send(ctx, entities.SendRequest{MessageID: uuid.New(), PhoneNumber: "+905550000001", Content: "Your code is 1234"})
+	if !entities.ErrorCodeOf(err).IsPermanent() {
+		t.Errorf("Expected a permanent rejection for an invalid number, got %s (%v)", entities.ErrorCodeOf(err), err)
+	}
+
+	_, err = provider.Send(ctx, entities.SendRequest{MessageID: uuid.New(), PhoneNumber: "+905550000002", Content: "Your code is 1234"})
+	var throttled *entities.ProviderThrottledError
+	if !errors.As(err, &throttled) || throttled.RetryAfter <= 0 {
+		t.Errorf("Expected a throttled error with Retry-After, got %v", err)
+	}
+
+	if recorder.Mode() == cassette.ModeReplay && recorder.Unused() != 0 {
+		t.Errorf("Expected every recorded interaction to be replayed, %d left", recorder.Unused())
+	}
+}
@@ -2,14 +2,30 @@ package external
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"message-sending-service/internal/domain/entities"
 	"message-sending-service/internal/infrastructure/config"
 )
 
+func newTestMessageAPIClient(t *testing.T, cfg *config.Config) *MessageAPIClient {
+	return newTestHTTPProvider(t, cfg.External.PrimaryProvider())
+}
+
+func newTestHTTPProvider(t *testing.T, providerConfig config.ProviderConfig) *MessageAPIClient {
+	client, err := NewHTTPProvider(providerConfig)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	return client
+}
+
 func TestMessageAPIClient_SendMessage(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -39,13 +55,22 @@ func TestMessageAPIClient_SendMessage(t *testing.T) {
 			expectedStatus: "",
 		},
 		{
-			name:           "success with webhook response",
+			name:           "success without message id",
 			serverResponse: `{"success": true}`,
 			serverStatus:   200,
 			phoneNumber:    "+1234567890",
 			message:        "Test message",
-			expectError:    false,
-			expectedStatus: "sent",
+			expectError:    true,
+			expectedStatus: "",
+		},
+		{
+			name:           "unparseable success response",
+			serverResponse: `<html>OK</html>`,
+			serverStatus:   200,
+			phoneNumber:    "+1234567890",
+			message:        "Test message",
+			expectError:    true,
+			expectedStatus: "",
 		},
 	}
 
@@ -63,10 +88,10 @@ func TestMessageAPIClient_SendMessage(t *testing.T) {
 					Timeout:       5 * time.Second,
 				},
 			}
-			client := NewMessageAPIClient(cfg)
+			client := newTestMessageAPIClient(t, cfg)
 
 			ctx := context.Background()
-			response, err := client.SendMessage(ctx, tt.phoneNumber, tt.message)
+			response, err := client.SendMessage(ctx, tt.phoneNumber, tt.message, "")
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -101,29 +126,228 @@ func TestMessageAPIClient_SendMessage_Timeout(t *testing.T) {
 			Timeout:       50 * time.Millisecond,
 		},
 	}
-	client := NewMessageAPIClient(cfg)
+	client := newTestMessageAPIClient(t, cfg)
 
 	ctx := context.Background()
-	_, err := client.SendMessage(ctx, "+1234567890", "Test")
+	_, err := client.SendMessage(ctx, "+1234567890", "Test", "")
 
 	if err == nil {
 		t.Error("Expected timeout error but got none")
 	}
 }
 
-func TestGenerateMockMessageID(t *testing.T) {
-	id1 := generateMockMessageID()
-	id2 := generateMockMessageID()
+func TestMessageAPIClient_SendMessage_SenderID(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = nil
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(200)
+		w.Write([]byte(`{"message_id": "test", "status": "sent"}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		External: config.ExternalConfig{
+			MessageAPIURL: server.URL,
+			Timeout:       5 * time.Second,
+		},
+	}
+	client := newTestMessageAPIClient(t, cfg)
+
+	if _, err := client.SendMessage(context.Background(), "+1234567890", "Test", "INSIDER"); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if received["sender_id"] != "INSIDER" {
+		t.Errorf("Expected sender_id INSIDER, got %v", received["sender_id"])
+	}
 
-	if id1 == id2 {
-		t.Error("Expected different message IDs")
+	if _, err := client.SendMessage(context.Background(), "+1234567890", "Test", ""); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if _, ok := received["sender_id"]; ok {
+		t.Error("Expected sender_id to be omitted when empty")
+	}
+	if _, ok := received["callback_url"]; ok {
+		t.Error("Expected callback_url to be omitted without a callback url")
 	}
+}
 
-	if id1 == "" {
-		t.Error("Expected non-empty message ID")
+func TestMessageAPIClient_Send_Throttled(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		want       time.Duration
+		throttled  bool
+	}{
+		{name: "429 with seconds", status: http.StatusTooManyRequests, retryAfter: "7", want: 7 * time.Second, throttled: true},
+		{name: "503 without header", status: http.StatusServiceUnavailable, throttled: true},
+		{name: "500 is not throttling", status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := newTestHTTPProvider(t, config.ProviderConfig{Name: "primary", URL: server.URL, Timeout: 5 * time.Second})
+			_, err := client.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1234567890"})
+
+			var throttled *entities.ProviderThrottledError
+			if errors.As(err, &throttled) != tt.throttled {
+				t.Fatalf("Expected throttled=%v, got %v", tt.throttled, err)
+			}
+			if tt.throttled && throttled.RetryAfter != tt.want {
+				t.Errorf("Expected retry after %s, got %s", tt.want, throttled.RetryAfter)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if got := parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now); got != 90*time.Second {
+		t.Errorf("Expected 90s from HTTP date, got %s", got)
+	}
+	if got := parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now); got != 0 {
+		t.Errorf("Expected 0 for past date, got %s", got)
+	}
+	if got := parseRetryAfter("soon", now); got != 0 {
+		t.Errorf("Expected 0 for invalid value, got %s", got)
+	}
+}
+
+func TestMessageAPIClient_Send_Mapping(t *testing.T) {
+	var received map[string]interface{}
+	var header http.Header
+	body := `{"data": {"messages": [{"id": 9001, "state": "ACCEPTED"}]}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, header = nil, r.Header
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := newTestHTTPProvider(t, config.ProviderConfig{
+		Name:        "acme",
+		URL:         server.URL,
+		Timeout:     5 * time.Second,
+		Password:    "token-1",
+		CallbackURL: "https://sms.example.com/api/v1/delivery-receipts/acme",
+		HTTP: config.HTTPAdapterConfig{
+			Auth:            config.HTTPAuthBearer,
+			Headers:         map[string]string{"X-Account": "42"},
+			RequestFields:   map[string]string{"phone_number": "recipient.msisdn", "message": "text", "sender_id": "recipient.from", "callback_url": "notify_url"},
+			MessageIDPath:   "$.data.messages[0].id",
+			StatusPath:      "data.messages.0.state",
+			ErrorPath:       "data.messages[0].reason",
+			SuccessStatuses: []string{"ACCEPTED", "QUEUED"},
+		},
+	})
+
+	result, err := client.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233", Content: "Hello", SenderID: "INSIDER"})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.ExternalMessageID != "9001" {
+		t.Errorf("Expected numeric id to be read as 9001, got %q", result.ExternalMessageID)
+	}
+
+	recipient, _ := received["recipient"].(map[string]interface{})
+	if received["text"] != "Hello" || recipient["msisdn"] != "+905551112233" || recipient["from"] != "INSIDER" ||
+		received["notify_url"] != "https://sms.example.com/api/v1/delivery-receipts/acme" {
+		t.Errorf("Unexpected request body %v", received)
+	}
+	if header.Get("Authorization") != "Bearer token-1" || header.Get("X-Account") != "42" {
+		t.Errorf("Unexpected headers %v", header)
+	}
+
+	body = `{"data": {"messages": [{"id": 9002, "state": "BLOCKED", "reason": "number on blocklist"}]}}`
+	if _, err := client.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233", Content: "Hello"}); !errors.Is(err, entities.ErrProviderRejected) {
+		t.Errorf("Expected ErrProviderRejected, got %v", err)
+	}
+
+	body = `{"data": {"messages": []}}`
+	_, err = client.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233", Content: "Hello"})
+	if err == nil || errors.Is(err, entities.ErrProviderRejected) || entities.ErrorCodeOf(err) != entities.ErrorCodeProviderError {
+		t.Errorf("Expected contract error, got %v", err)
+	}
+}
+
+func TestMessageAPIClient_Send_Auth(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`{"message_id": "ext_1"}`))
+	}))
+	defer server.Close()
+
+	basic := newTestHTTPProvider(t, config.ProviderConfig{
+		URL: server.URL, Username: "user", Password: "pass",
+		HTTP: config.HTTPAdapterConfig{Auth: config.HTTPAuthBasic, StatusPath: "-"},
+	})
+	if _, err := basic.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1234567890", Content: "Test"}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if header.Get("Authorization") != "Basic dXNlcjpwYXNz" {
+		t.Errorf("Unexpected basic auth header %q", header.Get("Authorization"))
+	}
+
+	apiKey := newTestHTTPProvider(t, config.ProviderConfig{
+		URL: server.URL, Password: "key-1",
+		HTTP: config.HTTPAdapterConfig{Auth: config.HTTPAuthAPIKey, APIKeyHeader: "X-Api-Token", StatusPath: "-"},
+	})
+	if _, err := apiKey.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1234567890", Content: "Test"}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if header.Get("X-Api-Token") != "key-1" || header.Get("Authorization") != "" {
+		t.Errorf("Unexpected api key headers %v", header)
+	}
+}
+
+func TestNewHTTPProvider_InvalidConfig(t *testing.T) {
+	for name, mapping := range map[string]config.HTTPAdapterConfig{
+		"unknown auth":          {Auth: "digest"},
+		"bearer without token":  {Auth: config.HTTPAuthBearer},
+		"unknown request field": {RequestFields: map[string]string{"phone": "to"}},
+		"conflicting fields":    {RequestFields: map[string]string{"phone_number": "to", "message": "to.text"}},
+		"same field twice":      {RequestFields: map[string]string{"sender_id": "to", "message": "to.text"}},
+		"index in request":      {RequestFields: map[string]string{"message": "parts[0]"}},
+		"broken id path":        {MessageIDPath: "data.messages[0"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewHTTPProvider(config.ProviderConfig{Name: "primary", HTTP: mapping}); !errors.Is(err, entities.ErrInvalidProviderConfig) {
+				t.Errorf("Expected ErrInvalidProviderConfig, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseJSONPath(t *testing.T) {
+	tests := map[string][]string{
+		"message_id":             {"message_id"},
+		"$.data.id":              {"data", "id"},
+		"data.messages[0].id":    {"data", "messages", "0", "id"},
+		"results[1][0]":          {"results", "1", "0"},
+		"data.messages.0.status": {"data", "messages", "0", "status"},
+	}
+	for path, want := range tests {
+		got, err := parseJSONPath(path)
+		if err != nil || strings.Join(got, "/") != strings.Join(want, "/") {
+			t.Errorf("parseJSONPath(%q) = %v, %v; want %v", path, got, err, want)
+		}
 	}
 
-	if len(id1) < 10 {
-		t.Error("Expected message ID to have reasonable length")
+	for _, path := range []string{"", "$", "data..id", "items[x]", "items[0]x"} {
+		if _, err := parseJSONPath(path); err == nil {
+			t.Errorf("Expected error for %q", path)
+		}
 	}
 }
@@ -0,0 +1,66 @@
+package external
+
+import (
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+const AdapterHTTP = "http"
+
+// ProviderFactory bir adapter'in ayarlarindan provider olusturur
+type ProviderFactory func(providerConfig config.ProviderConfig) (services.MessageProvider, error)
+
+// ProviderRegistry adapter adindan provider factory'sine eslesme; yeni entegrasyonlar Register ile eklenir
+type ProviderRegistry struct {
+	factories map[string]ProviderFactory
+}
+
+func NewProviderRegistry(logger *zap.Logger) *ProviderRegistry {
+	registry := &ProviderRegistry{factories: make(map[string]ProviderFactory)}
+	registry.Register(AdapterHTTP, func(providerConfig config.ProviderConfig) (services.MessageProvider, error) {
+		return NewHTTPProvider(providerConfig)
+	})
+	registry.Register(AdapterTwilio, func(providerConfig config.ProviderConfig) (services.MessageProvider, error) {
+		return NewTwilioProvider(providerConfig)
+	})
+	registry.Register(AdapterSMPP, func(providerConfig config.ProviderConfig) (services.MessageProvider, error) {
+		return NewSMPPProvider(providerConfig, logger)
+	})
+	registry.Register(AdapterSandbox, func(providerConfig config.ProviderConfig) (services.MessageProvider, error) {
+		return NewSandboxProvider(providerConfig, logger)
+	})
+	return registry
+}
+
+// Register ayni isimde adapter varsa onu degistirir
+func (r *ProviderRegistry) Register(adapter string, factory ProviderFactory) {
+	r.factories[adapter] = factory
+}
+
+func (r *ProviderRegistry) Build(providerConfig config.ProviderConfig) (services.MessageProvider, error) {
+	factory, ok := r.factories[providerConfig.Adapter]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (available: %v)", entities.ErrUnknownProviderAdapter, providerConfig.Adapter, r.Adapters())
+	}
+
+	provider, err := factory(providerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build provider %q: %w", providerConfig.Name, err)
+	}
+	return provider, nil
+}
+
+func (r *ProviderRegistry) Adapters() []string {
+	adapters := make([]string, 0, len(r.factories))
+	for adapter := range r.factories {
+		adapters = append(adapters, adapter)
+	}
+	sort.Strings(adapters)
+	return adapters
+}
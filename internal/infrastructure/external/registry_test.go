@@ -0,0 +1,92 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+type stubProvider struct {
+	name string
+}
+
+func (p *stubProvider) Name() string {
+	return p.name
+}
+
+func (p *stubProvider) Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+	return &entities.SendResult{ExternalMessageID: "stub"}, nil
+}
+
+func TestProviderRegistry_Build(t *testing.T) {
+	registry := NewProviderRegistry(zap.NewNop())
+	registry.Register("stub", func(providerConfig config.ProviderConfig) (services.MessageProvider, error) {
+		return &stubProvider{name: providerConfig.Name}, nil
+	})
+
+	provider, err := registry.Build(config.ProviderConfig{Name: "primary", Adapter: AdapterHTTP, URL: "http://localhost"})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if _, ok := provider.(*MessageAPIClient); !ok || provider.Name() != "primary" {
+		t.Errorf("Expected http adapter named primary, got %T %q", provider, provider.Name())
+	}
+
+	provider, err = registry.Build(config.ProviderConfig{Name: "backup", Adapter: "stub"})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if provider.Name() != "backup" {
+		t.Errorf("Expected provider backup, got %q", provider.Name())
+	}
+
+	if _, err := registry.Build(config.ProviderConfig{Name: "x", Adapter: "carrier-pigeon"}); !errors.Is(err, entities.ErrUnknownProviderAdapter) {
+		t.Errorf("Expected ErrUnknownProviderAdapter, got %v", err)
+	}
+
+	adapters := registry.Adapters()
+	if len(adapters) != 5 || adapters[0] != AdapterHTTP || adapters[1] != AdapterSandbox || adapters[2] != AdapterSMPP || adapters[3] != "stub" || adapters[4] != AdapterTwilio {
+		t.Errorf("Unexpected adapters %v", adapters)
+	}
+}
+
+func TestMessageAPIClient_Send(t *testing.T) {
+	status := http.StatusOK
+	body := `{"message_id": "ext_1", "status": "sent"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := newTestHTTPProvider(t, config.ProviderConfig{Name: "primary", URL: server.URL, Timeout: 5 * time.Second})
+	request := entities.SendRequest{PhoneNumber: "+1234567890", Content: "Test"}
+
+	result, err := client.Send(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.ExternalMessageID != "ext_1" {
+		t.Errorf("Expected external id ext_1, got %q", result.ExternalMessageID)
+	}
+
+	body = `{"message_id": "ext_2", "status": "rejected", "error": "blocked number"}`
+	if _, err := client.Send(context.Background(), request); !errors.Is(err, entities.ErrProviderRejected) {
+		t.Errorf("Expected ErrProviderRejected, got %v", err)
+	}
+
+	status = http.StatusInternalServerError
+	body = `{"error": "down"}`
+	if _, err := client.Send(context.Background(), request); err == nil || errors.Is(err, entities.ErrProviderRejected) {
+		t.Errorf("Expected transport error, got %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+const AdapterSandbox = "sandbox"
+
+// sandboxReceiptTimeout uretilen teslim raporunun islenmesi icin verilen sure
+const sandboxReceiptTimeout = 10 * time.Second
+
+// SandboxProvider ag kullanmadan gonderim simule eder; yerel gelistirme ve CI icin. Her gonderimde once
+// gecikme, sonra sonuc (timeout, throttle, gecici hata, ret ya da basari), basarida da teslim sonucu cekilir.
+// Ayni seed ve ayni gonderim sirasi ayni sonuclari verir.
+type SandboxProvider struct {
+	name    string
+	cfg     config.SandboxConfig
+	timeout time.Duration
+	logger  *zap.Logger
+	sleep   func(ctx context.Context, d time.Duration) error
+
+	mu       sync.Mutex
+	rng      *rand.Rand
+	receipts services.DeliveryReceiptHandler
+	pending  map[*time.Timer]struct{}
+	closed   bool
+}
+
+type sandboxOutcome int
+
+const (
+	sandboxSuccess sandboxOutcome = iota
+	sandboxTimeout
+	sandboxThrottle
+	sandboxFailure
+	sandboxReject
+)
+
+func NewSandboxProvider(providerConfig config.ProviderConfig, logger *zap.Logger) (*SandboxProvider, error) {
+	cfg := providerConfig.Sandbox
+
+	switch cfg.LatencyDistribution {
+	case config.SandboxLatencyFixed, config.SandboxLatencyUniform, config.SandboxLatencyNormal, config.SandboxLatencyExponential:
+	default:
+		return nil, fmt.Errorf("%w: unknown sandbox latency distribution %q (fixed, uniform, normal, exponential)", entities.ErrInvalidProviderConfig, cfg.LatencyDistribution)
+	}
+	if cfg.Latency < 0 || cfg.LatencyJitter < 0 || cfg.ReceiptDelay < 0 {
+		return nil, fmt.Errorf("%w: sandbox durations must not be negative", entities.ErrInvalidProviderConfig)
+	}
+
+	rates := []float64{cfg.FailureRate, cfg.TimeoutRate, cfg.ThrottleRate, cfg.RejectRate, cfg.UndeliveredRate}
+	for _, rate := range rates {
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("%w: sandbox rates must be between 0 and 1", entities.ErrInvalidProviderConfig)
+		}
+	}
+	if cfg.FailureRate+cfg.TimeoutRate+cfg.ThrottleRate+cfg.RejectRate > 1 {
+		return nil, fmt.Errorf("%w: sandbox failure, timeout, throttle and reject rates add up to more than 1", entities.ErrInvalidProviderConfig)
+	}
+
+	// seed loglanir ki rastgele secilmis bir calisma da tekrarlanabilsin
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	logger.Info("Sandbox provider ready", zap.String("provider", providerConfig.Name), zap.Int64("seed", cfg.Seed))
+
+	return &SandboxProvider{
+		name:    providerConfig.Name,
+		cfg:     cfg,
+		timeout: providerConfig.Timeout,
+		logger:  logger,
+		sleep:   sleepContext,
+		rng:     rand.New(rand.NewSource(cfg.Seed)),
+		pending: make(map[*time.Timer]struct{}),
+	}, nil
+}
+
+func (p *SandboxProvider) Name() string {
+	return p.name
+}
+
+func (p *SandboxProvider) OnDeliveryReceipt(handler services.DeliveryReceiptHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.receipts = handler
+}
+
+func (p *SandboxProvider) Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+	latency, outcome, delivered := p.draw()
+
+	// timeout'ta istek provider timeout'u dolana kadar cevapsiz kalir
+	if outcome == sandboxTimeout && p.timeout > 0 {
+		latency = p.timeout
+	}
+	if err := p.sleep(ctx, latency); err != nil {
+		return nil, transportError("sandbox request interrupted", err)
+	}
+
+	switch outcome {
+	case sandboxTimeout:
+		return nil, classified(entities.ErrorCodeTimeout, "sandbox: simulated timeout after %s: %w", latency, context.DeadlineExceeded)
+	case sandboxThrottle:
+		return nil, fmt.Errorf("sandbox: simulated HTTP 429: %w", &entities.ProviderThrottledError{})
+	case sandboxFailure:
+		return nil, classified(entities.ErrorCodeProviderError, "sandbox: simulated HTTP 500")
+	case sandboxReject:
+		return nil, classified(entities.ErrorCodeInvalidRecipient, "%w: sandbox: simulated invalid recipient %s", entities.ErrProviderRejected, request.PhoneNumber)
+	}
+
+	externalID := "sandbox-" + request.MessageID.String()
+	p.scheduleReceipt(externalID, delivered)
+	return &entities.SendResult{Provider: p.name, ExternalMessageID: externalID}, nil
+}
+
+// Close bekleyen teslim raporlarini iptal eder
+func (p *SandboxProvider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	for timer := range p.pending {
+		timer.Stop()
+	}
+	p.pending = make(map[*time.Timer]struct{})
+	return nil
+}
+
+// draw bir gonderimin butun rastgele degerlerini sabit sirayla ceker; boylece sonuc seed'e ve gonderim
+// sirasina bagli kalir, gecikmenin ne kadar surdugune degil
+func (p *SandboxProvider) draw() (time.Duration, sandboxOutcome, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	latency := p.latency()
+	roll := p.rng.Float64()
+	delivered := p.rng.Float64() >= p.cfg.UndeliveredRate
+
+	outcome := sandboxSuccess
+	for _, candidate := range []struct {
+		outcome sandboxOutcome
+		rate    float64
+	}{
+		{sandboxTimeout, p.cfg.TimeoutRate},
+		{sandboxThrottle, p.cfg.ThrottleRate},
+		{sandboxFailure, p.cfg.FailureRate},
+		{sandboxReject, p.cfg.RejectRate},
+	} {
+		if roll < candidate.rate {
+			outcome = candidate.outcome
+			break
+		}
+		roll -= candidate.rate
+	}
+	return latency, outcome, delivered
+}
+
+func (p *SandboxProvider) latency() time.Duration {
+	mean, jitter := float64(p.cfg.Latency), float64(p.cfg.LatencyJitter)
+
+	var value float64
+	switch p.cfg.LatencyDistribution {
+	case config.SandboxLatencyUniform:
+		value = mean + (p.rng.Float64()*2-1)*jitter
+	case config.SandboxLatencyNormal:
+		value = mean + p.rng.NormFloat64()*jitter
+	case config.SandboxLatencyExponential:
+		value = p.rng.ExpFloat64() * mean
+	default:
+		value = mean
+	}
+	return time.Duration(math.Max(value, 0))
+}
+
+func (p *SandboxProvider) scheduleReceipt(externalID string, delivered bool) {
+	if p.cfg.ReceiptDelay <= 0 {
+		return
+	}
+
+	receipt := entities.DeliveryReceipt{
+		Provider:          p.name,
+		ExternalMessageID: externalID,
+		Status:            entities.DeliveryStatusDelivered,
+	}
+	if !delivered {
+		receipt.Status = entities.DeliveryStatusUndelivered
+		receipt.ErrorCode = "sandbox"
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(p.cfg.ReceiptDelay, func() {
+		p.mu.Lock()
+		delete(p.pending, timer)
+		handler := p.receipts
+		p.mu.Unlock()
+		if handler == nil {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), sandboxReceiptTimeout)
+		defer cancel()
+
+		receipt.ReportedAt = time.Now()
+		if err := handler(ctx, receipt); err != nil {
+			p.logger.Debug("Sandbox delivery receipt not applied", zap.String("external_message_id", externalID), zap.Error(err))
+		}
+	})
+	p.pending[timer] = struct{}{}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,192 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+// newTestSandbox gecikmeleri beklemek yerine kaydeder
+func newTestSandbox(t *testing.T, cfg config.SandboxConfig) (*SandboxProvider, *[]time.Duration) {
+	if cfg.LatencyDistribution == "" {
+		cfg.LatencyDistribution = config.SandboxLatencyFixed
+	}
+	provider, err := NewSandboxProvider(config.ProviderConfig{Name: "sandbox", Timeout: 5 * time.Second, Sandbox: cfg}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	t.Cleanup(func() { provider.Close() })
+
+	var slept []time.Duration
+	provider.sleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return ctx.Err()
+	}
+	return provider, &slept
+}
+
+func sandboxRequest() entities.SendRequest {
+	return entities.SendRequest{MessageID: uuid.New(), PhoneNumber: "+905551112233", Content: "Hello"}
+}
+
+func TestSandboxProvider_Send(t *testing.T) {
+	provider, slept := newTestSandbox(t, config.SandboxConfig{Seed: 1, Latency: 80 * time.Millisecond})
+
+	request := sandboxRequest()
+	result, err := provider.Send(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.Provider != "sandbox" || result.ExternalMessageID != "sandbox-"+request.MessageID.String() {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if len(*slept) != 1 || (*slept)[0] != 80*time.Millisecond {
+		t.Errorf("Expected fixed latency of 80ms, got %v", *slept)
+	}
+}
+
+func TestSandboxProvider_Faults(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.SandboxConfig
+		wantCode  entities.ErrorCode
+		wantSleep time.Duration
+	}{
+		{name: "timeout waits for the provider timeout", cfg: config.SandboxConfig{TimeoutRate: 1}, wantCode: entities.ErrorCodeTimeout, wantSleep: 5 * time.Second},
+		{name: "throttle", cfg: config.SandboxConfig{ThrottleRate: 1}, wantCode: entities.ErrorCodeThrottled},
+		{name: "failure", cfg: config.SandboxConfig{FailureRate: 1}, wantCode: entities.ErrorCodeProviderError},
+		{name: "reject", cfg: config.SandboxConfig{RejectRate: 1}, wantCode: entities.ErrorCodeInvalidRecipient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Seed = 1
+			provider, slept := newTestSandbox(t, tt.cfg)
+
+			_, err := provider.Send(context.Background(), sandboxRequest())
+			if code := entities.ErrorCodeOf(err); code != tt.wantCode {
+				t.Fatalf("Expected %s, got %s (%v)", tt.wantCode, code, err)
+			}
+			if (*slept)[0] != tt.wantSleep {
+				t.Errorf("Expected to wait %s, got %s", tt.wantSleep, (*slept)[0])
+			}
+		})
+	}
+}
+
+func TestSandboxProvider_Deterministic(t *testing.T) {
+	cfg := config.SandboxConfig{
+		Seed:                42,
+		Latency:             100 * time.Millisecond,
+		LatencyJitter:       40 * time.Millisecond,
+		LatencyDistribution: config.SandboxLatencyNormal,
+		FailureRate:         0.3,
+		ThrottleRate:        0.2,
+	}
+
+	run := func() ([]entities.ErrorCode, []time.Duration) {
+		provider, slept := newTestSandbox(t, cfg)
+		var codes []entities.ErrorCode
+		for i := 0; i < 50; i++ {
+			_, err := provider.Send(context.Background(), sandboxRequest())
+			codes = append(codes, entities.ErrorCodeOf(err))
+		}
+		return codes, *slept
+	}
+
+	firstCodes, firstLatencies := run()
+	secondCodes, secondLatencies := run()
+
+	outcomes := make(map[entities.ErrorCode]int)
+	for i := range firstCodes {
+		if firstCodes[i] != secondCodes[i] || firstLatencies[i] != secondLatencies[i] {
+			t.Fatalf("Send %d differs between runs: %s/%s vs %s/%s", i, firstCodes[i], firstLatencies[i], secondCodes[i], secondLatencies[i])
+		}
+		outcomes[firstCodes[i]]++
+	}
+	if outcomes[""] == 0 || outcomes[entities.ErrorCodeProviderError] == 0 || outcomes[entities.ErrorCodeThrottled] == 0 {
+		t.Errorf("Expected a mix of outcomes, got %v", outcomes)
+	}
+}
+
+func TestSandboxProvider_LatencyDistributions(t *testing.T) {
+	for _, distribution := range []string{config.SandboxLatencyUniform, config.SandboxLatencyNormal, config.SandboxLatencyExponential} {
+		t.Run(distribution, func(t *testing.T) {
+			provider, slept := newTestSandbox(t, config.SandboxConfig{
+				Seed:                7,
+				Latency:             100 * time.Millisecond,
+				LatencyJitter:       50 * time.Millisecond,
+				LatencyDistribution: distribution,
+			})
+
+			var total time.Duration
+			for i := 0; i < 1000; i++ {
+				provider.Send(context.Background(), sandboxRequest())
+			}
+			for _, d := range *slept {
+				if d < 0 || (distribution == config.SandboxLatencyUniform && (d < 50*time.Millisecond || d > 150*time.Millisecond)) {
+					t.Fatalf("Latency %s out of range", d)
+				}
+				total += d
+			}
+			if mean := total / time.Duration(len(*slept)); mean < 90*time.Millisecond || mean > 110*time.Millisecond {
+				t.Errorf("Expected mean latency around 100ms, got %s", mean)
+			}
+		})
+	}
+}
+
+func TestSandboxProvider_DeliveryReceipts(t *testing.T) {
+	provider, _ := newTestSandbox(t, config.SandboxConfig{Seed: 1, ReceiptDelay: 10 * time.Millisecond, UndeliveredRate: 1})
+
+	receipts := make(chan entities.DeliveryReceipt, 1)
+	provider.OnDeliveryReceipt(func(ctx context.Context, receipt entities.DeliveryReceipt) error {
+		receipts <- receipt
+		return nil
+	})
+
+	result, err := provider.Send(context.Background(), sandboxRequest())
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	select {
+	case receipt := <-receipts:
+		if receipt.Provider != "sandbox" || receipt.ExternalMessageID != result.ExternalMessageID || receipt.Status != entities.DeliveryStatusUndelivered {
+			t.Errorf("Unexpected receipt %+v", receipt)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a delivery receipt")
+	}
+
+	// kapandiktan sonra bekleyen rapor gonderilmez
+	provider.cfg.ReceiptDelay = time.Hour
+	provider.Send(context.Background(), sandboxRequest())
+	provider.Close()
+	if len(provider.pending) != 0 {
+		t.Errorf("Expected pending receipts to be cancelled, got %d", len(provider.pending))
+	}
+}
+
+func TestNewSandboxProvider_InvalidConfig(t *testing.T) {
+	tests := []config.SandboxConfig{
+		{LatencyDistribution: "pareto"},
+		{LatencyDistribution: config.SandboxLatencyFixed, FailureRate: 1.5},
+		{LatencyDistribution: config.SandboxLatencyFixed, FailureRate: 0.6, TimeoutRate: 0.6},
+		{LatencyDistribution: config.SandboxLatencyFixed, Latency: -time.Second},
+	}
+
+	for _, cfg := range tests {
+		_, err := NewSandboxProvider(config.ProviderConfig{Name: "sandbox", Sandbox: cfg}, zap.NewNop())
+		if !errors.Is(err, entities.ErrInvalidProviderConfig) {
+			t.Errorf("Expected ErrInvalidProviderConfig for %+v, got %v", cfg, err)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/services"
+	"message-sending-service/internal/infrastructure/config"
+	"message-sending-service/internal/infrastructure/external/smpp"
+)
+
+const (
+	AdapterSMPP = "smpp"
+
+	defaultSMPPPort = "2775"
+
+	smppMinReconnectDelay = time.Second
+	smppMaxReconnectDelay = 30 * time.Second
+)
+
+// smppStatusCodes command_status'un sinifi; listede olmayan durumlar provider_error sayilir
+var smppStatusCodes = map[uint32]entities.ErrorCode{
+	// mesajin kendisinden kaynaklanan hatalar, baska SMSC'de denemek ise yaramaz
+	smpp.StatusInvalidMsgLen:  entities.ErrorCodeRejected,
+	smpp.StatusInvalidSrcAddr: entities.ErrorCodeRejected,
+	smpp.StatusInvalidDstAddr: entities.ErrorCodeInvalidRecipient,
+	smpp.StatusInvalidDstTON:  entities.ErrorCodeInvalidRecipient,
+	smpp.StatusInvalidDstNPI:  entities.ErrorCodeInvalidRecipient,
+	// bind hatalari bizim hesap ayarimizla ilgili
+	smpp.StatusBindFailed:    entities.ErrorCodeAuth,
+	smpp.StatusInvalidPasswd: entities.ErrorCodeAuth,
+	smpp.StatusInvalidSysID:  entities.ErrorCodeAuth,
+}
+
+// smppDeliveryStatuses teslim raporundaki stat degerinin karsiligi; listede olmayanlar unknown
+var smppDeliveryStatuses = map[string]entities.DeliveryStatus{
+	"DELIVRD": entities.DeliveryStatusDelivered,
+	"UNDELIV": entities.DeliveryStatusUndelivered,
+	"DELETED": entities.DeliveryStatusUndelivered,
+	"EXPIRED": entities.DeliveryStatusExpired,
+	"REJECTD": entities.DeliveryStatusRejected,
+}
+
+// SMPPProvider SMSC'ye SMPP 3.4 bind_transceiver ile kalici baglanti kurar. URL smpp://host:port,
+// Username system_id, Password sifre, From varsayilan gonderici. Baglanti koparsa arka planda yeniden kurulur.
+// Uzun mesajlar UDH ile parcalanir; mesajin ExternalMessageID'si ilk parcanin id'sidir, teslim raporu da
+// ilk parca icin islenir.
+type SMPPProvider struct {
+	name   string
+	from   string
+	cfg    smpp.Config
+	logger *zap.Logger
+
+	// concatenated SMS referansi, ayni anda giden uzun mesajlarin parcalari karismasin
+	ref atomic.Uint32
+
+	mu       sync.Mutex
+	session  *smpp.Session
+	closed   bool
+	receipts services.DeliveryReceiptHandler
+}
+
+func NewSMPPProvider(providerConfig config.ProviderConfig, logger *zap.Logger) (*SMPPProvider, error) {
+	if providerConfig.Username == "" {
+		return nil, fmt.Errorf("%w: smpp adapter needs a system_id (USERNAME)", entities.ErrInvalidProviderConfig)
+	}
+
+	addr, err := smppAddr(providerConfig.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	// yeniden baglanma ayni sureyi context timeout'u olarak kullanir, 0 olursa hic denenemez
+	timeout := providerConfig.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	return &SMPPProvider{
+		name: providerConfig.Name,
+		from: providerConfig.From,
+		cfg: smpp.Config{
+			Addr:        addr,
+			SystemID:    providerConfig.Username,
+			Password:    providerConfig.Password,
+			Timeout:     timeout,
+			EnquireLink: providerConfig.KeepAlive,
+		},
+		logger: logger.With(zap.String("provider", providerConfig.Name)),
+	}, nil
+}
+
+// smppAddr "smpp://host:port" ya da "host:port" kabul eder, port yoksa 2775. Baska bir sema ya da path
+// (ornegin MESSAGE_API_URL'deki http adresi) hata verir, yanlis adrese bind denenmez.
+func smppAddr(rawURL string) (string, error) {
+	addr := strings.TrimPrefix(rawURL, "smpp://")
+	addr = strings.TrimRight(addr, "/")
+	if addr == "" {
+		return "", fmt.Errorf("%w: smpp adapter needs an SMSC address (URL)", entities.ErrInvalidProviderConfig)
+	}
+	if strings.Contains(addr, "/") {
+		return "", fmt.Errorf("%w: smpp adapter needs an SMSC address like smpp://host:port, got %q", entities.ErrInvalidProviderConfig, rawURL)
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, defaultSMPPPort)
+	}
+	return addr, nil
+}
+
+func (p *SMPPProvider) Name() string {
+	return p.name
+}
+
+func (p *SMPPProvider) OnDeliveryReceipt(handler services.DeliveryReceiptHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.receipts = handler
+}
+
+func (p *SMPPProvider) Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+	session, err := p.connect(ctx)
+	if err != nil {
+		return nil, smppError(err)
+	}
+
+	sender := p.from
+	if request.SenderID != "" {
+		sender = request.SenderID
+	}
+	sourceTON, sourceNPI, sourceAddr := smppSourceAddress(sender)
+
+	var externalID string
+	segments := smpp.Split(request.Content, byte(p.ref.Add(1)))
+	for i, segment := range segments {
+		id, err := session.Submit(ctx, smpp.ShortMessage{
+			SourceAddrTON:      sourceTON,
+			SourceAddrNPI:      sourceNPI,
+			SourceAddr:         sourceAddr,
+			DestAddrTON:        1,
+			DestAddrNPI:        1,
+			DestinationAddr:    strings.TrimPrefix(request.PhoneNumber, "+"),
+			ESMClass:           segment.ESMClass,
+			RegisteredDelivery: 1,
+			DataCoding:         segment.DataCoding,
+			Message:            segment.Payload,
+		})
+		if err != nil {
+			if i > 0 {
+				// onceki parcalar SMSC'ye gitti; baska provider'a ya da tekrar gonderim alicida parcalari ikiler,
+				// bu yuzden hata kalici sayilir
+				p.logger.Warn("SMPP submit failed after partial delivery",
+					zap.Int("segment", i+1),
+					zap.Int("segments", len(segments)),
+					zap.Error(err))
+				return nil, classified(entities.ErrorCodePartialDelivery, "SMPP submit failed after %d of %d segments: %w", i, len(segments), err)
+			}
+			return nil, smppError(err)
+		}
+		if i == 0 {
+			externalID = id
+		}
+	}
+
+	if externalID == "" {
+		return nil, classified(entities.ErrorCodeProviderError, "SMSC returned an empty message_id")
+	}
+	return &entities.SendResult{Provider: p.name, ExternalMessageID: externalID}, nil
+}
+
+// Close baglantiyi unbind ile kapatir ve yeniden baglanmayi durdurur
+func (p *SMPPProvider) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	session := p.session
+	p.session = nil
+	p.mu.Unlock()
+
+	if session != nil {
+		return session.Close()
+	}
+	return nil
+}
+
+// connect acik session'i doner, yoksa yenisini kurar. Kilit dial boyunca tutulur ki ayni anda iki bind olmasin.
+func (p *SMPPProvider) connect(ctx context.Context) (*smpp.Session, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, smpp.ErrSessionClosed
+	}
+	if p.session != nil {
+		return p.session, nil
+	}
+
+	session, err := smpp.Dial(ctx, p.cfg, p.handleDeliver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind to SMSC: %w", err)
+	}
+	p.session = session
+	p.logger.Info("SMPP session bound", zap.String("addr", p.cfg.Addr))
+
+	go p.watch(session)
+	return session, nil
+}
+
+// watch session dusunce arka planda artan beklemeyle yeniden baglanir
+func (p *SMPPProvider) watch(session *smpp.Session) {
+	<-session.Done()
+
+	p.mu.Lock()
+	if p.session == session {
+		p.session = nil
+	}
+	closed := p.closed
+	p.mu.Unlock()
+
+	if closed {
+		return
+	}
+	p.logger.Warn("SMPP session lost, reconnecting", zap.Error(session.Err()))
+
+	delay := smppMinReconnectDelay
+	for {
+		time.Sleep(delay)
+
+		ctx, cancel := context.WithTimeout(context.Background(), p.cfg.Timeout)
+		_, err := p.connect(ctx)
+		cancel()
+		if err == nil || errors.Is(err, smpp.ErrSessionClosed) {
+			return
+		}
+
+		p.logger.Warn("SMPP reconnect failed", zap.Duration("retry_in", delay), zap.Error(err))
+		delay *= 2
+		if delay > smppMaxReconnectDelay {
+			delay = smppMaxReconnectDelay
+		}
+	}
+}
+
+func (p *SMPPProvider) handleDeliver(message smpp.ShortMessage) {
+	if !smpp.IsReceipt(message) {
+		p.logger.Debug("Ignoring mobile originated SMPP message", zap.String("source", message.SourceAddr))
+		return
+	}
+
+	parsed, ok := smpp.ParseReceipt(message, time.Now())
+	if !ok {
+		p.logger.Warn("SMPP delivery receipt without message id")
+		return
+	}
+
+	p.mu.Lock()
+	handler := p.receipts
+	p.mu.Unlock()
+	if handler == nil {
+		return
+	}
+
+	status, known := smppDeliveryStatuses[parsed.Stat]
+	if !known {
+		status = entities.DeliveryStatusUnknown
+	}
+	receipt := entities.DeliveryReceipt{
+		Provider:          p.name,
+		ExternalMessageID: parsed.MessageID,
+		Status:            status,
+		ReportedAt:        parsed.DoneAt,
+	}
+	// err:000 hata olmadigi anlamina gelir
+	if strings.Trim(parsed.Err, "0") != "" {
+		receipt.ErrorCode = parsed.Err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// diger hatalari usecase zaten logluyor; uzun mesajin ilk parcasi disindaki raporlar hicbir mesajla eslesmez
+	if err := handler(ctx, receipt); errors.Is(err, entities.ErrMessageNotFound) {
+		p.logger.Debug("Delivery receipt for unknown message", zap.String("external_message_id", parsed.MessageID))
+	}
+}
+
+// smppSourceAddress rakamlardan olusan gondericiyi uluslararasi numara, digerlerini alfanumerik olarak isaretler
+func smppSourceAddress(sender string) (ton, npi byte, addr string) {
+	if sender == "" {
+		return 0, 0, ""
+	}
+
+	digits := strings.TrimPrefix(sender, "+")
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 5, 0, sender
+		}
+	}
+	return 1, 1, digits
+}
+
+// smppError command_status'u bizim siniflarimiza esler; cevap gelmemesi timeout, kopan baglanti network
+func smppError(err error) error {
+	var statusErr *smpp.StatusError
+	if !errors.As(err, &statusErr) {
+		if errors.Is(err, smpp.ErrResponseTimeout) {
+			return classified(entities.ErrorCodeTimeout, "SMPP submit failed: %w", err)
+		}
+		return transportError("SMPP submit failed", err)
+	}
+
+	if statusErr.Status == smpp.StatusThrottled || statusErr.Status == smpp.StatusMsgQueueFull {
+		return fmt.Errorf("%s: %w", err.Error(), &entities.ProviderThrottledError{})
+	}
+
+	code, ok := smppStatusCodes[statusErr.Status]
+	if !ok {
+		code = entities.ErrorCodeProviderError
+	}
+	if code.IsPermanent() {
+		return classified(code, "%w: %w", entities.ErrProviderRejected, err)
+	}
+	return entities.NewProviderError(code, err)
+}
@@ -0,0 +1,102 @@
+package smpp
+
+import (
+	"unicode/utf16"
+)
+
+const (
+	maxSingleOctets = 140
+	// 6 byte'lik UDH: 05 00 03 <ref> <toplam> <sira>
+	udhLength        = 6
+	maxSegmentOctets = maxSingleOctets - udhLength
+)
+
+// Segment tek bir submit_sm'e sigan parca; UDH varsa Payload'in basindadir
+type Segment struct {
+	DataCoding byte
+	ESMClass   byte
+	Payload    []byte
+}
+
+// Split metni SMSC varsayilan alfabesi (ASCII, 160 karakter) ya da UCS2 (70 karakter) ile kodlar.
+// Tek submit_sm'e sigmazsa concatenated SMS UDH'si ile parcalara boler; ref ayni mesajin parcalarini baglar.
+func Split(text string, ref byte) []Segment {
+	dataCoding, encoded, unit := encode(text)
+
+	maxSingle := maxSingleOctets
+	if dataCoding == DataCodingDefault {
+		// varsayilan alfabede her karakter 7 bit: 140 octet = 160 karakter
+		maxSingle = 160
+	}
+	if len(encoded) <= maxSingle {
+		return []Segment{{DataCoding: dataCoding, Payload: encoded}}
+	}
+
+	perSegment := maxSegmentOctets
+	if dataCoding == DataCodingDefault {
+		perSegment = 153
+	}
+	// UCS2 karakterini (2 byte) ortadan bolme
+	perSegment -= perSegment % unit
+
+	var chunks [][]byte
+	for start := 0; start < len(encoded); start += perSegment {
+		end := start + perSegment
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		chunks = append(chunks, encoded[start:end])
+	}
+
+	segments := make([]Segment, len(chunks))
+	for i, chunk := range chunks {
+		payload := make([]byte, 0, udhLength+len(chunk))
+		payload = append(payload, 0x05, 0x00, 0x03, ref, byte(len(chunks)), byte(i+1))
+		payload = append(payload, chunk...)
+		segments[i] = Segment{DataCoding: dataCoding, ESMClass: ESMClassUDHI, Payload: payload}
+	}
+	return segments
+}
+
+// encode ASCII disinda karakter varsa UCS2 (UTF-16BE) kullanir; unit bir karakterin byte sayisi
+func encode(text string) (byte, []byte, int) {
+	ascii := true
+	for _, r := range text {
+		if r > 0x7F {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return DataCodingDefault, []byte(text), 1
+	}
+
+	units := utf16.Encode([]rune(text))
+	encoded := make([]byte, 0, len(units)*2)
+	for _, u := range units {
+		encoded = append(encoded, byte(u>>8), byte(u))
+	}
+	return DataCodingUCS2, encoded, 2
+}
+
+// Decode deliver_sm metnini cozer; UDH varsa atlanir
+func Decode(message ShortMessage) string {
+	payload := message.Message
+	if message.ESMClass&ESMClassUDHI != 0 && len(payload) > 0 {
+		skip := int(payload[0]) + 1
+		if skip > len(payload) {
+			skip = len(payload)
+		}
+		payload = payload[skip:]
+	}
+
+	if message.DataCoding != DataCodingUCS2 {
+		return string(payload)
+	}
+
+	units := make([]uint16, 0, len(payload)/2)
+	for i := 0; i+1 < len(payload); i += 2 {
+		units = append(units, uint16(payload[i])<<8|uint16(payload[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,318 @@
+// Package smpp SMPP 3.4 istemcisinin ihtiyac duydugu PDU'lari kodlar: bind_transceiver, submit_sm,
+// deliver_sm, enquire_link, unbind ve generic_nack. Diger PDU'lar generic_nack ile reddedilir.
+package smpp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	GenericNack         uint32 = 0x80000000
+	BindTransceiver     uint32 = 0x00000009
+	BindTransceiverResp uint32 = 0x80000009
+	SubmitSM            uint32 = 0x00000004
+	SubmitSMResp        uint32 = 0x80000004
+	DeliverSM           uint32 = 0x00000005
+	DeliverSMResp       uint32 = 0x80000005
+	Unbind              uint32 = 0x00000006
+	UnbindResp          uint32 = 0x80000006
+	EnquireLink         uint32 = 0x00000015
+	EnquireLinkResp     uint32 = 0x80000015
+)
+
+// command_status degerleri (SMPP 3.4 bolum 5.1.3), sadece kullandiklarimiz
+const (
+	StatusOK             uint32 = 0x00000000
+	StatusInvalidMsgLen  uint32 = 0x00000001
+	StatusInvalidCmdID   uint32 = 0x00000003
+	StatusBindFailed     uint32 = 0x0000000D
+	StatusInvalidPasswd  uint32 = 0x0000000E
+	StatusInvalidSysID   uint32 = 0x0000000F
+	StatusInvalidSrcAddr uint32 = 0x0000000A
+	StatusInvalidDstAddr uint32 = 0x0000000B
+	StatusMsgQueueFull   uint32 = 0x00000014
+	StatusSubmitFailed   uint32 = 0x00000045
+	StatusThrottled      uint32 = 0x00000058
+	StatusInvalidDstTON  uint32 = 0x00000050
+	StatusInvalidDstNPI  uint32 = 0x00000051
+	StatusSystemError    uint32 = 0x00000008
+)
+
+// esm_class bitleri
+const (
+	ESMClassUDHI    byte = 0x40
+	ESMClassReceipt byte = 0x04
+)
+
+// data_coding degerleri
+const (
+	DataCodingDefault byte = 0x00
+	DataCodingUCS2    byte = 0x08
+)
+
+// opsiyonel TLV etiketleri
+const (
+	TagReceiptedMessageID uint16 = 0x001E
+	TagMessageState       uint16 = 0x0427
+)
+
+const (
+	headerLength = 16
+	// command_length icin makul ust sinir; bozuk bir uzunluk yuzunden dev buffer ayrilmasin
+	maxPDULength = 64 * 1024
+)
+
+var ErrMalformedPDU = errors.New("malformed SMPP PDU")
+
+// PDU header ve ham govde; govde komuta gore Decode* fonksiyonlariyla okunur
+type PDU struct {
+	CommandID uint32
+	Status    uint32
+	Sequence  uint32
+	Body      []byte
+}
+
+func ReadPDU(r io.Reader) (*PDU, error) {
+	var header [headerLength]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header[0:4])
+	if length < headerLength || length > maxPDULength {
+		return nil, fmt.Errorf("%w: command_length %d", ErrMalformedPDU, length)
+	}
+
+	pdu := &PDU{
+		CommandID: binary.BigEndian.Uint32(header[4:8]),
+		Status:    binary.BigEndian.Uint32(header[8:12]),
+		Sequence:  binary.BigEndian.Uint32(header[12:16]),
+		Body:      make([]byte, length-headerLength),
+	}
+	if _, err := io.ReadFull(r, pdu.Body); err != nil {
+		return nil, err
+	}
+	return pdu, nil
+}
+
+func (p *PDU) Bytes() []byte {
+	buf := make([]byte, headerLength, headerLength+len(p.Body))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(headerLength+len(p.Body)))
+	binary.BigEndian.PutUint32(buf[4:8], p.CommandID)
+	binary.BigEndian.PutUint32(buf[8:12], p.Status)
+	binary.BigEndian.PutUint32(buf[12:16], p.Sequence)
+	return append(buf, p.Body...)
+}
+
+func WritePDU(w io.Writer, pdu *PDU) error {
+	_, err := w.Write(pdu.Bytes())
+	return err
+}
+
+// IsResponse response PDU'larinda command_id'nin en ust biti set edilir
+func IsResponse(commandID uint32) bool {
+	return commandID&0x80000000 != 0
+}
+
+type Bind struct {
+	SystemID         string
+	Password         string
+	SystemType       string
+	InterfaceVersion byte
+	AddrTON          byte
+	AddrNPI          byte
+	AddressRange     string
+}
+
+func (b Bind) Encode() []byte {
+	var buf bytes.Buffer
+	writeCString(&buf, b.SystemID)
+	writeCString(&buf, b.Password)
+	writeCString(&buf, b.SystemType)
+	buf.WriteByte(b.InterfaceVersion)
+	buf.WriteByte(b.AddrTON)
+	buf.WriteByte(b.AddrNPI)
+	writeCString(&buf, b.AddressRange)
+	return buf.Bytes()
+}
+
+func DecodeBind(body []byte) (Bind, error) {
+	d := decoder{buf: body}
+	b := Bind{
+		SystemID:         d.cstring(),
+		Password:         d.cstring(),
+		SystemType:       d.cstring(),
+		InterfaceVersion: d.byte(),
+		AddrTON:          d.byte(),
+		AddrNPI:          d.byte(),
+		AddressRange:     d.cstring(),
+	}
+	return b, d.err
+}
+
+// ShortMessage submit_sm ve deliver_sm ayni govde yapisini kullanir
+type ShortMessage struct {
+	ServiceType          string
+	SourceAddrTON        byte
+	SourceAddrNPI        byte
+	SourceAddr           string
+	DestAddrTON          byte
+	DestAddrNPI          byte
+	DestinationAddr      string
+	ESMClass             byte
+	ProtocolID           byte
+	PriorityFlag         byte
+	ScheduleDeliveryTime string
+	ValidityPeriod       string
+	RegisteredDelivery   byte
+	ReplaceIfPresent     byte
+	DataCoding           byte
+	SMDefaultMsgID       byte
+	Message              []byte
+	TLVs                 map[uint16][]byte
+}
+
+func (m ShortMessage) Encode() []byte {
+	var buf bytes.Buffer
+	writeCString(&buf, m.ServiceType)
+	buf.WriteByte(m.SourceAddrTON)
+	buf.WriteByte(m.SourceAddrNPI)
+	writeCString(&buf, m.SourceAddr)
+	buf.WriteByte(m.DestAddrTON)
+	buf.WriteByte(m.DestAddrNPI)
+	writeCString(&buf, m.DestinationAddr)
+	buf.WriteByte(m.ESMClass)
+	buf.WriteByte(m.ProtocolID)
+	buf.WriteByte(m.PriorityFlag)
+	writeCString(&buf, m.ScheduleDeliveryTime)
+	writeCString(&buf, m.ValidityPeriod)
+	buf.WriteByte(m.RegisteredDelivery)
+	buf.WriteByte(m.ReplaceIfPresent)
+	buf.WriteByte(m.DataCoding)
+	buf.WriteByte(m.SMDefaultMsgID)
+	buf.WriteByte(byte(len(m.Message)))
+	buf.Write(m.Message)
+	for tag, value := range m.TLVs {
+		binary.Write(&buf, binary.BigEndian, tag)
+		binary.Write(&buf, binary.BigEndian, uint16(len(value)))
+		buf.Write(value)
+	}
+	return buf.Bytes()
+}
+
+func DecodeShortMessage(body []byte) (ShortMessage, error) {
+	d := decoder{buf: body}
+	m := ShortMessage{
+		ServiceType:          d.cstring(),
+		SourceAddrTON:        d.byte(),
+		SourceAddrNPI:        d.byte(),
+		SourceAddr:           d.cstring(),
+		DestAddrTON:          d.byte(),
+		DestAddrNPI:          d.byte(),
+		DestinationAddr:      d.cstring(),
+		ESMClass:             d.byte(),
+		ProtocolID:           d.byte(),
+		PriorityFlag:         d.byte(),
+		ScheduleDeliveryTime: d.cstring(),
+		ValidityPeriod:       d.cstring(),
+		RegisteredDelivery:   d.byte(),
+		ReplaceIfPresent:     d.byte(),
+		DataCoding:           d.byte(),
+		SMDefaultMsgID:       d.byte(),
+	}
+	m.Message = d.bytes(int(d.byte()))
+	m.TLVs = d.tlvs()
+	return m, d.err
+}
+
+// EncodeMessageID submit_sm_resp ve deliver_sm_resp govdesi: tek bir C-string
+func EncodeMessageID(messageID string) []byte {
+	var buf bytes.Buffer
+	writeCString(&buf, messageID)
+	return buf.Bytes()
+}
+
+func DecodeMessageID(body []byte) (string, error) {
+	// hata yanitlarinda govde bos olabilir
+	if len(body) == 0 {
+		return "", nil
+	}
+	d := decoder{buf: body}
+	id := d.cstring()
+	return id, d.err
+}
+
+func writeCString(buf *bytes.Buffer, s string) {
+	buf.WriteString(s)
+	buf.WriteByte(0)
+}
+
+// decoder ilk hatada durur, sonraki okumalar sifir deger doner
+type decoder struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if d.pos >= len(d.buf) {
+		d.err = ErrMalformedPDU
+		return 0
+	}
+	b := d.buf[d.pos]
+	d.pos++
+	return b
+}
+
+func (d *decoder) cstring() string {
+	if d.err != nil {
+		return ""
+	}
+	end := bytes.IndexByte(d.buf[d.pos:], 0)
+	if end < 0 {
+		d.err = ErrMalformedPDU
+		return ""
+	}
+	s := string(d.buf[d.pos : d.pos+end])
+	d.pos += end + 1
+	return s
+}
+
+func (d *decoder) bytes(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if d.pos+n > len(d.buf) {
+		d.err = ErrMalformedPDU
+		return nil
+	}
+	b := append([]byte(nil), d.buf[d.pos:d.pos+n]...)
+	d.pos += n
+	return b
+}
+
+func (d *decoder) tlvs() map[uint16][]byte {
+	if d.err != nil || d.pos >= len(d.buf) {
+		return nil
+	}
+
+	tlvs := make(map[uint16][]byte)
+	for d.pos+4 <= len(d.buf) {
+		tag := binary.BigEndian.Uint16(d.buf[d.pos:])
+		length := int(binary.BigEndian.Uint16(d.buf[d.pos+2:]))
+		d.pos += 4
+		value := d.bytes(length)
+		if d.err != nil {
+			return nil
+		}
+		tlvs[tag] = value
+	}
+	return tlvs
+}
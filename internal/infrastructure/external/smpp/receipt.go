@@ -0,0 +1,101 @@
+package smpp
+
+import (
+	"strings"
+	"time"
+)
+
+// Receipt deliver_sm ile gelen teslim raporu (SMPP 3.4 Appendix B formati)
+type Receipt struct {
+	MessageID string
+	Stat      string
+	Err       string
+	DoneAt    time.Time
+}
+
+// message_state TLV degerlerinin stat karsiliklari
+var messageStates = map[byte]string{
+	1: "ENROUTE",
+	2: "DELIVRD",
+	3: "EXPIRED",
+	4: "DELETED",
+	5: "UNDELIV",
+	6: "ACCEPTD",
+	7: "UNKNOWN",
+	8: "REJECTD",
+}
+
+// IsReceipt esm_class'ta teslim raporu biti set edilmis deliver_sm'ler icin true
+func IsReceipt(message ShortMessage) bool {
+	return message.ESMClass&ESMClassReceipt != 0
+}
+
+// ParseReceipt "id:... sub:... dlvr:... submit date:... done date:... stat:... err:... text:..." metnini okur.
+// receipted_message_id ve message_state TLV'leri varsa metindeki degerlerin onune gecer.
+func ParseReceipt(message ShortMessage, now time.Time) (Receipt, bool) {
+	receipt := Receipt{DoneAt: now}
+	text := Decode(message)
+
+	for _, field := range []string{"id", "stat", "err", "done date"} {
+		value, ok := receiptField(text, field)
+		if !ok {
+			continue
+		}
+		switch field {
+		case "id":
+			receipt.MessageID = value
+		case "stat":
+			receipt.Stat = strings.ToUpper(value)
+		case "err":
+			receipt.Err = value
+		case "done date":
+			if doneAt, ok := parseReceiptDate(value); ok {
+				receipt.DoneAt = doneAt
+			}
+		}
+	}
+
+	if id, ok := message.TLVs[TagReceiptedMessageID]; ok {
+		receipt.MessageID = strings.TrimRight(string(id), "\x00")
+	}
+	if state, ok := message.TLVs[TagMessageState]; ok && len(state) == 1 {
+		if stat, known := messageStates[state[0]]; known {
+			receipt.Stat = stat
+		}
+	}
+
+	return receipt, receipt.MessageID != ""
+}
+
+// receiptField "anahtar:deger" alanini bulur; deger bir sonraki bosluga kadar surer
+func receiptField(text, key string) (string, bool) {
+	lower := strings.ToLower(text)
+	needle := key + ":"
+	idx := strings.Index(lower, needle)
+	// "submit date:" icindeki "date:" ya da "id:" eslesmelerini kelime basinda ara
+	for idx > 0 && lower[idx-1] != ' ' {
+		next := strings.Index(lower[idx+1:], needle)
+		if next < 0 {
+			return "", false
+		}
+		idx += next + 1
+	}
+	if idx < 0 {
+		return "", false
+	}
+
+	value := text[idx+len(needle):]
+	if end := strings.IndexByte(value, ' '); end >= 0 {
+		value = value[:end]
+	}
+	return value, true
+}
+
+func parseReceiptDate(value string) (time.Time, bool) {
+	for _, layout := range []string{"0601021504", "060102150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,268 @@
+package smpp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	interfaceVersion34 = 0x34
+	defaultTimeout     = 30 * time.Second
+)
+
+var (
+	ErrSessionClosed   = errors.New("SMPP session closed")
+	ErrUnbound         = errors.New("SMPP session unbound by SMSC")
+	ErrResponseTimeout = errors.New("SMPP response timeout")
+)
+
+// StatusError SMSC bir istegi sifir olmayan command_status ile yanitladiginda doner
+type StatusError struct {
+	Command string
+	Status  uint32
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("SMPP %s failed with command_status 0x%08X", e.Command, e.Status)
+}
+
+type Config struct {
+	Addr       string
+	SystemID   string
+	Password   string
+	SystemType string
+	// Timeout baglanti, bind ve her yanit icin beklenecek sure
+	Timeout time.Duration
+	// EnquireLink 0 ise keepalive gonderilmez
+	EnquireLink time.Duration
+}
+
+// Session bind_transceiver ile baglanmis tek bir TCP baglantisi. Ayni anda birden fazla istek gonderilebilir,
+// yanitlar sequence_number ile eslestirilir. Baglanti koptugunda Done kapanir ve Err sebebi doner.
+type Session struct {
+	conn      net.Conn
+	timeout   time.Duration
+	onDeliver func(ShortMessage)
+
+	writeMu sync.Mutex
+	seq     atomic.Uint32
+
+	mu      sync.Mutex
+	pending map[uint32]chan *PDU
+
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+// Dial baglanir ve bind_transceiver yapar; onDeliver her deliver_sm icin ayri goroutine'de cagrilir
+func Dial(ctx context.Context, cfg Config, onDeliver func(ShortMessage)) (*Session, error) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	dialer := net.Dialer{Timeout: cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMSC: %w", err)
+	}
+
+	s := &Session{
+		conn:      conn,
+		timeout:   cfg.Timeout,
+		onDeliver: onDeliver,
+		pending:   make(map[uint32]chan *PDU),
+		done:      make(chan struct{}),
+	}
+	go s.readLoop()
+
+	bind := Bind{
+		SystemID:         cfg.SystemID,
+		Password:         cfg.Password,
+		SystemType:       cfg.SystemType,
+		InterfaceVersion: interfaceVersion34,
+	}
+	resp, err := s.request(ctx, BindTransceiver, bind.Encode())
+	if err == nil && resp.Status != StatusOK {
+		err = &StatusError{Command: "bind_transceiver", Status: resp.Status}
+	}
+	if err != nil {
+		s.fail(err)
+		return nil, err
+	}
+
+	if cfg.EnquireLink > 0 {
+		go s.keepAlive(cfg.EnquireLink)
+	}
+	return s, nil
+}
+
+// Submit submit_sm gonderir ve SMSC'nin verdigi message_id'yi doner
+func (s *Session) Submit(ctx context.Context, message ShortMessage) (string, error) {
+	resp, err := s.request(ctx, SubmitSM, message.Encode())
+	if err != nil {
+		return "", err
+	}
+	if resp.Status != StatusOK {
+		return "", &StatusError{Command: "submit_sm", Status: resp.Status}
+	}
+	return DecodeMessageID(resp.Body)
+}
+
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Session) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Close once unbind dener, SMSC cevap vermezse yine de baglantiyi kapatir
+func (s *Session) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	s.request(ctx, Unbind, nil)
+	s.fail(ErrSessionClosed)
+	return nil
+}
+
+func (s *Session) request(ctx context.Context, commandID uint32, body []byte) (*PDU, error) {
+	seq := s.seq.Add(1)
+	ch := make(chan *PDU, 1)
+
+	s.mu.Lock()
+	select {
+	case <-s.done:
+		s.mu.Unlock()
+		return nil, s.err
+	default:
+	}
+	s.pending[seq] = ch
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, seq)
+		s.mu.Unlock()
+	}()
+
+	if err := s.write(&PDU{CommandID: commandID, Sequence: seq, Body: body}); err != nil {
+		s.fail(err)
+		return nil, err
+	}
+
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+
+	select {
+	case resp := <-ch:
+		if resp.CommandID == GenericNack {
+			return nil, &StatusError{Command: "generic_nack", Status: resp.Status}
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+		return nil, ErrResponseTimeout
+	case <-s.done:
+		return nil, s.err
+	}
+}
+
+func (s *Session) write(pdu *PDU) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if s.timeout > 0 {
+		s.conn.SetWriteDeadline(time.Now().Add(s.timeout))
+	}
+	return WritePDU(s.conn, pdu)
+}
+
+func (s *Session) reply(request *PDU, commandID uint32, status uint32, body []byte) {
+	if err := s.write(&PDU{CommandID: commandID, Status: status, Sequence: request.Sequence, Body: body}); err != nil {
+		s.fail(err)
+	}
+}
+
+func (s *Session) readLoop() {
+	for {
+		pdu, err := ReadPDU(s.conn)
+		if err != nil {
+			s.fail(err)
+			return
+		}
+
+		switch pdu.CommandID {
+		case EnquireLink:
+			s.reply(pdu, EnquireLinkResp, StatusOK, nil)
+		case DeliverSM:
+			message, err := DecodeShortMessage(pdu.Body)
+			if err != nil {
+				s.reply(pdu, DeliverSMResp, StatusSystemError, EncodeMessageID(""))
+				continue
+			}
+			s.reply(pdu, DeliverSMResp, StatusOK, EncodeMessageID(""))
+			if s.onDeliver != nil {
+				go s.onDeliver(message)
+			}
+		case Unbind:
+			s.reply(pdu, UnbindResp, StatusOK, nil)
+			s.fail(ErrUnbound)
+			return
+		default:
+			if !IsResponse(pdu.CommandID) {
+				s.reply(pdu, GenericNack, StatusInvalidCmdID, nil)
+				continue
+			}
+			s.mu.Lock()
+			ch, ok := s.pending[pdu.Sequence]
+			s.mu.Unlock()
+			if ok {
+				ch <- pdu
+			}
+		}
+	}
+}
+
+// keepAlive enquire_link yanitlanmazsa baglantiyi olu sayar ve kapatir
+func (s *Session) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+			_, err := s.request(ctx, EnquireLink, nil)
+			cancel()
+			if err != nil {
+				s.fail(fmt.Errorf("enquire_link failed: %w", err))
+				return
+			}
+		}
+	}
+}
+
+func (s *Session) fail(err error) {
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		s.err = err
+		close(s.done)
+		s.mu.Unlock()
+		s.conn.Close()
+	})
+}
@@ -0,0 +1,135 @@
+package smpp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPDU_RoundTrip(t *testing.T) {
+	message := ShortMessage{
+		SourceAddrTON:      5,
+		SourceAddr:         "INSIDER",
+		DestAddrTON:        1,
+		DestAddrNPI:        1,
+		DestinationAddr:    "905551112233",
+		RegisteredDelivery: 1,
+		DataCoding:         DataCodingUCS2,
+		Message:            []byte{0x00, 0x48, 0x01, 0x5F},
+		TLVs:               map[uint16][]byte{TagMessageState: {2}},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePDU(&buf, &PDU{CommandID: SubmitSM, Sequence: 7, Body: message.Encode()}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	pdu, err := ReadPDU(&buf)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if pdu.CommandID != SubmitSM || pdu.Sequence != 7 || pdu.Status != StatusOK {
+		t.Errorf("Unexpected header %+v", pdu)
+	}
+
+	decoded, err := DecodeShortMessage(pdu.Body)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if decoded.SourceAddr != "INSIDER" || decoded.DestinationAddr != "905551112233" || decoded.DataCoding != DataCodingUCS2 {
+		t.Errorf("Unexpected message %+v", decoded)
+	}
+	if !bytes.Equal(decoded.Message, message.Message) || !bytes.Equal(decoded.TLVs[TagMessageState], []byte{2}) {
+		t.Errorf("Unexpected payload %v / %v", decoded.Message, decoded.TLVs)
+	}
+
+	if _, err := DecodeShortMessage(pdu.Body[:10]); err == nil {
+		t.Error("Expected truncated body to fail")
+	}
+}
+
+func TestReadPDU_RejectsBadLength(t *testing.T) {
+	header := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 1}
+	if _, err := ReadPDU(bytes.NewReader(header)); err == nil {
+		t.Error("Expected oversized command_length to fail")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		segments   int
+		dataCoding byte
+	}{
+		{name: "short ascii", text: "Hello", segments: 1, dataCoding: DataCodingDefault},
+		{name: "160 ascii chars", text: strings.Repeat("a", 160), segments: 1, dataCoding: DataCodingDefault},
+		{name: "161 ascii chars", text: strings.Repeat("a", 161), segments: 2, dataCoding: DataCodingDefault},
+		{name: "70 unicode chars", text: strings.Repeat("ş", 70), segments: 1, dataCoding: DataCodingUCS2},
+		{name: "71 unicode chars", text: strings.Repeat("ş", 71), segments: 2, dataCoding: DataCodingUCS2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments := Split(tt.text, 9)
+			if len(segments) != tt.segments {
+				t.Fatalf("Expected %d segments, got %d", tt.segments, len(segments))
+			}
+
+			var text strings.Builder
+			for i, segment := range segments {
+				if segment.DataCoding != tt.dataCoding {
+					t.Errorf("Expected data_coding %#x, got %#x", tt.dataCoding, segment.DataCoding)
+				}
+				if tt.segments > 1 {
+					udh := []byte{0x05, 0x00, 0x03, 9, byte(tt.segments), byte(i + 1)}
+					if segment.ESMClass != ESMClassUDHI || !bytes.Equal(segment.Payload[:udhLength], udh) {
+						t.Errorf("Unexpected UDH in segment %d: %v", i+1, segment.Payload[:udhLength])
+					}
+				}
+				if len(segment.Payload) > maxSingleOctets && segment.DataCoding == DataCodingUCS2 {
+					t.Errorf("Segment %d is %d octets", i+1, len(segment.Payload))
+				}
+				text.WriteString(Decode(ShortMessage{ESMClass: segment.ESMClass, DataCoding: segment.DataCoding, Message: segment.Payload}))
+			}
+			if text.String() != tt.text {
+				t.Errorf("Segments do not reassemble to the original text")
+			}
+		})
+	}
+}
+
+func TestParseReceipt(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	text := "id:abc123 sub:001 dlvr:001 submit date:2401021504 done date:2401021505 stat:DELIVRD err:000 text:Hello"
+	receipt, ok := ParseReceipt(ShortMessage{ESMClass: ESMClassReceipt, Message: []byte(text)}, now)
+	if !ok {
+		t.Fatal("Expected receipt to parse")
+	}
+	if receipt.MessageID != "abc123" || receipt.Stat != "DELIVRD" || receipt.Err != "000" {
+		t.Errorf("Unexpected receipt %+v", receipt)
+	}
+	if !receipt.DoneAt.Equal(time.Date(2024, 1, 2, 15, 5, 0, 0, time.UTC)) {
+		t.Errorf("Expected done date from the receipt, got %v", receipt.DoneAt)
+	}
+
+	// TLV'ler metnin onune gecer
+	message := ShortMessage{
+		ESMClass: ESMClassReceipt,
+		Message:  []byte("id:ignored stat:ENROUTE"),
+		TLVs: map[uint16][]byte{
+			TagReceiptedMessageID: []byte("tlv-42\x00"),
+			TagMessageState:       {5},
+		},
+	}
+	receipt, ok = ParseReceipt(message, now)
+	if !ok || receipt.MessageID != "tlv-42" || receipt.Stat != "UNDELIV" || !receipt.DoneAt.Equal(now) {
+		t.Errorf("Unexpected receipt %+v", receipt)
+	}
+
+	if _, ok := ParseReceipt(ShortMessage{ESMClass: ESMClassReceipt, Message: []byte("stat:DELIVRD")}, now); ok {
+		t.Error("Expected receipt without id to be rejected")
+	}
+}
@@ -0,0 +1,231 @@
+// Package smpptest adapter testleri icin bellekte calisan basit bir SMSC. bind_transceiver, submit_sm,
+// enquire_link ve unbind'i yanitlar, istenince deliver_sm ile teslim raporu gonderir.
+package smpptest
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"message-sending-service/internal/infrastructure/external/smpp"
+)
+
+type Server struct {
+	SystemID string
+	Password string
+
+	listener net.Listener
+
+	mu           sync.Mutex
+	conns        map[*conn]bool
+	submitted    []smpp.ShortMessage
+	submitStatus uint32
+	failAfter    int
+	failStatus   uint32
+	binds        int
+	enquireLinks int
+	nextID       int
+	sequence     uint32
+}
+
+type conn struct {
+	net.Conn
+	writeMu sync.Mutex
+	bound   bool
+}
+
+func (c *conn) write(pdu *smpp.PDU) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return smpp.WritePDU(c.Conn, pdu)
+}
+
+// NewServer 127.0.0.1 uzerinde rastgele bir portta dinlemeye baslar
+func NewServer(systemID, password string) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		SystemID: systemID,
+		Password: password,
+		listener: listener,
+		conns:    make(map[*conn]bool),
+	}
+	go s.accept()
+	return s, nil
+}
+
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *Server) Close() {
+	s.listener.Close()
+	s.DropConnections()
+}
+
+// DropConnections acik baglantilari unbind etmeden keser, ag kopmasini taklit eder
+func (s *Server) DropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		c.Close()
+		delete(s.conns, c)
+	}
+}
+
+// SetSubmitStatus sonraki submit_sm'ler bu command_status ile yanitlanir; StatusOK normale doner
+func (s *Server) SetSubmitStatus(status uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.submitStatus = status
+}
+
+// FailSubmitsAfter sonraki accepted submit_sm kabul edilir, sonrakiler status ile yanitlanir; uzun mesajin
+// yarida kalmasini denemek icin. StatusOK normale doner
+func (s *Server) FailSubmitsAfter(accepted int, status uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failAfter = accepted
+	s.failStatus = status
+}
+
+func (s *Server) Submitted() []smpp.ShortMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]smpp.ShortMessage(nil), s.submitted...)
+}
+
+// Binds basarili bind sayisi; yeniden baglanmayi dogrulamak icin
+func (s *Server) Binds() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.binds
+}
+
+func (s *Server) EnquireLinks() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enquireLinks
+}
+
+// DeliverReceipt bagli ilk istemciye messageID icin stat durumunda teslim raporu gonderir
+func (s *Server) DeliverReceipt(messageID, stat string) error {
+	s.mu.Lock()
+	var target *conn
+	for c := range s.conns {
+		if c.bound {
+			target = c
+			break
+		}
+	}
+	s.sequence++
+	sequence := s.sequence
+	s.mu.Unlock()
+
+	if target == nil {
+		return errors.New("no bound SMPP client")
+	}
+
+	text := fmt.Sprintf("id:%s sub:001 dlvr:001 submit date:2401021504 done date:2401021505 stat:%s err:000 text:", messageID, stat)
+	receipt := smpp.ShortMessage{
+		ESMClass: smpp.ESMClassReceipt,
+		Message:  []byte(text),
+	}
+	return target.write(&smpp.PDU{CommandID: smpp.DeliverSM, Sequence: sequence, Body: receipt.Encode()})
+}
+
+func (s *Server) accept() {
+	for {
+		netConn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c := &conn{Conn: netConn}
+		s.mu.Lock()
+		s.conns[c] = true
+		s.mu.Unlock()
+		go s.serve(c)
+	}
+}
+
+func (s *Server) serve(c *conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		c.Close()
+	}()
+
+	for {
+		pdu, err := smpp.ReadPDU(c)
+		if err != nil {
+			return
+		}
+
+		reply := &smpp.PDU{CommandID: pdu.CommandID | 0x80000000, Sequence: pdu.Sequence}
+		switch pdu.CommandID {
+		case smpp.BindTransceiver:
+			reply.Body = smpp.EncodeMessageID("smpptest")
+			bind, err := smpp.DecodeBind(pdu.Body)
+			s.mu.Lock()
+			switch {
+			case err != nil:
+				reply.Status = smpp.StatusBindFailed
+			case bind.SystemID != s.SystemID:
+				reply.Status = smpp.StatusInvalidSysID
+			case bind.Password != s.Password:
+				reply.Status = smpp.StatusInvalidPasswd
+			default:
+				c.bound = true
+				s.binds++
+			}
+			s.mu.Unlock()
+		case smpp.SubmitSM:
+			reply.Body = s.submit(c, pdu, reply)
+		case smpp.EnquireLink:
+			s.mu.Lock()
+			s.enquireLinks++
+			s.mu.Unlock()
+		case smpp.Unbind:
+			c.write(reply)
+			return
+		case smpp.DeliverSMResp:
+			continue
+		default:
+			reply = &smpp.PDU{CommandID: smpp.GenericNack, Status: smpp.StatusInvalidCmdID, Sequence: pdu.Sequence}
+		}
+
+		if err := c.write(reply); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) submit(c *conn, pdu *smpp.PDU, reply *smpp.PDU) []byte {
+	message, err := smpp.DecodeShortMessage(pdu.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case !c.bound:
+		reply.Status = smpp.StatusBindFailed
+	case err != nil:
+		reply.Status = smpp.StatusSystemError
+	case s.submitStatus != smpp.StatusOK:
+		reply.Status = s.submitStatus
+	case s.failStatus != smpp.StatusOK && s.failAfter <= 0:
+		reply.Status = s.failStatus
+	default:
+		s.failAfter--
+		s.submitted = append(s.submitted, message)
+		s.nextID++
+		return smpp.EncodeMessageID(fmt.Sprintf("smsc-%d", s.nextID))
+	}
+	return nil
+}
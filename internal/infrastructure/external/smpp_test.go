@@ -0,0 +1,278 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+	"message-sending-service/internal/infrastructure/external/smpp"
+	"message-sending-service/internal/infrastructure/external/smpp/smpptest"
+)
+
+func newTestSMPP(t *testing.T) (*smpptest.Server, *SMPPProvider) {
+	server, err := smpptest.NewServer("insider", "secret")
+	if err != nil {
+		t.Fatalf("Failed to start SMSC: %v", err)
+	}
+	t.Cleanup(server.Close)
+
+	provider, err := NewSMPPProvider(config.ProviderConfig{
+		Name:     "smsc",
+		URL:      "smpp://" + server.Addr(),
+		Timeout:  2 * time.Second,
+		Username: "insider",
+		Password: "secret",
+		From:     "INSIDER",
+	}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	t.Cleanup(func() { provider.Close() })
+
+	return server, provider
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSMPPProvider_Send(t *testing.T) {
+	server, provider := newTestSMPP(t)
+
+	result, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233", Content: "Hello"})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.Provider != "smsc" || result.ExternalMessageID != "smsc-1" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	submitted := server.Submitted()
+	if len(submitted) != 1 {
+		t.Fatalf("Expected 1 submit_sm, got %d", len(submitted))
+	}
+	message := submitted[0]
+	if message.DestinationAddr != "905551112233" || message.DestAddrTON != 1 || message.DestAddrNPI != 1 {
+		t.Errorf("Unexpected destination %+v", message)
+	}
+	if message.SourceAddr != "INSIDER" || message.SourceAddrTON != 5 || message.RegisteredDelivery != 1 {
+		t.Errorf("Unexpected source %+v", message)
+	}
+	if string(message.Message) != "Hello" {
+		t.Errorf("Unexpected short_message %q", message.Message)
+	}
+
+	// numerik gonderici uluslararasi numara olarak gider
+	if _, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233", Content: "Hi", SenderID: "+4915550001111"}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if message := server.Submitted()[1]; message.SourceAddr != "4915550001111" || message.SourceAddrTON != 1 || message.SourceAddrNPI != 1 {
+		t.Errorf("Unexpected numeric source %+v", message)
+	}
+}
+
+func TestSMPPProvider_Send_LongMessage(t *testing.T) {
+	server, provider := newTestSMPP(t)
+
+	result, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233", Content: strings.Repeat("ğ", 100)})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.ExternalMessageID != "smsc-1" {
+		t.Errorf("Expected first segment id, got %q", result.ExternalMessageID)
+	}
+
+	submitted := server.Submitted()
+	if len(submitted) != 2 {
+		t.Fatalf("Expected 2 segments, got %d", len(submitted))
+	}
+	for _, message := range submitted {
+		if message.DataCoding != smpp.DataCodingUCS2 || message.ESMClass&smpp.ESMClassUDHI == 0 {
+			t.Errorf("Expected UCS2 segment with UDH, got %+v", message)
+		}
+	}
+}
+
+func TestSMPPProvider_Send_Errors(t *testing.T) {
+	server, provider := newTestSMPP(t)
+
+	server.SetSubmitStatus(smpp.StatusInvalidDstAddr)
+	_, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1", Content: "x"})
+	if !errors.Is(err, entities.ErrProviderRejected) || entities.ErrorCodeOf(err) != entities.ErrorCodeInvalidRecipient {
+		t.Errorf("Expected invalid recipient rejection, got %v (%s)", err, entities.ErrorCodeOf(err))
+	}
+
+	server.SetSubmitStatus(smpp.StatusThrottled)
+	if _, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1", Content: "x"}); !errors.Is(err, entities.ErrProviderThrottled) {
+		t.Errorf("Expected ErrProviderThrottled, got %v", err)
+	}
+
+	server.SetSubmitStatus(smpp.StatusSystemError)
+	_, err = provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1", Content: "x"})
+	if err == nil || errors.Is(err, entities.ErrProviderRejected) || errors.Is(err, entities.ErrProviderThrottled) {
+		t.Errorf("Expected transient error, got %v", err)
+	}
+	if code := entities.ErrorCodeOf(err); code != entities.ErrorCodeProviderError {
+		t.Errorf("Expected provider_error, got %s", code)
+	}
+}
+
+func TestSMPPProvider_Send_PartialLongMessage(t *testing.T) {
+	server, provider := newTestSMPP(t)
+
+	// ilk parca kabul edildi, ikincisi gecici hata aldi; tekrar gondermek ilk parcayi ikiler
+	server.FailSubmitsAfter(1, smpp.StatusSystemError)
+	_, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233", Content: strings.Repeat("ğ", 100)})
+	if code := entities.ErrorCodeOf(err); code != entities.ErrorCodePartialDelivery || !code.IsPermanent() {
+		t.Errorf("Expected a permanent partial_delivery error, got %v (%s)", err, code)
+	}
+	if len(server.Submitted()) != 1 {
+		t.Errorf("Expected only the first segment to be accepted, got %d", len(server.Submitted()))
+	}
+
+	// ilk parca da reddedilirse hicbir sey gitmedi, hata normal siniflandirilir
+	_, err = provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233", Content: strings.Repeat("ğ", 100)})
+	if code := entities.ErrorCodeOf(err); code != entities.ErrorCodeProviderError {
+		t.Errorf("Expected provider_error when no segment was accepted, got %v (%s)", err, code)
+	}
+}
+
+func TestSMPPAddr(t *testing.T) {
+	tests := []struct {
+		rawURL  string
+		want    string
+		wantErr bool
+	}{
+		{rawURL: "smpp://smsc.example.com:2776", want: "smsc.example.com:2776"},
+		{rawURL: "smsc.example.com", want: "smsc.example.com:2775"},
+		{rawURL: "", wantErr: true},
+		{rawURL: "https://webhook.site/your-webhook-id", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rawURL, func(t *testing.T) {
+			got, err := smppAddr(tt.rawURL)
+			if tt.wantErr {
+				if !errors.Is(err, entities.ErrInvalidProviderConfig) {
+					t.Errorf("Expected ErrInvalidProviderConfig, got %q, %v", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Expected %q, got %q, %v", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestSMPPProvider_BindFailure(t *testing.T) {
+	server, err := smpptest.NewServer("insider", "secret")
+	if err != nil {
+		t.Fatalf("Failed to start SMSC: %v", err)
+	}
+	defer server.Close()
+
+	provider, err := NewSMPPProvider(config.ProviderConfig{Name: "smsc", URL: server.Addr(), Timeout: time.Second, Username: "insider", Password: "wrong"}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	defer provider.Close()
+
+	_, err = provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1", Content: "x"})
+	var statusErr *smpp.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Status != smpp.StatusInvalidPasswd {
+		t.Errorf("Expected invalid password bind error, got %v", err)
+	}
+	if code := entities.ErrorCodeOf(err); code != entities.ErrorCodeAuth {
+		t.Errorf("Expected auth error code, got %s", code)
+	}
+
+	if _, err := NewSMPPProvider(config.ProviderConfig{Name: "smsc", URL: server.Addr()}, zap.NewNop()); !errors.Is(err, entities.ErrInvalidProviderConfig) {
+		t.Errorf("Expected ErrInvalidProviderConfig without system_id, got %v", err)
+	}
+}
+
+func TestSMPPProvider_DeliveryReceipt(t *testing.T) {
+	server, provider := newTestSMPP(t)
+
+	receipts := make(chan entities.DeliveryReceipt, 1)
+	provider.OnDeliveryReceipt(func(ctx context.Context, receipt entities.DeliveryReceipt) error {
+		receipts <- receipt
+		return nil
+	})
+
+	result, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233", Content: "Hello"})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if err := server.DeliverReceipt(result.ExternalMessageID, "UNDELIV"); err != nil {
+		t.Fatalf("Failed to deliver receipt: %v", err)
+	}
+
+	select {
+	case receipt := <-receipts:
+		if receipt.Provider != "smsc" || receipt.ExternalMessageID != "smsc-1" || receipt.Status != entities.DeliveryStatusUndelivered {
+			t.Errorf("Unexpected receipt %+v", receipt)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for delivery receipt")
+	}
+}
+
+func TestSMPPProvider_Reconnect(t *testing.T) {
+	server, provider := newTestSMPP(t)
+
+	if _, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1", Content: "x"}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	server.DropConnections()
+	// baglanti arka planda yeniden kurulur
+	waitFor(t, func() bool { return server.Binds() == 2 })
+
+	if _, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1", Content: "y"}); err != nil {
+		t.Fatalf("Expected send after reconnect to succeed, got: %v", err)
+	}
+	if len(server.Submitted()) != 2 {
+		t.Errorf("Expected 2 submits, got %d", len(server.Submitted()))
+	}
+}
+
+func TestSMPPProvider_KeepAlive(t *testing.T) {
+	server, err := smpptest.NewServer("insider", "secret")
+	if err != nil {
+		t.Fatalf("Failed to start SMSC: %v", err)
+	}
+	defer server.Close()
+
+	provider, err := NewSMPPProvider(config.ProviderConfig{
+		Name:      "smsc",
+		URL:       server.Addr(),
+		Timeout:   time.Second,
+		Username:  "insider",
+		Password:  "secret",
+		KeepAlive: 20 * time.Millisecond,
+	}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	defer provider.Close()
+
+	if _, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+1", Content: "x"}); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	waitFor(t, func() bool { return server.EnquireLinks() >= 2 })
+}
@@ -0,0 +1,246 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+var errSTARTTLSUnsupported = errors.New("SMTP server does not support STARTTLS")
+
+// smtpAuthCodes hesap ayarlarimizla ilgili 5xx yanitlari; mesajin kendisi sorunlu degil
+var smtpAuthCodes = map[int]bool{
+	530: true, // once authentication gerekli
+	534: true, // daha guclu mekanizma gerekli
+	535: true, // kullanici adi/sifre hatali
+	538: true, // sifreleme gerekli
+}
+
+// SMTPProvider email kanalindaki mesajlari duz metin e-posta olarak gonderir. Her gonderim ayri bir
+// SMTP oturumu acar; ExternalMessageID uretilen Message-ID basligidir.
+type SMTPProvider struct {
+	name       string
+	addr       string
+	host       string
+	username   string
+	password   string
+	from       string
+	domain     string
+	tlsMode    string
+	skipVerify bool
+	timeout    time.Duration
+}
+
+func NewSMTPProvider(emailConfig config.EmailConfig) (*SMTPProvider, error) {
+	if !entities.IsValidEmailAddress(emailConfig.From) {
+		return nil, fmt.Errorf("%w: smtp provider needs a sender address (SMTP_FROM)", entities.ErrInvalidProviderConfig)
+	}
+	if emailConfig.Host == "" || emailConfig.Port <= 0 {
+		return nil, fmt.Errorf("%w: smtp provider needs SMTP_HOST and SMTP_PORT", entities.ErrInvalidProviderConfig)
+	}
+
+	switch emailConfig.TLSMode {
+	case config.SMTPTLSStartTLS, config.SMTPTLSImplicit, config.SMTPTLSNone:
+	default:
+		return nil, fmt.Errorf("%w: unknown SMTP_TLS mode %q", entities.ErrInvalidProviderConfig, emailConfig.TLSMode)
+	}
+
+	return &SMTPProvider{
+		name:       emailConfig.Provider,
+		addr:       net.JoinHostPort(emailConfig.Host, strconv.Itoa(emailConfig.Port)),
+		host:       emailConfig.Host,
+		username:   emailConfig.Username,
+		password:   emailConfig.Password,
+		from:       emailConfig.From,
+		domain:     emailConfig.From[strings.LastIndex(emailConfig.From, "@")+1:],
+		tlsMode:    emailConfig.TLSMode,
+		skipVerify: emailConfig.InsecureSkipVerify,
+		timeout:    emailConfig.Timeout,
+	}, nil
+}
+
+func (p *SMTPProvider) Name() string {
+	return p.name
+}
+
+func (p *SMTPProvider) Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+	if request.Channel != entities.MessageChannelEmail || request.Email == "" {
+		return nil, classified(entities.ErrorCodeRejected, "%w: smtp provider only sends email messages", entities.ErrProviderRejected)
+	}
+
+	messageID := fmt.Sprintf("<%s@%s>", request.MessageID, p.domain)
+	body, err := p.buildMessage(request, messageID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.deliver(ctx, request.Email, body); err != nil {
+		return nil, smtpError(err)
+	}
+
+	return &entities.SendResult{Provider: p.name, ExternalMessageID: messageID}, nil
+}
+
+func (p *SMTPProvider) buildMessage(request entities.SendRequest, messageID string, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	headers := [][2]string{
+		{"From", p.from},
+		{"To", request.Email},
+		{"Subject", mime.QEncoding.Encode("utf-8", request.Subject)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"Message-ID", messageID},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", header[0], header[1])
+	}
+	buf.WriteString("\r\n")
+
+	writer := quotedprintable.NewWriter(&buf)
+	if _, err := writer.Write([]byte(request.Content)); err != nil {
+		return nil, fmt.Errorf("failed to encode email body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode email body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (p *SMTPProvider) deliver(ctx context.Context, to string, body []byte) error {
+	conn, err := p.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+
+	// butun oturum icin tek deadline; sifir deger deadline yok demek
+	var deadline time.Time
+	if p.timeout > 0 {
+		deadline = time.Now().Add(p.timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, p.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if p.tlsMode == config.SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errSTARTTLSUnsupported
+		}
+		if err := client.StartTLS(p.tlsConfig()); err != nil {
+			return err
+		}
+	}
+
+	// net/smtp PLAIN auth'u sifrelenmemis baglantida sadece localhost'a yapar
+	if p.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", p.username, p.password, p.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(p.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return &smtpStageError{stage: smtpStageRcpt, err: err}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(body); err != nil {
+		return err
+	}
+	// icerik filtreleri mesaji govde bittikten sonra reddeder
+	if err := writer.Close(); err != nil {
+		return &smtpStageError{stage: smtpStageData, err: err}
+	}
+
+	return client.Quit()
+}
+
+func (p *SMTPProvider) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: p.timeout}
+	if p.tlsMode == config.SMTPTLSImplicit {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: p.tlsConfig()}
+		return tlsDialer.DialContext(ctx, "tcp", p.addr)
+	}
+	return dialer.DialContext(ctx, "tcp", p.addr)
+}
+
+func (p *SMTPProvider) tlsConfig() *tls.Config {
+	return &tls.Config{ServerName: p.host, InsecureSkipVerify: p.skipVerify}
+}
+
+const (
+	smtpStageRcpt = "RCPT"
+	smtpStageData = "DATA"
+)
+
+// smtpStageError 5xx yanitinin alici mi icerik mi icin verildigini ayirt etmek icin hatanin adimini tasir
+type smtpStageError struct {
+	stage string
+	err   error
+}
+
+func (e *smtpStageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *smtpStageError) Unwrap() error {
+	return e.err
+}
+
+// smtpError kalici 5xx yanitlarini ret sayar: RCPT'de alici yok, DATA sonunda icerik reddedildi.
+// Baglanti, 4xx ve authentication hatalari gecicidir.
+func smtpError(err error) error {
+	var protoErr *textproto.Error
+	if !errors.As(err, &protoErr) {
+		if errors.Is(err, errSTARTTLSUnsupported) {
+			return classified(entities.ErrorCodeProviderError, "SMTP delivery failed: %w", err)
+		}
+		return transportError("SMTP delivery failed", err)
+	}
+
+	switch {
+	case smtpAuthCodes[protoErr.Code]:
+		return classified(entities.ErrorCodeAuth, "SMTP delivery failed: %w", err)
+	case protoErr.Code < 500:
+		return classified(entities.ErrorCodeProviderError, "SMTP delivery failed: %w", err)
+	}
+
+	code := entities.ErrorCodeRejected
+	var stageErr *smtpStageError
+	if errors.As(err, &stageErr) {
+		switch stageErr.stage {
+		case smtpStageRcpt:
+			code = entities.ErrorCodeInvalidRecipient
+		case smtpStageData:
+			code = entities.ErrorCodeBlockedContent
+		}
+	}
+	return classified(code, "%w: SMTP %d %s", entities.ErrProviderRejected, protoErr.Code, protoErr.Msg)
+}
@@ -0,0 +1,165 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+	"message-sending-service/internal/infrastructure/external/smtptest"
+)
+
+func newTestSMTP(t *testing.T, options smtptest.Options, tlsMode string) (*smtptest.Server, *SMTPProvider) {
+	server, err := smtptest.NewServer(options)
+	if err != nil {
+		t.Fatalf("Failed to start SMTP sink: %v", err)
+	}
+	t.Cleanup(server.Close)
+
+	provider, err := NewSMTPProvider(config.EmailConfig{
+		Provider:           "smtp",
+		Host:               server.Host(),
+		Port:               server.Port(),
+		Username:           options.Username,
+		Password:           options.Password,
+		From:               "noreply@example.com",
+		TLSMode:            tlsMode,
+		InsecureSkipVerify: true,
+		Timeout:            5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	return server, provider
+}
+
+func emailRequest() entities.SendRequest {
+	return entities.SendRequest{
+		MessageID: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+		Channel:   entities.MessageChannelEmail,
+		Email:     "jane@example.com",
+		Subject:   "Siparişiniz yolda",
+		Content:   "Hello Jane,\nyour order has shipped.",
+	}
+}
+
+func TestSMTPProvider_Send(t *testing.T) {
+	server, provider := newTestSMTP(t, smtptest.Options{Username: "mailer", Password: "secret"}, config.SMTPTLSNone)
+
+	result, err := provider.Send(context.Background(), emailRequest())
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.Provider != "smtp" || result.ExternalMessageID != "<123e4567-e89b-12d3-a456-426614174000@example.com>" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	messages := server.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 email, got %d", len(messages))
+	}
+	if messages[0].From != "noreply@example.com" || len(messages[0].To) != 1 || messages[0].To[0] != "jane@example.com" {
+		t.Errorf("Unexpected envelope %+v", messages[0])
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(messages[0].Data)))
+	if err != nil {
+		t.Fatalf("Failed to parse email: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != "Siparişiniz yolda" {
+		t.Errorf("Unexpected subject %q (%v)", subject, err)
+	}
+	if parsed.Header.Get("Message-ID") != result.ExternalMessageID || parsed.Header.Get("To") != "jane@example.com" {
+		t.Errorf("Unexpected headers %v", parsed.Header)
+	}
+	body, _ := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	// DATA sonlandirilirken govdeye son satir sonu eklenir
+	if strings.TrimSuffix(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n") != "Hello Jane,\nyour order has shipped." {
+		t.Errorf("Unexpected body %q", body)
+	}
+}
+
+func TestSMTPProvider_StartTLS(t *testing.T) {
+	// httptest'in self-signed sertifikasi sink'in STARTTLS'i icin yeterli
+	https := httptest.NewTLSServer(http.NotFoundHandler())
+	tlsConfig := https.TLS
+	https.Close()
+
+	server, provider := newTestSMTP(t, smtptest.Options{Username: "mailer", Password: "secret", TLSConfig: tlsConfig}, config.SMTPTLSStartTLS)
+	if _, err := provider.Send(context.Background(), emailRequest()); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(server.Messages()) != 1 {
+		t.Errorf("Expected 1 email, got %d", len(server.Messages()))
+	}
+
+	// STARTTLS istenip sunucu desteklemiyorsa sifresiz gonderilmez
+	plain, plainProvider := newTestSMTP(t, smtptest.Options{}, config.SMTPTLSStartTLS)
+	if _, err := plainProvider.Send(context.Background(), emailRequest()); !errors.Is(err, errSTARTTLSUnsupported) {
+		t.Errorf("Expected errSTARTTLSUnsupported, got %v", err)
+	}
+	if len(plain.Messages()) != 0 {
+		t.Error("Expected no email without TLS")
+	}
+}
+
+func TestSMTPProvider_Errors(t *testing.T) {
+	server, provider := newTestSMTP(t, smtptest.Options{}, config.SMTPTLSNone)
+
+	server.RejectRecipients(550, "5.1.1 No such user")
+	_, err := provider.Send(context.Background(), emailRequest())
+	if !errors.Is(err, entities.ErrProviderRejected) || entities.ErrorCodeOf(err) != entities.ErrorCodeInvalidRecipient {
+		t.Errorf("Expected invalid recipient rejection, got %v (%s)", err, entities.ErrorCodeOf(err))
+	}
+
+	server.RejectRecipients(451, "4.3.0 Try again later")
+	_, err = provider.Send(context.Background(), emailRequest())
+	if err == nil || errors.Is(err, entities.ErrProviderRejected) || entities.ErrorCodeOf(err) != entities.ErrorCodeProviderError {
+		t.Errorf("Expected transient provider error, got %v (%s)", err, entities.ErrorCodeOf(err))
+	}
+
+	// sifre hatali: hesap sorunu, baska provider denenebilir
+	_, authProvider := newTestSMTP(t, smtptest.Options{Username: "mailer", Password: "secret"}, config.SMTPTLSNone)
+	authProvider.password = "wrong"
+	_, err = authProvider.Send(context.Background(), emailRequest())
+	if err == nil || errors.Is(err, entities.ErrProviderRejected) || entities.ErrorCodeOf(err) != entities.ErrorCodeAuth {
+		t.Errorf("Expected transient auth error, got %v (%s)", err, entities.ErrorCodeOf(err))
+	}
+
+	sms := emailRequest()
+	sms.Channel = entities.MessageChannelSMS
+	if _, err := provider.Send(context.Background(), sms); !errors.Is(err, entities.ErrProviderRejected) {
+		t.Errorf("Expected sms request to be rejected, got %v", err)
+	}
+}
+
+func TestNewSMTPProvider_InvalidConfig(t *testing.T) {
+	valid := config.EmailConfig{Host: "localhost", Port: 587, From: "noreply@example.com", TLSMode: config.SMTPTLSStartTLS}
+
+	for name, modify := range map[string]func(*config.EmailConfig){
+		"missing from":     func(c *config.EmailConfig) { c.From = "" },
+		"invalid from":     func(c *config.EmailConfig) { c.From = "Mailer <noreply@example.com>" },
+		"missing host":     func(c *config.EmailConfig) { c.Host = "" },
+		"unknown tls mode": func(c *config.EmailConfig) { c.TLSMode = "ssl" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			emailConfig := valid
+			modify(&emailConfig)
+			if _, err := NewSMTPProvider(emailConfig); !errors.Is(err, entities.ErrInvalidProviderConfig) {
+				t.Errorf("Expected ErrInvalidProviderConfig, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Package smtptest SMTP adapter testleri icin bellekte calisan bir SMTP sink. Gelen mesajlari saklar,
+// istenirse AUTH PLAIN ve STARTTLS ister, alicilari belirli bir kodla reddedebilir.
+package smtptest
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+type Message struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// Options Username bos degilse MAIL'den once AUTH PLAIN gerekir; TLSConfig verilirse STARTTLS sunulur
+type Options struct {
+	Username  string
+	Password  string
+	TLSConfig *tls.Config
+}
+
+type Server struct {
+	options  Options
+	listener net.Listener
+
+	mu         sync.Mutex
+	messages   []Message
+	rejectCode int
+	rejectMsg  string
+}
+
+// NewServer 127.0.0.1 uzerinde rastgele bir portta dinlemeye baslar
+func NewServer(options Options) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{options: options, listener: listener}
+	go s.accept()
+	return s, nil
+}
+
+func (s *Server) Host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *Server) Close() {
+	s.listener.Close()
+}
+
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// RejectRecipients sonraki RCPT komutlari bu kodla yanitlanir; code 0 normale doner
+func (s *Server) RejectRecipients(code int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rejectCode = code
+	s.rejectMsg = message
+}
+
+func (s *Server) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.serve(conn)
+	}
+}
+
+type session struct {
+	conn          net.Conn
+	text          *textproto.Conn
+	secure        bool
+	authenticated bool
+	from          string
+	to            []string
+}
+
+func (s *Server) serve(conn net.Conn) {
+	sess := &session{conn: conn, text: textproto.NewConn(conn)}
+	defer func() { sess.conn.Close() }()
+
+	sess.text.PrintfLine("220 smtptest ESMTP ready")
+	for {
+		line, err := sess.text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			s.hello(sess, strings.EqualFold(verb, "EHLO"))
+		case "STARTTLS":
+			if s.options.TLSConfig == nil || sess.secure {
+				sess.text.PrintfLine("502 5.5.1 STARTTLS not available")
+				continue
+			}
+			sess.text.PrintfLine("220 2.0.0 Ready to start TLS")
+			tlsConn := tls.Server(sess.conn, s.options.TLSConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			sess.conn, sess.text, sess.secure = tlsConn, textproto.NewConn(tlsConn), true
+			sess.authenticated, sess.from, sess.to = false, "", nil
+		case "AUTH":
+			s.auth(sess, arg)
+		case "MAIL":
+			if s.options.Username != "" && !sess.authenticated {
+				sess.text.PrintfLine("530 5.7.0 Authentication required")
+				continue
+			}
+			sess.from, sess.to = addressArg(arg), nil
+			sess.text.PrintfLine("250 2.1.0 OK")
+		case "RCPT":
+			s.mu.Lock()
+			code, message := s.rejectCode, s.rejectMsg
+			s.mu.Unlock()
+			if code != 0 {
+				sess.text.PrintfLine("%d %s", code, message)
+				continue
+			}
+			sess.to = append(sess.to, addressArg(arg))
+			sess.text.PrintfLine("250 2.1.5 OK")
+		case "DATA":
+			if sess.from == "" || len(sess.to) == 0 {
+				sess.text.PrintfLine("503 5.5.1 Need MAIL and RCPT first")
+				continue
+			}
+			sess.text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := sess.text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, Message{From: sess.from, To: sess.to, Data: data})
+			s.mu.Unlock()
+			sess.from, sess.to = "", nil
+			sess.text.PrintfLine("250 2.0.0 OK queued")
+		case "RSET":
+			sess.from, sess.to = "", nil
+			sess.text.PrintfLine("250 2.0.0 OK")
+		case "NOOP":
+			sess.text.PrintfLine("250 2.0.0 OK")
+		case "QUIT":
+			sess.text.PrintfLine("221 2.0.0 Bye")
+			return
+		default:
+			sess.text.PrintfLine("502 5.5.2 Command not implemented")
+		}
+	}
+}
+
+func (s *Server) hello(sess *session, extended bool) {
+	if !extended {
+		sess.text.PrintfLine("250 smtptest")
+		return
+	}
+
+	lines := []string{"smtptest"}
+	if s.options.TLSConfig != nil && !sess.secure {
+		lines = append(lines, "STARTTLS")
+	}
+	if s.options.Username != "" {
+		lines = append(lines, "AUTH PLAIN")
+	}
+	lines = append(lines, "8BITMIME")
+
+	for i, line := range lines {
+		separator := "-"
+		if i == len(lines)-1 {
+			separator = " "
+		}
+		sess.text.PrintfLine("250%s%s", separator, line)
+	}
+}
+
+// auth sadece ilk yanitla gelen "AUTH PLAIN <base64>" bicimini destekler, net/smtp de boyle gonderir
+func (s *Server) auth(sess *session, arg string) {
+	mechanism, initial, _ := strings.Cut(arg, " ")
+	if !strings.EqualFold(mechanism, "PLAIN") || s.options.Username == "" {
+		sess.text.PrintfLine("504 5.5.4 Unrecognized authentication type")
+		return
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(initial)
+	parts := strings.Split(string(decoded), "\x00")
+	if err != nil || len(parts) != 3 || parts[1] != s.options.Username || parts[2] != s.options.Password {
+		sess.text.PrintfLine("535 5.7.8 Authentication credentials invalid")
+		return
+	}
+
+	sess.authenticated = true
+	sess.text.PrintfLine("235 2.7.0 Authentication successful")
+}
+
+// addressArg "FROM:<a@b.c> SIZE=10" gibi argumandan adresi alir
+func addressArg(arg string) string {
+	start, end := strings.IndexByte(arg, '<'), strings.IndexByte(arg, '>')
+	if start < 0 || end < start {
+		return ""
+	}
+	return arg[start+1 : end]
+}
@@ -0,0 +1,187 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+const (
+	AdapterTwilio = "twilio"
+
+	defaultTwilioURL = "https://api.twilio.com"
+)
+
+// twilioRejectCodes mesajin kendisiyle ilgili hatalar; baska provider'da denemek de ise yaramaz.
+// https://www.twilio.com/docs/api/errors
+var twilioRejectCodes = map[int]entities.ErrorCode{
+	21211: entities.ErrorCodeInvalidRecipient, // gecersiz 'To' numarasi
+	21408: entities.ErrorCodeRejected,         // bolgeye gonderim izni yok
+	21610: entities.ErrorCodeRejected,         // alici STOP ile abonelikten cikmis
+	21612: entities.ErrorCodeInvalidRecipient, // 'To' numarasina yonlendirilemiyor
+	21614: entities.ErrorCodeInvalidRecipient, // 'To' mobil numara degil
+	21617: entities.ErrorCodeRejected,         // mesaj govdesi cok uzun
+	30003: entities.ErrorCodeInvalidRecipient, // alici ulasilamaz
+	30004: entities.ErrorCodeBlockedContent,   // mesaj engellendi
+	30005: entities.ErrorCodeInvalidRecipient, // bilinmeyen hedef
+	30006: entities.ErrorCodeInvalidRecipient, // sabit hat ya da ulasilamayan operator
+	30007: entities.ErrorCodeBlockedContent,   // operator filtresi
+}
+
+// twilioThrottleCodes Twilio'nun bizi yavaslattigi hatalar
+var twilioThrottleCodes = map[int]bool{
+	20429: true, // too many requests
+	30001: true, // kuyruk tasmasi
+}
+
+// TwilioProvider Twilio uyumlu form-encoded Messages.json API'si; cogu SMS firmasi ayni protokolu konusur.
+// Username account SID, Password auth token olarak kullanilir.
+type TwilioProvider struct {
+	name        string
+	baseURL     string
+	accountSID  string
+	authToken   string
+	from        string
+	callbackURL string
+	httpClient  *http.Client
+}
+
+func NewTwilioProvider(providerConfig config.ProviderConfig) (*TwilioProvider, error) {
+	if providerConfig.Username == "" || providerConfig.Password == "" {
+		return nil, fmt.Errorf("%w: twilio adapter needs an account SID (USERNAME) and auth token (PASSWORD)", entities.ErrInvalidProviderConfig)
+	}
+	if providerConfig.From == "" {
+		return nil, fmt.Errorf("%w: twilio adapter needs a default sender (FROM)", entities.ErrInvalidProviderConfig)
+	}
+
+	baseURL := providerConfig.URL
+	if baseURL == "" {
+		baseURL = defaultTwilioURL
+	}
+
+	return &TwilioProvider{
+		name:        providerConfig.Name,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		accountSID:  providerConfig.Username,
+		authToken:   providerConfig.Password,
+		from:        providerConfig.From,
+		callbackURL: providerConfig.CallbackURL,
+		httpClient: &http.Client{
+			Timeout: providerConfig.Timeout,
+		},
+	}, nil
+}
+
+type twilioMessage struct {
+	SID          string  `json:"sid"`
+	Status       string  `json:"status"`
+	ErrorCode    *int    `json:"error_code"`
+	ErrorMessage *string `json:"error_message"`
+}
+
+type twilioErrorResponse struct {
+	Code     int    `json:"code"`
+	Message  string `json:"message"`
+	MoreInfo string `json:"more_info"`
+	Status   int    `json:"status"`
+}
+
+func (p *TwilioProvider) Name() string {
+	return p.name
+}
+
+func (p *TwilioProvider) Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+	form := url.Values{}
+	form.Set("To", request.PhoneNumber)
+	form.Set("Body", request.Content)
+	form.Set("From", p.from)
+	if request.SenderID != "" {
+		form.Set("From", request.SenderID)
+	}
+	if p.callbackURL != "" {
+		form.Set("StatusCallback", p.callbackURL)
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", p.baseURL, url.PathEscape(p.accountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.SetBasicAuth(p.accountSID, p.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "Insider-Sending-Service/1.0")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, transportError("failed to send request", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, transportError("failed to read response body", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errorResponse twilioErrorResponse
+		if err := json.Unmarshal(body, &errorResponse); err != nil || errorResponse.Message == "" {
+			errorResponse.Message = strings.TrimSpace(string(body))
+		}
+		return nil, twilioError(resp.StatusCode, errorResponse.Code, errorResponse.Message, resp.Header.Get("Retry-After"))
+	}
+
+	var message twilioMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		return nil, classified(entities.ErrorCodeProviderError, "failed to decode twilio response: %w", err)
+	}
+
+	// basarili yanitta da mesaj hemen failed donebilir
+	if message.ErrorCode != nil || message.Status == "failed" || message.Status == "undelivered" {
+		code, errorMsg := 0, message.Status
+		if message.ErrorCode != nil {
+			code = *message.ErrorCode
+		}
+		if message.ErrorMessage != nil {
+			errorMsg = *message.ErrorMessage
+		}
+		return nil, twilioError(resp.StatusCode, code, errorMsg, "")
+	}
+	if message.SID == "" {
+		return nil, classified(entities.ErrorCodeProviderError, "twilio response has no message sid")
+	}
+
+	return &entities.SendResult{Provider: p.name, ExternalMessageID: message.SID}, nil
+}
+
+// twilioError Twilio hata kodunu bizim siniflarimiza esler: throttle, kalici ret ya da gecici hata
+func twilioError(status, code int, message, retryAfter string) error {
+	detail := fmt.Sprintf("twilio error %d (HTTP %d): %s", code, status, message)
+
+	if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable || twilioThrottleCodes[code] {
+		throttled := &entities.ProviderThrottledError{RetryAfter: parseRetryAfter(retryAfter, time.Now())}
+		return fmt.Errorf("%s: %w", detail, throttled)
+	}
+
+	errorCode, ok := twilioRejectCodes[code]
+	if !ok {
+		// bilinmeyen kodlarda HTTP durumu belirleyici; basarili yanitta failed donen mesaj provider hatasi
+		errorCode = entities.ErrorCodeProviderError
+		if status >= 400 {
+			errorCode = httpStatusCode(status)
+		}
+	}
+	if errorCode.IsPermanent() {
+		return classified(errorCode, "%w: %s", entities.ErrProviderRejected, detail)
+	}
+	return classified(errorCode, "%s", detail)
+}
@@ -0,0 +1,122 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+func newTestTwilioProvider(t *testing.T, url string) *TwilioProvider {
+	provider, err := NewTwilioProvider(config.ProviderConfig{
+		Name:        "twilio",
+		URL:         url,
+		Timeout:     5 * time.Second,
+		Username:    "AC123",
+		Password:    "secret",
+		From:        "+15550001111",
+		CallbackURL: "https://example.com/callbacks/twilio",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	return provider
+}
+
+func TestTwilioProvider_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2010-04-01/Accounts/AC123/Messages.json" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "AC123" || pass != "secret" {
+			t.Errorf("Unexpected basic auth %q %q", user, pass)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Failed to parse form: %v", err)
+		}
+		if r.PostForm.Get("To") != "+905551112233" || r.PostForm.Get("Body") != "Hello" || r.PostForm.Get("From") != "INSIDER" {
+			t.Errorf("Unexpected form %v", r.PostForm)
+		}
+		if r.PostForm.Get("StatusCallback") != "https://example.com/callbacks/twilio" {
+			t.Errorf("Expected status callback, got %q", r.PostForm.Get("StatusCallback"))
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"sid": "SM42", "status": "queued", "error_code": null, "error_message": null}`))
+	}))
+	defer server.Close()
+
+	provider := newTestTwilioProvider(t, server.URL)
+	result, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233", Content: "Hello", SenderID: "INSIDER"})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.ExternalMessageID != "SM42" || result.Provider != "twilio" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
+
+func TestTwilioProvider_Send_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		retryAfter    string
+		wantRejected  bool
+		wantThrottled bool
+		wantCode      entities.ErrorCode
+	}{
+		{name: "invalid number is rejected", status: 400, body: `{"code": 21211, "message": "The 'To' number is not valid.", "status": 400}`, wantRejected: true, wantCode: entities.ErrorCodeInvalidRecipient},
+		{name: "unsubscribed recipient is rejected", status: 400, body: `{"code": 21610, "message": "Attempt to send to unsubscribed recipient", "status": 400}`, wantRejected: true, wantCode: entities.ErrorCodeRejected},
+		{name: "too many requests is throttled", status: 429, body: `{"code": 20429, "message": "Too Many Requests", "status": 429}`, retryAfter: "3", wantThrottled: true, wantCode: entities.ErrorCodeThrottled},
+		{name: "auth failure is transient", status: 401, body: `{"code": 20003, "message": "Authenticate", "status": 401}`, wantCode: entities.ErrorCodeAuth},
+		{name: "server error is transient", status: 500, body: `{"code": 20500, "message": "Internal Server Error", "status": 500}`, wantCode: entities.ErrorCodeProviderError},
+		{name: "failed in success response is rejected", status: 201, body: `{"sid": "SM1", "status": "failed", "error_code": 30007, "error_message": "Carrier violation"}`, wantRejected: true, wantCode: entities.ErrorCodeBlockedContent},
+		{name: "missing sid", status: 201, body: `{"status": "queued"}`, wantCode: entities.ErrorCodeProviderError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			provider := newTestTwilioProvider(t, server.URL)
+			_, err := provider.Send(context.Background(), entities.SendRequest{PhoneNumber: "+905551112233", Content: "Hello"})
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+
+			if errors.Is(err, entities.ErrProviderRejected) != tt.wantRejected {
+				t.Errorf("Expected rejected=%v, got %v", tt.wantRejected, err)
+			}
+			if code := entities.ErrorCodeOf(err); code != tt.wantCode {
+				t.Errorf("Expected error code %s, got %s", tt.wantCode, code)
+			}
+			var throttled *entities.ProviderThrottledError
+			if errors.As(err, &throttled) != tt.wantThrottled {
+				t.Errorf("Expected throttled=%v, got %v", tt.wantThrottled, err)
+			}
+			if tt.wantThrottled && throttled.RetryAfter != 3*time.Second {
+				t.Errorf("Expected retry after 3s, got %s", throttled.RetryAfter)
+			}
+		})
+	}
+}
+
+func TestNewTwilioProvider_RequiresCredentials(t *testing.T) {
+	_, err := NewTwilioProvider(config.ProviderConfig{Name: "twilio", From: "+15550001111"})
+	if !errors.Is(err, entities.ErrInvalidProviderConfig) {
+		t.Errorf("Expected ErrInvalidProviderConfig, got %v", err)
+	}
+}
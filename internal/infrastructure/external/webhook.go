@@ -0,0 +1,207 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+const (
+	WebhookIDHeader        = "X-Webhook-Id"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// errWebhookAddressBlocked URL'in cozuldugu adres ozel agda; istek hic gonderilmez
+var errWebhookAddressBlocked = errors.New("webhook address is not public")
+
+// nonPublicNetworks net.IP metodlarinin kapsamadigi, internetten erisilemeyen araliklar
+var nonPublicNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("198.18.0.0/15"),
+}
+
+// WebhookProvider webhook kanalindaki mesajlari mesajin URL'ine JSON olarak POST eder. Her istek
+// "<timestamp>.<govde>" uzerinden HMAC-SHA256 ile imzalanir; alici timestamp'i kontrol ederek tekrar
+// gonderilen istekleri reddedebilir. Yonlendirmeler takip edilmez, proxy kullanilmaz ve AllowPrivateNetworks
+// kapaliyken baglanti DNS cozumlemesinden sonra ozel, loopback ve link-local adreslere acilmaz (SSRF).
+type WebhookProvider struct {
+	name       string
+	secret     []byte
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+type webhookPayload struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	Channel  string    `json:"channel"`
+	Category string    `json:"category,omitempty"`
+	Content  string    `json:"content"`
+}
+
+func NewWebhookProvider(webhookConfig config.WebhookConfig) (*WebhookProvider, error) {
+	if webhookConfig.SigningSecret == "" {
+		return nil, fmt.Errorf("%w: webhook channel needs a signing secret (WEBHOOK_SIGNING_SECRET)", entities.ErrInvalidProviderConfig)
+	}
+
+	dialer := &net.Dialer{Timeout: webhookConfig.Timeout}
+	if !webhookConfig.AllowPrivateNetworks {
+		dialer.Control = rejectNonPublicAddress
+	}
+
+	return &WebhookProvider{
+		name:   webhookConfig.Provider,
+		secret: []byte(webhookConfig.SigningSecret),
+		httpClient: &http.Client{
+			Timeout: webhookConfig.Timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: 10 * time.Second,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}, nil
+}
+
+// rejectNonPublicAddress dialer'a cozulmus IP ile cagrilir, yani DNS rebinding ile de atlatilamaz
+func rejectNonPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("%w: %s", errWebhookAddressBlocked, host)
+	}
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// SignWebhook alicinin X-Webhook-Signature'i dogrulamak icin hesaplamasi gereken deger
+func SignWebhook(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (p *WebhookProvider) Name() string {
+	return p.name
+}
+
+// Send basarili yanitta (2xx) ExternalMessageID olarak mesajin kendi ID'sini, yani X-Webhook-Id'yi doner
+func (p *WebhookProvider) Send(ctx context.Context, request entities.SendRequest) (*entities.SendResult, error) {
+	if request.Channel != entities.MessageChannelWebhook || request.WebhookURL == "" {
+		return nil, classified(entities.ErrorCodeRejected, "%w: webhook provider only sends webhook messages", entities.ErrProviderRejected)
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		ID:       request.MessageID,
+		TenantID: request.TenantID,
+		Channel:  string(request.Channel),
+		Category: string(request.Category),
+		Content:  request.Content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, classified(entities.ErrorCodeInvalidRecipient, "%w: invalid webhook url: %v", entities.ErrProviderRejected, err)
+	}
+
+	timestamp := p.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Insider-Sending-Service/1.0")
+	req.Header.Set(WebhookIDHeader, request.MessageID.String())
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(p.secret, timestamp, body))
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, errWebhookAddressBlocked) {
+			return nil, classified(entities.ErrorCodeInvalidRecipient, "%w: %v", entities.ErrProviderRejected, errWebhookAddressBlocked)
+		}
+		return nil, transportError("failed to send webhook", err)
+	}
+	defer resp.Body.Close()
+
+	// yanit govdesi tenant'in okuyabildigi hata mesajina yazilmaz, sadece baglanti tekrar kullanilsin diye okunur
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return &entities.SendResult{Provider: p.name, ExternalMessageID: request.MessageID.String()}, nil
+	}
+
+	return nil, webhookError(resp.StatusCode, resp.Header.Get("Retry-After"))
+}
+
+// webhookError SMS adapter'lariyla ayni siniflar: 429/503 throttle, alicinin istegi reddettigi 4xx kalici
+// ret, 401/403 (imza ya da anahtar kabul edilmedi), 5xx, 408 ve yonlendirmeler gecici hata. Webhook'ta URL
+// alicinin kendisi oldugundan 404/410 gecersiz alici sayilir.
+func webhookError(status int, retryAfter string) error {
+	detail := fmt.Sprintf("webhook responded with status %d", status)
+
+	var code entities.ErrorCode
+	switch {
+	case status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable:
+		throttled := &entities.ProviderThrottledError{RetryAfter: parseRetryAfter(retryAfter, time.Now())}
+		return fmt.Errorf("%s: %w", detail, throttled)
+	case status == http.StatusNotFound || status == http.StatusGone:
+		code = entities.ErrorCodeInvalidRecipient
+	case status >= 400:
+		code = httpStatusCode(status)
+	default:
+		code = entities.ErrorCodeProviderError
+	}
+
+	if code.IsPermanent() {
+		return classified(code, "%w: %s", entities.ErrProviderRejected, detail)
+	}
+	return classified(code, "%s", detail)
+}
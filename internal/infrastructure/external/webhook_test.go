@@ -0,0 +1,198 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+// newTestWebhookProvider httptest sunuculari loopback'te oldugu icin ozel aglara izin verir
+func newTestWebhookProvider(t *testing.T) *WebhookProvider {
+	provider, err := NewWebhookProvider(config.WebhookConfig{Provider: "webhook", SigningSecret: "whsec", Timeout: 5 * time.Second, AllowPrivateNetworks: true})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	provider.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return provider
+}
+
+func webhookRequest(url string) entities.SendRequest {
+	return entities.SendRequest{
+		MessageID:  uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+		TenantID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		Channel:    entities.MessageChannelWebhook,
+		WebhookURL: url,
+		Content:    "order 42 shipped",
+		Category:   entities.MessageCategoryTransactional,
+	}
+}
+
+func TestWebhookProvider_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path != "/hooks/orders" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected request %s %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		if r.Header.Get(WebhookIDHeader) != "123e4567-e89b-12d3-a456-426614174000" || r.Header.Get(WebhookTimestampHeader) != "1700000000" {
+			t.Errorf("Unexpected headers %v", r.Header)
+		}
+		if r.Header.Get(WebhookSignatureHeader) != SignWebhook([]byte("whsec"), 1700000000, body) {
+			t.Errorf("Signature does not match body")
+		}
+
+		var payload map[string]string
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("Failed to parse payload: %v", err)
+		}
+		if payload["id"] != "123e4567-e89b-12d3-a456-426614174000" || payload["content"] != "order 42 shipped" || payload["channel"] != "webhook" {
+			t.Errorf("Unexpected payload %s", body)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	result, err := newTestWebhookProvider(t).Send(context.Background(), webhookRequest(server.URL+"/hooks/orders"))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if result.Provider != "webhook" || result.ExternalMessageID != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	// alici tarafta ayni deger openssl ile de uretilebilir:
+	// printf '1700000000.{}' | openssl dgst -sha256 -hmac whsec
+	want := "v1=7d44587dddbaf4c7f70fef20f48cd594834ffea1641e3ac227b84408298738af"
+	if got := SignWebhook([]byte("whsec"), 1700000000, []byte("{}")); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestWebhookProvider_Send_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		retryAfter    string
+		wantRejected  bool
+		wantThrottled bool
+		wantCode      entities.ErrorCode
+	}{
+		{name: "bad request is rejected", status: 400, wantRejected: true, wantCode: entities.ErrorCodeRejected},
+		{name: "gone is rejected", status: 410, wantRejected: true, wantCode: entities.ErrorCodeInvalidRecipient},
+		{name: "too many requests is throttled", status: 429, retryAfter: "3", wantThrottled: true, wantCode: entities.ErrorCodeThrottled},
+		{name: "unavailable is throttled", status: 503, retryAfter: "3", wantThrottled: true, wantCode: entities.ErrorCodeThrottled},
+		{name: "unauthorized is transient", status: 401, wantCode: entities.ErrorCodeAuth},
+		{name: "request timeout is transient", status: 408, wantCode: entities.ErrorCodeTimeout},
+		{name: "server error is transient", status: 500, wantCode: entities.ErrorCodeProviderError},
+		{name: "redirect is not followed", status: 302, wantCode: entities.ErrorCodeProviderError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte("secret internal response"))
+			}))
+			defer server.Close()
+
+			_, err := newTestWebhookProvider(t).Send(context.Background(), webhookRequest(server.URL))
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+
+			if strings.Contains(err.Error(), "secret internal response") {
+				t.Errorf("Expected the response body to stay out of the error, got %v", err)
+			}
+			if errors.Is(err, entities.ErrProviderRejected) != tt.wantRejected {
+				t.Errorf("Expected rejected=%v, got %v", tt.wantRejected, err)
+			}
+			if code := entities.ErrorCodeOf(err); code != tt.wantCode {
+				t.Errorf("Expected error code %s, got %s", tt.wantCode, code)
+			}
+			var throttled *entities.ProviderThrottledError
+			if errors.As(err, &throttled) != tt.wantThrottled {
+				t.Errorf("Expected throttled=%v, got %v", tt.wantThrottled, err)
+			}
+			if tt.wantThrottled && throttled.RetryAfter != 3*time.Second {
+				t.Errorf("Expected retry after 3s, got %s", throttled.RetryAfter)
+			}
+		})
+	}
+
+	sms := webhookRequest("http://127.0.0.1")
+	sms.Channel = entities.MessageChannelSMS
+	if _, err := newTestWebhookProvider(t).Send(context.Background(), sms); !errors.Is(err, entities.ErrProviderRejected) {
+		t.Errorf("Expected sms request to be rejected, got %v", err)
+	}
+}
+
+func TestWebhookProvider_Send_BlocksPrivateAddresses(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	provider, err := NewWebhookProvider(config.WebhookConfig{Provider: "webhook", SigningSecret: "whsec", Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	_, err = provider.Send(context.Background(), webhookRequest(server.URL))
+	if !errors.Is(err, entities.ErrProviderRejected) || entities.ErrorCodeOf(err) != entities.ErrorCodeInvalidRecipient {
+		t.Errorf("Expected a loopback webhook to be rejected as invalid recipient, got %v", err)
+	}
+	if called {
+		t.Error("Expected no request to reach a loopback address")
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+		"::ffff:10.0.0.1": false,
+	}
+
+	for address, want := range tests {
+		if got := isPublicIP(net.ParseIP(address)); got != want {
+			t.Errorf("isPublicIP(%s) = %v, want %v", address, got, want)
+		}
+	}
+}
+
+func TestNewWebhookProvider_RequiresSecret(t *testing.T) {
+	_, err := NewWebhookProvider(config.WebhookConfig{Provider: "webhook"})
+	if !errors.Is(err, entities.ErrInvalidProviderConfig) {
+		t.Errorf("Expected ErrInvalidProviderConfig, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package pricing
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/repositories"
+)
+
+type filePriceRepository struct {
+	path string
+}
+
+// NewFilePriceRepository fiyatlari "provider,prefix,price_per_segment" kolonlu CSV dosyasindan okur.
+// Ilk satir baslik olabilir, # ile baslayan satirlar atlanir. path bos ise tablo bos doner.
+func NewFilePriceRepository(path string) repositories.PriceRepository {
+	return &filePriceRepository{path: path}
+}
+
+func (r *filePriceRepository) Load(ctx context.Context) ([]entities.PriceEntry, error) {
+	if r.path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open price table: %w", err)
+	}
+	defer file.Close()
+
+	return ParsePriceTable(file)
+}
+
+// ParsePriceTable CSV icerigini fiyat satirlarina cevirir, gecerlilik kontrolu entities.NewPriceTable'da yapilir
+func ParsePriceTable(reader io.Reader) ([]entities.PriceEntry, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comment = '#'
+	csvReader.FieldsPerRecord = 3
+	csvReader.TrimLeadingSpace = true
+
+	var entries []entities.PriceEntry
+	for line := 1; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read price table: %w", err)
+		}
+
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "provider") {
+			continue
+		}
+
+		price, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %q is not a price", entities.ErrInvalidPriceEntry, line, record[2])
+		}
+
+		entries = append(entries, entities.PriceEntry{
+			Provider:        strings.TrimSpace(record[0]),
+			Prefix:          strings.TrimPrefix(strings.TrimSpace(record[1]), "+"),
+			PricePerSegment: price,
+		})
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,65 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"message-sending-service/internal/domain/entities"
+)
+
+func TestParsePriceTable(t *testing.T) {
+	input := `provider,prefix,price_per_segment
+# Turkey
+*,+90,0.02
+acme, 90532, 0.012
+`
+	entries, err := ParsePriceTable(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := []entities.PriceEntry{
+		{Provider: "*", Prefix: "90", PricePerSegment: 0.02},
+		{Provider: "acme", Prefix: "90532", PricePerSegment: 0.012},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %d", len(want), len(entries))
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("Entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+
+	if _, err := ParsePriceTable(strings.NewReader("acme,90,cheap\n")); !errors.Is(err, entities.ErrInvalidPriceEntry) {
+		t.Errorf("Expected ErrInvalidPriceEntry for bad price, got %v", err)
+	}
+	if _, err := ParsePriceTable(strings.NewReader("acme,90\n")); err == nil {
+		t.Error("Expected error for missing column")
+	}
+}
+
+func TestFilePriceRepository_Load(t *testing.T) {
+	entries, err := NewFilePriceRepository("").Load(context.Background())
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Expected empty table without a file, got %v, %v", entries, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "prices.csv")
+	if err := os.WriteFile(path, []byte("default,44,0.035\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = NewFilePriceRepository(path).Load(context.Background())
+	if err != nil || len(entries) != 1 || entries[0].Prefix != "44" {
+		t.Errorf("Unexpected entries: %v, %v", entries, err)
+	}
+
+	if _, err := NewFilePriceRepository(filepath.Join(t.TempDir(), "missing.csv")).Load(context.Background()); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -35,6 +35,20 @@ func (r *cacheRepositoryImpl) Set(ctx context.Context, key string, value interfa
 	return nil
 }
 
+func (r *cacheRepositoryImpl) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	ok, err := r.client.SetNX(ctx, key, jsonValue, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to set cache value: %w", err)
+	}
+
+	return ok, nil
+}
+
 func (r *cacheRepositoryImpl) Get(ctx context.Context, key string) (string, error) {
 	val, err := r.client.Get(ctx, key).Result()
 	if err != nil {
@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"message-sending-service/internal/domain/services"
+	"message-sending-service/internal/infrastructure/config"
+)
+
+// acquireScript pause anahtarina ve token bucket'a tek adimda bakar, beklenecek sureyi ms olarak doner.
+// Saat Redis'ten alinir, boylece replikalar arasindaki saat farki bucket'i bozmaz.
+var acquireScript = redis.NewScript(`
+local pause = redis.call('PTTL', KEYS[2])
+if pause > 0 then
+	return pause
+end
+
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+if rate <= 0 then
+	return 0
+end
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local tokens = tonumber(redis.call('HGET', KEYS[1], 'tokens'))
+local updated = tonumber(redis.call('HGET', KEYS[1], 'updated'))
+if tokens == nil or updated == nil then
+	tokens = burst
+	updated = now
+end
+tokens = math.min(burst, tokens + (now - updated) * rate / 1000)
+
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return wait
+`)
+
+type bucketLimit struct {
+	rate  float64
+	burst int
+}
+
+// rateLimiterImpl tum replikalar ayni provider icin ayni bucket'i ve ayni pause anahtarini kullanir
+type rateLimiterImpl struct {
+	client *redis.Client
+	limits map[string]bucketLimit
+}
+
+func NewRateLimiter(client *redis.Client, providers []config.ProviderConfig) services.ProviderRateLimiter {
+	limits := make(map[string]bucketLimit, len(providers))
+	for _, provider := range providers {
+		limits[provider.Name] = bucketLimit{rate: provider.RateLimit, burst: provider.Burst()}
+	}
+	return &rateLimiterImpl{
+		client: client,
+		limits: limits,
+	}
+}
+
+func (r *rateLimiterImpl) Acquire(ctx context.Context, provider string) (time.Duration, error) {
+	limit := r.limits[provider]
+	keys := []string{bucketKey(provider), pauseKey(provider)}
+
+	wait, err := acquireScript.Run(ctx, r.client, keys, limit.rate, limit.burst).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to acquire rate limit token: %w", err)
+	}
+
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+func (r *rateLimiterImpl) Pause(ctx context.Context, provider string, d time.Duration) error {
+	// sifir sure Redis'te suresiz anahtar demek
+	if d <= 0 {
+		return nil
+	}
+	if err := r.client.Set(ctx, pauseKey(provider), "1", d).Err(); err != nil {
+		return fmt.Errorf("failed to pause provider: %w", err)
+	}
+	return nil
+}
+
+func bucketKey(provider string) string {
+	return fmt.Sprintf("provider_rate:%s", provider)
+}
+
+func pauseKey(provider string) string {
+	return fmt.Sprintf("provider_pause:%s", provider)
+}
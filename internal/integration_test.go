@@ -2,7 +2,6 @@ package internal
 
 import (
 	"bytes"
-	"context"
 	"database/sql"
 	"encoding/json"
 	"net/http"
@@ -17,17 +16,16 @@ import (
 	"message-sending-service/internal/application/handlers"
 	"message-sending-service/internal/application/usecases"
 	"message-sending-service/internal/infrastructure/config"
-	"message-sending-service/internal/infrastructure/database"
 	"message-sending-service/internal/infrastructure/external"
-	"message-sending-service/internal/presentation/http"
+	httpPresentation "message-sending-service/internal/presentation/http"
 )
 
 // Integration test: Full message creation flow
 func TestMessageCreationFlow_Integration(t *testing.T) {
 	// Bu test gerçek bir integration test değil, ama nasıl olacağını gösteriyor
-	
+
 	gin.SetMode(gin.TestMode)
-	
+
 	// Setup test configuration
 	cfg := &config.Config{
 		External: config.ExternalConfig{
@@ -38,13 +36,17 @@ func TestMessageCreationFlow_Integration(t *testing.T) {
 			Interval:         2 * time.Minute,
 			MessagesPerBatch: 2,
 		},
+		Auth: config.AuthConfig{Enabled: false},
 	}
 
 	// Setup logger
 	logger, _ := zap.NewNop(), zap.NewNop()
 
 	// Setup external API client (real one)
-	apiClient := external.NewMessageAPIClient(cfg)
+	apiClient, err := external.NewMessageAPIClient(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create API client: %v", err)
+	}
 
 	// Note: In a real integration test, you would:
 	// 1. Setup test database
@@ -53,15 +55,15 @@ func TestMessageCreationFlow_Integration(t *testing.T) {
 	// But for this demo, we'll use nil and focus on the flow
 
 	// Setup use cases
-	messageUseCase := usecases.NewMessageUseCase(nil, nil, apiClient, logger)
-	schedulerUseCase := usecases.NewSchedulerUseCase(messageUseCase, nil, cfg, logger)
+	messageUseCase := usecases.NewMessageUseCase(nil, nil, nil, apiClient, nil, nil, nil, nil, nil, cfg, logger)
+	schedulerUseCase := usecases.NewSchedulerUseCase(messageUseCase, nil, nil, nil, cfg, logger)
 
 	// Setup handlers
 	messageHandler := handlers.NewMessageHandler(messageUseCase, logger)
 	schedulerHandler := handlers.NewSchedulerHandler(schedulerUseCase, logger)
 
 	// Setup router (real HTTP router)
-	router := http.NewRouter(messageHandler, schedulerHandler, logger)
+	router := httpPresentation.NewRouter(messageHandler, schedulerHandler, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg, logger)
 	ginEngine := router.SetupRoutes()
 
 	t.Run("create message via HTTP API", func(t *testing.T) {
@@ -74,7 +76,7 @@ func TestMessageCreationFlow_Integration(t *testing.T) {
 		reqBody, _ := json.Marshal(createReq)
 		req := httptest.NewRequest("POST", "/api/v1/messages", bytes.NewBuffer(reqBody))
 		req.Header.Set("Content-Type", "application/json")
-		
+
 		// Make request
 		w := httptest.NewRecorder()
 		ginEngine.ServeHTTP(w, req)
@@ -88,7 +90,7 @@ func TestMessageCreationFlow_Integration(t *testing.T) {
 	t.Run("get scheduler status via HTTP API", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/api/v1/scheduler/status", nil)
 		w := httptest.NewRecorder()
-		
+
 		ginEngine.ServeHTTP(w, req)
 
 		// This should work since it doesn't need database
@@ -116,54 +118,54 @@ func TestDatabaseIntegration_Template(t *testing.T) {
 	t.Skip("This is a template - requires test database setup")
 
 	/*
-	Real implementation would be:
+		Real implementation would be:
 
-	// 1. Setup test database
-	testDB := setupTestDatabase(t)
-	defer cleanupTestDatabase(t, testDB)
+		// 1. Setup test database
+		testDB := setupTestDatabase(t)
+		defer cleanupTestDatabase(t, testDB)
 
-	// 2. Run migrations
-	err := database.CreateTables(testDB)
-	if err != nil {
-		t.Fatalf("Failed to create tables: %v", err)
-	}
+		// 2. Run migrations
+		err := database.CreateTables(testDB)
+		if err != nil {
+			t.Fatalf("Failed to create tables: %v", err)
+		}
 
-	// 3. Create real repositories
-	messageRepo := database.NewMessageRepository(testDB)
+		// 3. Create real repositories
+		messageRepo := database.NewMessageRepository(testDB)
 
-	// 4. Test actual database operations
-	message := &entities.Message{
-		ID:          uuid.New(),
-		Content:     "Test message",
-		PhoneNumber: "+1234567890",
-		Status:      entities.MessageStatusPending,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-	}
+		// 4. Test actual database operations
+		message := &entities.Message{
+			ID:          uuid.New(),
+			Content:     "Test message",
+			PhoneNumber: "+1234567890",
+			Status:      entities.MessageStatusPending,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
 
-	// Create
-	err = messageRepo.Create(context.Background(), message)
-	if err != nil {
-		t.Fatalf("Failed to create message: %v", err)
-	}
+		// Create
+		err = messageRepo.Create(context.Background(), message)
+		if err != nil {
+			t.Fatalf("Failed to create message: %v", err)
+		}
 
-	// Retrieve
-	retrieved, err := messageRepo.GetByID(context.Background(), message.ID)
-	if err != nil {
-		t.Fatalf("Failed to get message: %v", err)
-	}
+		// Retrieve
+		retrieved, err := messageRepo.GetByID(context.Background(), message.ID)
+		if err != nil {
+			t.Fatalf("Failed to get message: %v", err)
+		}
 
-	// Verify
-	if retrieved.Content != message.Content {
-		t.Errorf("Expected content %s, got %s", message.Content, retrieved.Content)
-	}
+		// Verify
+		if retrieved.Content != message.Content {
+			t.Errorf("Expected content %s, got %s", message.Content, retrieved.Content)
+		}
 	*/
 }
 
 // Benchmark integration test
 func BenchmarkMessageCreationFlow(b *testing.B) {
 	gin.SetMode(gin.ReleaseMode)
-	
+
 	cfg := &config.Config{
 		External: config.ExternalConfig{
 			MessageAPIURL: "https://httpbin.org/post",
@@ -172,8 +174,11 @@ func BenchmarkMessageCreationFlow(b *testing.B) {
 	}
 
 	logger, _ := zap.NewNop(), zap.NewNop()
-	apiClient := external.NewMessageAPIClient(cfg)
-	messageUseCase := usecases.NewMessageUseCase(nil, nil, apiClient, logger)
+	apiClient, err := external.NewMessageAPIClient(cfg)
+	if err != nil {
+		b.Fatalf("Failed to create API client: %v", err)
+	}
+	messageUseCase := usecases.NewMessageUseCase(nil, nil, nil, apiClient, nil, nil, nil, nil, nil, cfg, logger)
 	messageHandler := handlers.NewMessageHandler(messageUseCase, logger)
 
 	createReq := dto.CreateMessageRequest{
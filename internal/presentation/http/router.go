@@ -8,6 +8,9 @@ import (
 
 	"message-sending-service/internal/application/handlers"
 	"message-sending-service/internal/application/middlewares"
+	"message-sending-service/internal/domain/entities"
+	"message-sending-service/internal/domain/usecases"
+	"message-sending-service/internal/infrastructure/config"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -16,17 +19,53 @@ import (
 type Router struct {
 	messageHandler   *handlers.MessageHandler
 	schedulerHandler *handlers.SchedulerHandler
+	linkHandler      *handlers.LinkHandler
+	contactHandler   *handlers.ContactHandler
+	tenantHandler    *handlers.TenantHandler
+	apiKeyHandler    *handlers.APIKeyHandler
+	auditHandler     *handlers.AuditHandler
+	usageHandler     *handlers.UsageHandler
+	pricingHandler   *handlers.PricingHandler
+	routingHandler   *handlers.RoutingHandler
+	twilioHandler    *handlers.TwilioCallbackHandler
+	tenantUseCase    usecases.TenantUseCase
+	authenticators   []middlewares.Authenticator
+	config           *config.Config
 	logger           *zap.Logger
 }
 
 func NewRouter(
 	messageHandler *handlers.MessageHandler,
 	schedulerHandler *handlers.SchedulerHandler,
+	linkHandler *handlers.LinkHandler,
+	contactHandler *handlers.ContactHandler,
+	tenantHandler *handlers.TenantHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	auditHandler *handlers.AuditHandler,
+	usageHandler *handlers.UsageHandler,
+	pricingHandler *handlers.PricingHandler,
+	routingHandler *handlers.RoutingHandler,
+	twilioHandler *handlers.TwilioCallbackHandler,
+	tenantUseCase usecases.TenantUseCase,
+	authenticators []middlewares.Authenticator,
+	config *config.Config,
 	logger *zap.Logger,
 ) *Router {
 	return &Router{
 		messageHandler:   messageHandler,
 		schedulerHandler: schedulerHandler,
+		linkHandler:      linkHandler,
+		contactHandler:   contactHandler,
+		tenantHandler:    tenantHandler,
+		apiKeyHandler:    apiKeyHandler,
+		auditHandler:     auditHandler,
+		usageHandler:     usageHandler,
+		pricingHandler:   pricingHandler,
+		routingHandler:   routingHandler,
+		twilioHandler:    twilioHandler,
+		tenantUseCase:    tenantUseCase,
+		authenticators:   authenticators,
+		config:           config,
 		logger:           logger,
 	}
 }
@@ -39,18 +78,99 @@ func (r *Router) SetupRoutes() *gin.Engine {
 	router.Use(middlewares.RequestLoggingMiddleware(r.logger))
 	router.GET("/health", r.healthCheck)
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/r/:code", r.linkHandler.Redirect)
+	// Twilio API key gonderemez; callback X-Twilio-Signature ile dogrulanir
+	router.POST("/callbacks/twilio/:provider", r.twilioHandler.StatusCallback)
 	v1 := router.Group("/api/v1")
+	if r.authEnabled() {
+		v1.Use(middlewares.AuthMiddleware(r.logger, r.authenticators...))
+	}
+	v1.Use(middlewares.ActorMiddleware())
 	{
-		messages := v1.Group("/messages")
+		tenants := v1.Group("/tenants", r.requireScope(entities.ScopeAdmin))
+		{
+			tenants.POST("", r.tenantHandler.CreateTenant)
+			tenants.GET("", r.tenantHandler.GetTenants)
+			tenants.GET("/:id", r.tenantHandler.GetTenant)
+			tenants.GET("/:id/quota", r.usageHandler.GetQuota)
+			tenants.PUT("/:id/quota", r.usageHandler.SetQuota)
+		}
+
+		apiKeys := v1.Group("/api-keys", r.requireScope(entities.ScopeAdmin))
+		{
+			apiKeys.POST("", r.apiKeyHandler.CreateAPIKey)
+			apiKeys.GET("", r.apiKeyHandler.GetAPIKeys)
+			apiKeys.DELETE("/:id", r.apiKeyHandler.RevokeAPIKey)
+		}
+
+		v1.GET("/audit", r.requireScope(entities.ScopeAdmin), r.auditHandler.GetAuditLogs)
+
+		pricing := v1.Group("/pricing", r.requireScope(entities.ScopeAdmin))
+		{
+			pricing.GET("", r.pricingHandler.GetPrices)
+			pricing.POST("/reload", r.pricingHandler.ReloadPrices)
+		}
+
+		routing := v1.Group("/routing", r.requireScope(entities.ScopeAdmin))
+		{
+			routing.POST("/rules", r.routingHandler.CreateRule)
+			routing.GET("/rules", r.routingHandler.GetRules)
+			routing.GET("/rules/:id", r.routingHandler.GetRule)
+			routing.PUT("/rules/:id", r.routingHandler.UpdateRule)
+			routing.DELETE("/rules/:id", r.routingHandler.DeleteRule)
+			routing.POST("/reload", r.routingHandler.ReloadRules)
+		}
+
+		// mesaj olusturan ya da okuyan butun route'lar tenant context'i ile calisir
+		scoped := v1.Group("", middlewares.TenantMiddleware(r.tenantUseCase, r.logger))
+
+		// teslim raporu sadece anahtarin tenant'inin mesajlarini degistirebilir
+		scoped.POST("/delivery-receipts/:provider", r.requireScope(entities.ScopeReceiptsWrite), r.messageHandler.RecordDeliveryReceipt)
+
+		messages := scoped.Group("/messages")
 		{
-			messages.POST("", r.messageHandler.CreateMessage)
-			messages.GET("/:id", r.messageHandler.GetMessage)
-			messages.GET("/sent", r.messageHandler.GetSentMessages)
-			messages.GET("/stats", r.messageHandler.GetMessageStats)
-			messages.POST("/:id/send", r.messageHandler.SendMessage)
+			messages.POST("", r.requireScope(entities.ScopeMessagesWrite), r.messageHandler.CreateMessage)
+			messages.GET("/:id", r.requireScope(entities.ScopeMessagesRead), r.messageHandler.GetMessage)
+			messages.PATCH("/:id", r.requireScope(entities.ScopeMessagesWrite), r.messageHandler.UpdateMessage)
+			messages.POST("/:id/cancel", r.requireScope(entities.ScopeMessagesWrite), r.messageHandler.CancelMessage)
+			messages.POST("/:id/requeue", r.requireScope(entities.ScopeMessagesWrite), r.messageHandler.RequeueMessage)
+			messages.GET("/sent", r.requireScope(entities.ScopeMessagesRead), r.messageHandler.GetSentMessages)
+			messages.GET("/stats", r.requireScope(entities.ScopeMessagesRead), r.messageHandler.GetMessageStats)
+			messages.POST("/:id/send", r.requireScope(entities.ScopeMessagesWrite), r.messageHandler.SendMessage)
+			messages.GET("/:id/clicks", r.requireScope(entities.ScopeMessagesRead), r.linkHandler.GetMessageClicks)
 		}
 
-		scheduler := v1.Group("/scheduler")
+		contacts := scoped.Group("/contacts")
+		{
+			contacts.POST("", r.requireScope(entities.ScopeContactsWrite), r.contactHandler.CreateContact)
+			contacts.GET("", r.requireScope(entities.ScopeContactsRead), r.contactHandler.GetContacts)
+			contacts.GET("/:id", r.requireScope(entities.ScopeContactsRead), r.contactHandler.GetContact)
+			contacts.PUT("/:id", r.requireScope(entities.ScopeContactsWrite), r.contactHandler.UpdateContact)
+			contacts.DELETE("/:id", r.requireScope(entities.ScopeContactsWrite), r.contactHandler.DeleteContact)
+		}
+
+		lists := scoped.Group("/lists")
+		{
+			lists.POST("", r.requireScope(entities.ScopeContactsWrite), r.contactHandler.CreateList)
+			lists.GET("", r.requireScope(entities.ScopeContactsRead), r.contactHandler.GetLists)
+			lists.GET("/:id", r.requireScope(entities.ScopeContactsRead), r.contactHandler.GetList)
+			lists.PUT("/:id", r.requireScope(entities.ScopeContactsWrite), r.contactHandler.UpdateList)
+			lists.DELETE("/:id", r.requireScope(entities.ScopeContactsWrite), r.contactHandler.DeleteList)
+			lists.GET("/:id/contacts", r.requireScope(entities.ScopeContactsRead), r.contactHandler.GetListContacts)
+			lists.POST("/:id/contacts", r.requireScope(entities.ScopeContactsWrite), r.contactHandler.AddListContacts)
+			lists.DELETE("/:id/contacts/:contactId", r.requireScope(entities.ScopeContactsWrite), r.contactHandler.RemoveListContact)
+			lists.POST("/:id/broadcast", r.requireScope(entities.ScopeContactsRead, entities.ScopeMessagesWrite), r.contactHandler.Broadcast)
+		}
+
+		scoped.GET("/usage", r.requireScope(entities.ScopeMessagesRead), r.usageHandler.GetUsage)
+		scoped.GET("/reports/cost", r.requireScope(entities.ScopeMessagesRead), r.pricingHandler.GetCostReport)
+
+		campaigns := scoped.Group("/campaigns")
+		{
+			campaigns.GET("/:campaign/clicks", r.requireScope(entities.ScopeMessagesRead), r.linkHandler.GetCampaignClicks)
+		}
+
+		scheduler := v1.Group("/scheduler", r.requireScope(entities.ScopeSchedulerAdmin))
 		{
 			scheduler.POST("/start", r.schedulerHandler.StartScheduler)
 			scheduler.POST("/stop", r.schedulerHandler.StopScheduler)
@@ -61,6 +181,18 @@ func (r *Router) SetupRoutes() *gin.Engine {
 	return router
 }
 
+func (r *Router) authEnabled() bool {
+	return r.config == nil || r.config.Auth.Enabled
+}
+
+// requireScope auth kapaliyken scope kontrolu de yapilmaz
+func (r *Router) requireScope(scopes ...string) gin.HandlerFunc {
+	if !r.authEnabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return middlewares.RequireScope(scopes...)
+}
+
 func (r *Router) healthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "healthy",
@@ -34,7 +34,8 @@ CREATE TABLE IF NOT EXISTS messages
 (
     'pending',
     'sent',
-    'failed'
+    'failed',
+    'cancelled'
 )),
     CONSTRAINT valid_content_length CHECK
 (
@@ -44,11 +45,208 @@ CREATE TABLE IF NOT EXISTS messages
 ) <= 160)
     );
 
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS category VARCHAR(20) NOT NULL DEFAULT 'transactional';
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS campaign VARCHAR(100);
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS sender_id VARCHAR(16);
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS cost_prefix VARCHAR(16);
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS estimated_cost NUMERIC(14, 6);
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS actual_cost NUMERIC(14, 6);
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS provider VARCHAR(64);
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS delivery_status VARCHAR(20);
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS delivery_error VARCHAR(64);
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS delivery_reported_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS channel VARCHAR(10) NOT NULL DEFAULT 'sms';
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS email VARCHAR(254);
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS subject VARCHAR(255);
+CREATE INDEX IF NOT EXISTS idx_messages_email ON messages (email);
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS webhook_url VARCHAR(2048);
+
+-- Class of the last send failure (network, timeout, invalid_recipient, ...), grouped in /messages/stats
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS error_code VARCHAR(32);
+
+-- Transient send failures keep the message pending until next_attempt_at; attempts counts the failures
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP WITH TIME ZONE;
+
+-- Usage reserved from the tenant quota when the message was accepted; given back if it is never sent
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS quota_reserved_on DATE;
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS quota_reserved_segments BIGINT NOT NULL DEFAULT 0;
+
+-- The 160 character limit only applies to SMS; email and webhook bodies are longer
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS valid_content_length;
+ALTER TABLE messages
+    ADD CONSTRAINT valid_content_length CHECK (channel <> 'sms' OR char_length(content) <= 160);
+
+-- Delivery receipts look messages up by the id the provider returned
+CREATE INDEX IF NOT EXISTS idx_messages_external_id ON messages (provider, external_message_id);
+
+CREATE TABLE IF NOT EXISTS tenants
+(
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Existing rows and clients without X-Tenant-ID belong to the default tenant
+INSERT INTO tenants (id, name)
+VALUES ('00000000-0000-0000-0000-000000000001', 'default') ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants (id);
+
+-- Only the SHA-256 hash of each key is stored; prefix is kept for display
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id    UUID         NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    name         VARCHAR(255) NOT NULL,
+    prefix       VARCHAR(16)  NOT NULL,
+    key_hash     CHAR(64)     NOT NULL UNIQUE,
+    scopes       TEXT[]       NOT NULL DEFAULT '{}',
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at   TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS tenant_usage
+(
+    tenant_id UUID   NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    day       DATE   NOT NULL,
+    messages  BIGINT NOT NULL DEFAULT 0,
+    segments  BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant_id, day)
+);
+
+CREATE TABLE IF NOT EXISTS tenant_quotas
+(
+    tenant_id          UUID PRIMARY KEY REFERENCES tenants (id) ON DELETE CASCADE,
+    daily_messages     BIGINT  NOT NULL DEFAULT 0,
+    daily_segments     BIGINT  NOT NULL DEFAULT 0,
+    monthly_messages   BIGINT  NOT NULL DEFAULT 0,
+    monthly_segments   BIGINT  NOT NULL DEFAULT 0,
+    soft_limit_percent INTEGER NOT NULL DEFAULT 80,
+    updated_at         TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS routing_rules
+(
+    id         UUID PRIMARY KEY         DEFAULT gen_random_uuid(),
+    name       VARCHAR(100) NOT NULL,
+    prefix     VARCHAR(16)  NOT NULL    DEFAULT '',
+    tenant_id  UUID REFERENCES tenants (id) ON DELETE CASCADE,
+    category   VARCHAR(20),
+    targets    JSONB        NOT NULL,
+    enabled    BOOLEAN      NOT NULL    DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS short_links
+(
+    code       VARCHAR(16) PRIMARY KEY,
+    tenant_id  UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants (id) ON DELETE CASCADE,
+    message_id UUID NOT NULL,
+    campaign   VARCHAR(100),
+    target_url TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS link_clicks
+(
+    id         BIGSERIAL PRIMARY KEY,
+    code       VARCHAR(16) NOT NULL REFERENCES short_links (code) ON DELETE CASCADE,
+    tenant_id  UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants (id) ON DELETE CASCADE,
+    message_id UUID NOT NULL,
+    campaign   VARCHAR(100),
+    clicked_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    ip_address VARCHAR(45),
+    user_agent TEXT
+);
+
+CREATE TABLE IF NOT EXISTS contacts
+(
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id    UUID         NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants (id) ON DELETE CASCADE,
+    phone_number VARCHAR(20)  NOT NULL,
+    name         VARCHAR(255) NOT NULL DEFAULT '',
+    attributes   JSONB        NOT NULL DEFAULT '{}'::jsonb,
+    suppressed   BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at   TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS contact_lists
+(
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id   UUID         NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES tenants (id) ON DELETE CASCADE,
+    name        VARCHAR(255) NOT NULL,
+    description TEXT         NOT NULL DEFAULT '',
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS contact_list_members
+(
+    list_id    UUID NOT NULL REFERENCES contact_lists (id) ON DELETE CASCADE,
+    contact_id UUID NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+    added_at   TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (list_id, contact_id)
+);
+
+CREATE TABLE IF NOT EXISTS audit_logs
+(
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id    UUID,
+    actor_id     VARCHAR(255) NOT NULL,
+    actor_name   VARCHAR(255) NOT NULL DEFAULT '',
+    auth_method  VARCHAR(20)  NOT NULL DEFAULT '',
+    ip_address   VARCHAR(45)  NOT NULL DEFAULT '',
+    action       VARCHAR(50)  NOT NULL,
+    target_type  VARCHAR(50)  NOT NULL,
+    target_id    VARCHAR(255) NOT NULL DEFAULT '',
+    before_state JSONB,
+    after_state  JSONB,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
 -- Create indexes for better performance
 CREATE INDEX IF NOT EXISTS idx_messages_status ON messages(status);
 CREATE INDEX IF NOT EXISTS idx_messages_created_at ON messages(created_at);
 CREATE INDEX IF NOT EXISTS idx_messages_phone_number ON messages(phone_number);
 CREATE INDEX IF NOT EXISTS idx_messages_sent_at ON messages(sent_at);
+CREATE INDEX IF NOT EXISTS idx_messages_campaign ON messages(campaign);
+CREATE INDEX IF NOT EXISTS idx_messages_tenant_status ON messages(tenant_id, status, created_at);
+CREATE INDEX IF NOT EXISTS idx_short_links_message_id ON short_links(message_id);
+CREATE INDEX IF NOT EXISTS idx_short_links_campaign ON short_links(campaign);
+CREATE INDEX IF NOT EXISTS idx_link_clicks_code ON link_clicks(code);
+CREATE INDEX IF NOT EXISTS idx_contact_list_members_contact_id ON contact_list_members(contact_id);
+-- A phone number is unique per tenant, not across tenants
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contacts_tenant_phone_number ON contacts(tenant_id, phone_number);
+CREATE INDEX IF NOT EXISTS idx_contact_lists_tenant ON contact_lists(tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_short_links_tenant_campaign ON short_links(tenant_id, campaign);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs(target_type, target_id);
 
 CREATE
 OR REPLACE FUNCTION update_updated_at_column()
@@ -68,6 +266,22 @@ CREATE TRIGGER update_messages_updated_at
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+CREATE
+OR REPLACE FUNCTION prevent_audit_log_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$
+LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+CREATE TRIGGER audit_logs_append_only
+    BEFORE UPDATE OR DELETE
+    ON audit_logs
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_audit_log_change();
+
 INSERT INTO messages (content, phone_number, status)
 VALUES ('Test message 1', '+1234567890', 'pending'),
        ('Test message 2', '+1234567891', 'pending'),